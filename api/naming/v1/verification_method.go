@@ -0,0 +1,37 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+// VerificationMethod identifies how a DomainVerification's key was proven to
+// belong to the record's domain. DomainVerification.Method carries the
+// string value of one of these constants.
+type VerificationMethod string
+
+const (
+	// VerificationMethodDNS indicates verification via a domain-published DNS
+	// TXT record containing the signer's public key.
+	VerificationMethodDNS VerificationMethod = "dns"
+
+	// VerificationMethodWellKnown indicates verification via a domain-hosted
+	// JWKS well-known file (RFC 7517).
+	VerificationMethodWellKnown VerificationMethod = "wellknown"
+
+	// VerificationMethodDNSChallenge indicates verification via an ACME
+	// DNS-01-style challenge: the domain publishes the RFC 7638 JWK
+	// thumbprint of the signer's key rather than the key itself.
+	VerificationMethodDNSChallenge VerificationMethod = "dns-challenge"
+
+	// VerificationMethodHTTPChallenge indicates verification via an ACME
+	// HTTP-01-style challenge: the domain serves the signer's JWK thumbprint
+	// as a static file, for domain owners who cannot host a JWKS endpoint.
+	VerificationMethodHTTPChallenge VerificationMethod = "http-challenge"
+
+	// VerificationMethodOIDC indicates verification via a Fulcio-issued
+	// certificate's OIDC issuer/subject SAN rather than a domain-published
+	// artifact.
+	VerificationMethodOIDC VerificationMethod = "oidc"
+
+	// VerificationMethodNone indicates no verification was possible.
+	VerificationMethodNone VerificationMethod = "none"
+)
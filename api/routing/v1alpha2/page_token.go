@@ -0,0 +1,47 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routingv1alpha2
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errMalformedPageToken = errors.New("missing separator")
+
+// EncodePageToken builds an opaque page token from the last-emitted
+// (peerID, digest) pair, so a resumed List call can skip everything up to and
+// including that item.
+func EncodePageToken(peerID, digest string) string {
+	if peerID == "" && digest == "" {
+		return ""
+	}
+
+	raw := peerID + "\x00" + digest
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodePageToken recovers the (peerID, digest) pair encoded by
+// EncodePageToken. An empty token decodes to two empty strings, matching the
+// "start from the beginning" request.
+func DecodePageToken(token string) (string, string, error) {
+	if token == "" {
+		return "", "", nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid page token: %w", err)
+	}
+
+	peerID, digest, ok := strings.Cut(string(raw), "\x00")
+	if !ok {
+		return "", "", fmt.Errorf("invalid page token: %w", errMalformedPageToken)
+	}
+
+	return peerID, digest, nil
+}
@@ -0,0 +1,300 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package routingv1alpha2 defines the v1alpha2 routing service, which layers
+// cursor-based pagination on top of the legacy, all-at-once List request.
+package routingv1alpha2
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Peer identifies a network peer participating in routing.
+type Peer struct {
+	Id string
+}
+
+func (p *Peer) GetId() string {
+	if p == nil {
+		return ""
+	}
+
+	return p.Id
+}
+
+// ObjectRef identifies a published record by its content-addressable
+// identifiers.
+type ObjectRef struct {
+	Cid    string
+	Digest string
+}
+
+func (o *ObjectRef) GetCid() string {
+	if o == nil {
+		return ""
+	}
+
+	return o.Cid
+}
+
+func (o *ObjectRef) GetDigest() string {
+	if o == nil {
+		return ""
+	}
+
+	return o.Digest
+}
+
+// LegacyListRequest mirrors the pre-pagination List request shape. It is kept
+// as its own message so existing callers that don't set PageSize/PageToken
+// keep working unchanged.
+type LegacyListRequest struct {
+	Peer   *Peer
+	Labels []string
+}
+
+func (r *LegacyListRequest) GetPeer() *Peer {
+	if r == nil {
+		return nil
+	}
+
+	return r.Peer
+}
+
+func (r *LegacyListRequest) GetLabels() []string {
+	if r == nil {
+		return nil
+	}
+
+	return r.Labels
+}
+
+// ListRequest requests a page of published records matching the embedded
+// LegacyListRequest. PageSize bounds the number of items returned in a single
+// response; PageToken resumes a previously interrupted listing from the
+// last-emitted (peer_id, digest) pair, as returned in ListResponse.NextPageToken.
+type ListRequest struct {
+	LegacyListRequest *LegacyListRequest
+	PageSize          int32
+	PageToken         string
+}
+
+func (r *ListRequest) GetLegacyListRequest() *LegacyListRequest {
+	if r == nil {
+		return nil
+	}
+
+	return r.LegacyListRequest
+}
+
+func (r *ListRequest) GetPeer() *Peer {
+	return r.GetLegacyListRequest().GetPeer()
+}
+
+func (r *ListRequest) GetLabels() []string {
+	return r.GetLegacyListRequest().GetLabels()
+}
+
+func (r *ListRequest) GetPageSize() int32 {
+	if r == nil {
+		return 0
+	}
+
+	return r.PageSize
+}
+
+func (r *ListRequest) GetPageToken() string {
+	if r == nil {
+		return ""
+	}
+
+	return r.PageToken
+}
+
+// ListResponse_Item is a single published record surfaced by a List call. //nolint:revive,stylecheck
+type ListResponse_Item struct { //nolint:revive,stylecheck
+	Peer        *Peer
+	Labels      []string
+	Ref         *ObjectRef
+	Record      *ObjectRef
+	LabelCounts map[string]int64
+}
+
+func (i *ListResponse_Item) GetPeer() *Peer { //nolint:revive,stylecheck
+	if i == nil {
+		return nil
+	}
+
+	return i.Peer
+}
+
+func (i *ListResponse_Item) GetLabels() []string { //nolint:revive,stylecheck
+	if i == nil {
+		return nil
+	}
+
+	return i.Labels
+}
+
+func (i *ListResponse_Item) GetRef() *ObjectRef { //nolint:revive,stylecheck
+	if i == nil {
+		return nil
+	}
+
+	return i.Ref
+}
+
+func (i *ListResponse_Item) GetRecord() *ObjectRef { //nolint:revive,stylecheck
+	if i == nil {
+		return nil
+	}
+
+	return i.Record
+}
+
+func (i *ListResponse_Item) GetLabelCounts() map[string]int64 { //nolint:revive,stylecheck
+	if i == nil {
+		return nil
+	}
+
+	return i.LabelCounts
+}
+
+// ListResponse carries one page of List results. NextPageToken is empty once
+// the listing is exhausted, and opaque otherwise - callers must not attempt to
+// interpret it beyond passing it back as ListRequest.PageToken.
+type ListResponse struct {
+	Items         []*ListResponse_Item //nolint:revive,stylecheck
+	NextPageToken string
+}
+
+func (r *ListResponse) GetItems() []*ListResponse_Item {
+	if r == nil {
+		return nil
+	}
+
+	return r.Items
+}
+
+func (r *ListResponse) GetNextPageToken() string {
+	if r == nil {
+		return ""
+	}
+
+	return r.NextPageToken
+}
+
+// PublishRequest announces that the caller is providing Record.
+type PublishRequest struct {
+	Record  *ObjectRef
+	Network bool
+}
+
+func (r *PublishRequest) GetRecord() *ObjectRef {
+	if r == nil {
+		return nil
+	}
+
+	return r.Record
+}
+
+func (r *PublishRequest) GetNetwork() bool {
+	if r == nil {
+		return false
+	}
+
+	return r.Network
+}
+
+// UnpublishRequest withdraws a prior Publish announcement for Record.
+type UnpublishRequest struct {
+	Record  *ObjectRef
+	Network bool
+}
+
+func (r *UnpublishRequest) GetRecord() *ObjectRef {
+	if r == nil {
+		return nil
+	}
+
+	return r.Record
+}
+
+func (r *UnpublishRequest) GetNetwork() bool {
+	if r == nil {
+		return false
+	}
+
+	return r.Network
+}
+
+// SearchRequest matches published records against Labels across the network.
+type SearchRequest struct {
+	Labels []string
+}
+
+func (r *SearchRequest) GetLabels() []string {
+	if r == nil {
+		return nil
+	}
+
+	return r.Labels
+}
+
+// SearchResponse carries one page of Search results.
+type SearchResponse struct {
+	Items []*ListResponse_Item
+}
+
+func (r *SearchResponse) GetItems() []*ListResponse_Item {
+	if r == nil {
+		return nil
+	}
+
+	return r.Items
+}
+
+// RoutingService_ListServer streams paginated List responses back to the caller. //nolint:revive,stylecheck
+type RoutingService_ListServer interface { //nolint:revive,stylecheck
+	Send(*ListResponse) error
+	grpc.ServerStream
+}
+
+// RoutingService_SearchServer streams Search responses back to the caller. //nolint:revive,stylecheck
+type RoutingService_SearchServer interface { //nolint:revive,stylecheck
+	Send(*SearchResponse) error
+	grpc.ServerStream
+}
+
+// RoutingServiceServer is the v1alpha2 routing gRPC service.
+type RoutingServiceServer interface {
+	Publish(context.Context, *PublishRequest) (*emptypb.Empty, error)
+	Unpublish(context.Context, *UnpublishRequest) (*emptypb.Empty, error)
+	Search(*SearchRequest, RoutingService_SearchServer) error
+	List(*ListRequest, RoutingService_ListServer) error
+}
+
+// UnimplementedRoutingServiceServer must be embedded by RoutingServiceServer
+// implementations for forward compatibility with new methods.
+type UnimplementedRoutingServiceServer struct{}
+
+func (UnimplementedRoutingServiceServer) Publish(context.Context, *PublishRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+
+func (UnimplementedRoutingServiceServer) Unpublish(context.Context, *UnpublishRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unpublish not implemented")
+}
+
+func (UnimplementedRoutingServiceServer) Search(*SearchRequest, RoutingService_SearchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+
+func (UnimplementedRoutingServiceServer) List(*ListRequest, RoutingService_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
@@ -0,0 +1,284 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package searchv1alpha2
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MatchMode selects how a QueryTerm's value is compared against a record
+// field's value, extending the plain exact-match semantics that
+// RecordQueryType alone provides.
+type MatchMode string
+
+const (
+	// MatchModeExact requires an exact, case-insensitive match.
+	MatchModeExact MatchMode = "exact"
+	// MatchModePrefix requires the field value to start with Value.
+	MatchModePrefix MatchMode = "prefix"
+	// MatchModeGlob matches Value as a shell glob pattern (*, ?, [...]).
+	MatchModeGlob MatchMode = "glob"
+	// MatchModeRegex matches Value as a regular expression.
+	MatchModeRegex MatchMode = "regex"
+	// MatchModeRange compares Value, which carries its own comparison
+	// operator prefix (e.g. ">=1.2.0"), against the field as a semver.
+	MatchModeRange MatchMode = "range"
+)
+
+// ValidMatchModes lists every match mode ParseQuery can produce.
+var ValidMatchModes = []string{
+	string(MatchModeExact),
+	string(MatchModePrefix),
+	string(MatchModeGlob),
+	string(MatchModeRegex),
+	string(MatchModeRange),
+}
+
+// BoolOp composes child expressions in a QueryExpr tree.
+type BoolOp string
+
+const (
+	// BoolAnd requires every child to match.
+	BoolAnd BoolOp = "AND"
+	// BoolOr requires at least one child to match.
+	BoolOr BoolOp = "OR"
+	// BoolNot negates its single child.
+	BoolNot BoolOp = "NOT"
+)
+
+// QueryTerm is a single field/value comparison, extending the plain
+// name=value RecordQuery with an explicit match mode.
+type QueryTerm struct {
+	Type  RecordQueryType
+	Value string
+	Mode  MatchMode
+}
+
+// QueryExpr is a node in a boolean query tree produced by ParseQuery: either
+// a Term leaf, or a boolean combination of Children. For BoolNot, Children
+// holds exactly one element.
+type QueryExpr struct {
+	Term     *QueryTerm
+	Op       BoolOp
+	Children []*QueryExpr
+}
+
+// NewTermExpr wraps term as a leaf QueryExpr.
+func NewTermExpr(term *QueryTerm) *QueryExpr {
+	return &QueryExpr{Term: term}
+}
+
+// NewBoolExpr combines children under op.
+func NewBoolExpr(op BoolOp, children ...*QueryExpr) *QueryExpr {
+	return &QueryExpr{Op: op, Children: children}
+}
+
+// Evaluate reports whether fields - a field name (RecordQueryType_name form,
+// e.g. "skill-name") to its record's values - satisfies e. A term whose field
+// is absent from fields never matches.
+func (e *QueryExpr) Evaluate(fields map[string][]string) (bool, error) {
+	if e == nil {
+		return false, nil
+	}
+
+	if e.Term != nil {
+		return e.Term.evaluate(fields)
+	}
+
+	switch e.Op {
+	case BoolNot:
+		if len(e.Children) != 1 {
+			return false, fmt.Errorf("NOT expects exactly one child expression, got %d", len(e.Children))
+		}
+
+		matched, err := e.Children[0].Evaluate(fields)
+		if err != nil {
+			return false, err
+		}
+
+		return !matched, nil
+
+	case BoolAnd:
+		for _, child := range e.Children {
+			matched, err := child.Evaluate(fields)
+			if err != nil {
+				return false, err
+			}
+
+			if !matched {
+				return false, nil
+			}
+		}
+
+		return true, nil
+
+	case BoolOr:
+		for _, child := range e.Children {
+			matched, err := child.Evaluate(fields)
+			if err != nil {
+				return false, err
+			}
+
+			if matched {
+				return true, nil
+			}
+		}
+
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("unknown boolean operator %q", e.Op)
+	}
+}
+
+// evaluate reports whether any of fields[fieldName(t.Type)] satisfies t.
+func (t *QueryTerm) evaluate(fields map[string][]string) (bool, error) {
+	name, ok := RecordQueryType_name[int32(t.Type)] //nolint:staticcheck
+	if !ok {
+		return false, fmt.Errorf("unknown query type %v", t.Type)
+	}
+
+	values, ok := fields[name]
+	if !ok {
+		return false, nil
+	}
+
+	for _, value := range values {
+		matched, err := t.matches(value)
+		if err != nil {
+			return false, err
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (t *QueryTerm) matches(value string) (bool, error) {
+	switch t.Mode {
+	case "", MatchModeExact:
+		return strings.EqualFold(value, t.Value), nil
+
+	case MatchModePrefix:
+		return len(value) >= len(t.Value) && strings.EqualFold(value[:len(t.Value)], t.Value), nil
+
+	case MatchModeGlob:
+		matched, err := path.Match(strings.ToLower(t.Value), strings.ToLower(value))
+		if err != nil {
+			return false, fmt.Errorf("invalid glob pattern %q: %w", t.Value, err)
+		}
+
+		return matched, nil
+
+	case MatchModeRegex:
+		re, err := regexp.Compile(t.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid regex pattern %q: %w", t.Value, err)
+		}
+
+		return re.MatchString(value), nil
+
+	case MatchModeRange:
+		return matchSemverRange(value, t.Value)
+
+	default:
+		return false, fmt.Errorf("unknown match mode %q", t.Mode)
+	}
+}
+
+// matchSemverRange evaluates value against a range expression carrying its
+// own comparison operator prefix (e.g. ">=1.2.0"), same convention as
+// server/database/utils.ParseComparisonOperator.
+func matchSemverRange(value, rangeExpr string) (bool, error) {
+	op, rawWant := cutComparisonOperator(rangeExpr)
+	if op == "" {
+		op, rawWant = "=", rangeExpr
+	}
+
+	got, err := parseSemver(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %q as semver: %w", value, err)
+	}
+
+	want, err := parseSemver(rawWant)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %q as semver: %w", rawWant, err)
+	}
+
+	cmp := compareSemver(got, want)
+
+	switch op {
+	case "=":
+		return cmp == 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	default:
+		return false, fmt.Errorf("unsupported range operator %q", op)
+	}
+}
+
+// cutComparisonOperator splits a range value into its comparison operator
+// prefix (>=, <=, >, <, =) and the remaining value. It returns an empty
+// operator when value has none.
+func cutComparisonOperator(value string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if rest, found := strings.CutPrefix(value, op); found {
+			return op, rest
+		}
+	}
+
+	return "", value
+}
+
+// parseSemver parses a major.minor.patch version, ignoring any pre-release
+// or build metadata suffix (anything from the first '-' or '+' onward).
+func parseSemver(version string) ([3]int, error) {
+	var parsed [3]int
+
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := strings.SplitN(version, ".", 3) //nolint:mnd
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return parsed, fmt.Errorf("invalid version segment %q: %w", part, err)
+		}
+
+		parsed[i] = n
+	}
+
+	return parsed, nil
+}
+
+// compareSemver returns -1, 0, or 1 as a is less than, equal to, or greater
+// than b.
+func compareSemver(a, b [3]int) int {
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+
+	return 0
+}
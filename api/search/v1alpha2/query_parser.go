@@ -0,0 +1,266 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package searchv1alpha2
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// twoCharOperators must be tried before their one-character prefixes below,
+// so that e.g. ">=" isn't parsed as ">" followed by a value of "=1.2.0".
+var twoCharOperators = []struct {
+	token string
+	mode  MatchMode
+}{
+	{"==", MatchModeExact},
+	{"^=", MatchModePrefix},
+	{"~=", MatchModeGlob},
+	{"=~", MatchModeRegex},
+	{">=", MatchModeRange},
+	{"<=", MatchModeRange},
+}
+
+var oneCharOperators = []struct {
+	token string
+	mode  MatchMode
+}{
+	{"=", MatchModeExact},
+	{">", MatchModeRange},
+	{"<", MatchModeRange},
+}
+
+// ParseQuery parses a human-readable query string, e.g.
+// "skill-name~=chat* AND version>=1.2.0", into a QueryExpr tree.
+//
+// Terms are "<field><op><value>" with no surrounding whitespace (e.g.
+// "version>=1.2.0"); NOT binds tighter than AND, which binds tighter than
+// OR; parentheses group sub-expressions. Boolean keywords (AND/OR/NOT) are
+// case-insensitive.
+func ParseQuery(input string) (*QueryExpr, error) {
+	tokens := tokenizeQuery(input)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &queryParser{tokens: tokens}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q", p.tokens[p.pos])
+	}
+
+	return expr, nil
+}
+
+// tokenizeQuery splits input into parentheses and whitespace-delimited
+// words, so that "(a=1 AND b=2)" becomes ["(", "a=1", "AND", "b=2", ")"].
+func tokenizeQuery(input string) []string {
+	var tokens []string
+
+	var cur strings.Builder
+
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for _, r := range input {
+		switch {
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return tokens
+}
+
+type queryParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *queryParser) peek() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+func (p *queryParser) parseOr() (*QueryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*QueryExpr{left}
+
+	for {
+		tok, ok := p.peek()
+		if !ok || !strings.EqualFold(tok, string(BoolOr)) {
+			break
+		}
+
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		children = append(children, right)
+	}
+
+	if len(children) == 1 {
+		return children[0], nil
+	}
+
+	return NewBoolExpr(BoolOr, children...), nil
+}
+
+func (p *queryParser) parseAnd() (*QueryExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*QueryExpr{left}
+
+	for {
+		tok, ok := p.peek()
+		if !ok || !strings.EqualFold(tok, string(BoolAnd)) {
+			break
+		}
+
+		p.pos++
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		children = append(children, right)
+	}
+
+	if len(children) == 1 {
+		return children[0], nil
+	}
+
+	return NewBoolExpr(BoolAnd, children...), nil
+}
+
+func (p *queryParser) parseNot() (*QueryExpr, error) {
+	tok, ok := p.peek()
+	if ok && strings.EqualFold(tok, string(BoolNot)) {
+		p.pos++
+
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewBoolExpr(BoolNot, child), nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (*QueryExpr, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+
+	if tok == "(" {
+		p.pos++
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		closing, ok := p.peek()
+		if !ok || closing != ")" {
+			return nil, fmt.Errorf("expected closing ')'")
+		}
+
+		p.pos++
+
+		return expr, nil
+	}
+
+	p.pos++
+
+	term, err := parseTerm(tok)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTermExpr(term), nil
+}
+
+// parseTerm parses a single "<field><op><value>" token into a QueryTerm.
+func parseTerm(tok string) (*QueryTerm, error) {
+	for _, op := range twoCharOperators {
+		if field, value, found := strings.Cut(tok, op.token); found {
+			if op.mode == MatchModeRange {
+				return newTerm(field, op.token+value, op.mode)
+			}
+
+			return newTerm(field, value, op.mode)
+		}
+	}
+
+	for _, op := range oneCharOperators {
+		if field, value, found := strings.Cut(tok, op.token); found {
+			// Range comparisons keep their operator prefix on Value so
+			// QueryTerm.Evaluate's semver comparison knows which direction to
+			// compare in, matching server/database/utils.ParseComparisonOperator.
+			if op.mode == MatchModeRange {
+				return newTerm(field, op.token+value, op.mode)
+			}
+
+			return newTerm(field, value, op.mode)
+		}
+	}
+
+	return nil, fmt.Errorf("invalid term %q, expected '<field><op><value>'", tok)
+}
+
+func newTerm(field, value string, mode MatchMode) (*QueryTerm, error) {
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return nil, fmt.Errorf("invalid term: empty field")
+	}
+
+	if value == "" {
+		return nil, fmt.Errorf("invalid term: empty value for field %q", field)
+	}
+
+	queryType, ok := RecordQueryType_value[field]
+	if !ok || field == "unspecified" {
+		return nil, fmt.Errorf("invalid query field %q, valid fields are: %v", field, ValidQueryTypes)
+	}
+
+	return &QueryTerm{
+		Type:  RecordQueryType(queryType),
+		Value: value,
+		Mode:  mode,
+	}, nil
+}
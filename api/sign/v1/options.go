@@ -26,11 +26,12 @@ var (
 
 	// DefaultVerifyOptionsOIDC provides default values for OIDC-based verification.
 	DefaultVerifyOptionsOIDC = &VerifyOptionsOIDC{
-		TufMirrorUrl:    DefaultTUFMirrorURL,
-		TrustedRootPath: "",
-		IgnoreTlog:      false,
-		IgnoreTsa:       false,
-		IgnoreSct:       false,
+		TufMirrorUrl:     DefaultTUFMirrorURL,
+		TrustedRootPath:  "",
+		IgnoreTlog:       false,
+		IgnoreTsa:        false,
+		IgnoreSct:        false,
+		TsaCertChainPath: "",
 	}
 )
 
@@ -58,11 +59,12 @@ func (x *VerifyOptionsOIDC) GetDefaultOptions() *VerifyOptionsOIDC {
 	}
 
 	return &VerifyOptionsOIDC{
-		TufMirrorUrl:    valueOrDefault(x.GetTufMirrorUrl(), DefaultVerifyOptionsOIDC.GetTufMirrorUrl()),
-		TrustedRootPath: x.GetTrustedRootPath(), // No default, keep user value
-		IgnoreTlog:      valueOrDefault(x.GetIgnoreTlog(), DefaultVerifyOptionsOIDC.GetIgnoreTlog()),
-		IgnoreTsa:       valueOrDefault(x.GetIgnoreTsa(), DefaultVerifyOptionsOIDC.GetIgnoreTsa()),
-		IgnoreSct:       valueOrDefault(x.GetIgnoreSct(), DefaultVerifyOptionsOIDC.GetIgnoreSct()),
+		TufMirrorUrl:     valueOrDefault(x.GetTufMirrorUrl(), DefaultVerifyOptionsOIDC.GetTufMirrorUrl()),
+		TrustedRootPath:  x.GetTrustedRootPath(), // No default, keep user value
+		IgnoreTlog:       valueOrDefault(x.GetIgnoreTlog(), DefaultVerifyOptionsOIDC.GetIgnoreTlog()),
+		IgnoreTsa:        valueOrDefault(x.GetIgnoreTsa(), DefaultVerifyOptionsOIDC.GetIgnoreTsa()),
+		IgnoreSct:        valueOrDefault(x.GetIgnoreSct(), DefaultVerifyOptionsOIDC.GetIgnoreSct()),
+		TsaCertChainPath: x.GetTsaCertChainPath(), // No default, keep user value
 	}
 }
 
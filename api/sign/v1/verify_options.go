@@ -0,0 +1,329 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+// VerifyOptions specifies criteria that a record's signatures must match.
+// A nil VerifyOptions (or a VerifyOptions with all fields nil) matches any
+// valid signature.
+type VerifyOptions struct {
+	// Key, if set, restricts verification to a signature matching this
+	// specific public key.
+	Key *KeyVerifyOptions
+
+	// Oidc, if set, restricts verification to an OIDC/Sigstore bundle
+	// signature matching this exact issuer/identity.
+	Oidc *OIDCVerifyOptions
+
+	// Keyless, if set, restricts verification to an OIDC/Sigstore bundle
+	// signature whose Fulcio certificate matches these identity patterns.
+	// Unlike Oidc, Keyless matches by regular expression, for callers that
+	// don't know the exact issuer/identity in advance.
+	Keyless *KeylessVerifyOptions
+}
+
+func (x *VerifyOptions) GetKey() *KeyVerifyOptions {
+	if x != nil {
+		return x.Key
+	}
+
+	return nil
+}
+
+func (x *VerifyOptions) GetOidc() *OIDCVerifyOptions {
+	if x != nil {
+		return x.Oidc
+	}
+
+	return nil
+}
+
+func (x *VerifyOptions) GetKeyless() *KeylessVerifyOptions {
+	if x != nil {
+		return x.Keyless
+	}
+
+	return nil
+}
+
+// KeyVerifyOptions restricts verification to a specific public key.
+type KeyVerifyOptions struct {
+	// PublicKey is the PEM-encoded public key the signature must verify against.
+	PublicKey string
+}
+
+func (x *KeyVerifyOptions) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+
+	return ""
+}
+
+// OIDCVerifyOptions restricts verification to an OIDC/Sigstore signature
+// from a specific issuer and/or identity (exact match).
+type OIDCVerifyOptions struct {
+	// Issuer is the expected OIDC issuer URL. Empty means any issuer.
+	Issuer string
+
+	// Identity is the expected OIDC subject/identity. Empty means any identity.
+	Identity string
+
+	// TrustRoot overrides the Sigstore trust root used to verify the bundle.
+	// If nil, the public good instance is used.
+	TrustRoot *TrustRoot
+}
+
+func (x *OIDCVerifyOptions) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+
+	return ""
+}
+
+func (x *OIDCVerifyOptions) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+
+	return ""
+}
+
+func (x *OIDCVerifyOptions) GetTrustRoot() *TrustRoot {
+	if x != nil {
+		return x.TrustRoot
+	}
+
+	return nil
+}
+
+// KeylessVerifyOptions restricts verification to a Fulcio-issued, keyless
+// Sigstore signature whose signing certificate matches the given identity
+// patterns.
+type KeylessVerifyOptions struct {
+	// CertificateIdentity is a regular expression the signing certificate's
+	// identity (the SAN: email or URI) must match. Empty matches any identity.
+	CertificateIdentity string
+
+	// CertificateOidcIssuer is a regular expression the signing certificate's
+	// OIDC issuer extension (OID 1.3.6.1.4.1.57264.1.1) must match. Empty
+	// matches any issuer.
+	CertificateOidcIssuer string
+
+	// RekorURL selects the Rekor transparency log instance to verify the
+	// signature's inclusion proof against. Empty uses the Sigstore public
+	// good instance.
+	RekorURL string
+
+	// FulcioRoots is a PEM-encoded bundle of Fulcio CA certificates to trust.
+	// Empty uses the Sigstore public good instance.
+	FulcioRoots string
+}
+
+func (x *KeylessVerifyOptions) GetCertificateIdentity() string {
+	if x != nil {
+		return x.CertificateIdentity
+	}
+
+	return ""
+}
+
+func (x *KeylessVerifyOptions) GetCertificateOidcIssuer() string {
+	if x != nil {
+		return x.CertificateOidcIssuer
+	}
+
+	return ""
+}
+
+func (x *KeylessVerifyOptions) GetRekorURL() string {
+	if x != nil {
+		return x.RekorURL
+	}
+
+	return ""
+}
+
+func (x *KeylessVerifyOptions) GetFulcioRoots() string {
+	if x != nil {
+		return x.FulcioRoots
+	}
+
+	return ""
+}
+
+// TrustRoot carries PEM-encoded Sigstore trust material for OIDC/keyless
+// verification.
+type TrustRoot struct {
+	FulcioRootPem              string
+	RekorPublicKeyPem          string
+	TimestampAuthorityRootsPem string
+	CtLogPublicKeysPem         string
+}
+
+func (x *TrustRoot) GetFulcioRootPem() string {
+	if x != nil {
+		return x.FulcioRootPem
+	}
+
+	return ""
+}
+
+func (x *TrustRoot) GetRekorPublicKeyPem() string {
+	if x != nil {
+		return x.RekorPublicKeyPem
+	}
+
+	return ""
+}
+
+func (x *TrustRoot) GetTimestampAuthorityRootsPem() string {
+	if x != nil {
+		return x.TimestampAuthorityRootsPem
+	}
+
+	return ""
+}
+
+func (x *TrustRoot) GetCtLogPublicKeysPem() string {
+	if x != nil {
+		return x.CtLogPublicKeysPem
+	}
+
+	return ""
+}
+
+// SignerInfo identifies the signer of one valid record signature.
+type SignerInfo struct {
+	// SignerType is one of SignerInfo_Key, SignerInfo_Oidc, or SignerInfo_Keyless.
+	SignerType isSignerInfo_SignerType
+}
+
+type isSignerInfo_SignerType interface {
+	isSignerInfo_SignerType()
+}
+
+type SignerInfo_Key struct {
+	Key *KeySignerInfo
+}
+
+func (*SignerInfo_Key) isSignerInfo_SignerType() {}
+
+type SignerInfo_Oidc struct {
+	Oidc *OIDCSignerInfo
+}
+
+func (*SignerInfo_Oidc) isSignerInfo_SignerType() {}
+
+type SignerInfo_Keyless struct {
+	Keyless *KeylessSignerInfo
+}
+
+func (*SignerInfo_Keyless) isSignerInfo_SignerType() {}
+
+func (x *SignerInfo) GetKey() *KeySignerInfo {
+	if x != nil {
+		if k, ok := x.SignerType.(*SignerInfo_Key); ok {
+			return k.Key
+		}
+	}
+
+	return nil
+}
+
+func (x *SignerInfo) GetOidc() *OIDCSignerInfo {
+	if x != nil {
+		if o, ok := x.SignerType.(*SignerInfo_Oidc); ok {
+			return o.Oidc
+		}
+	}
+
+	return nil
+}
+
+func (x *SignerInfo) GetKeyless() *KeylessSignerInfo {
+	if x != nil {
+		if k, ok := x.SignerType.(*SignerInfo_Keyless); ok {
+			return k.Keyless
+		}
+	}
+
+	return nil
+}
+
+// KeySignerInfo identifies a signer verified via a static public key.
+type KeySignerInfo struct {
+	PublicKey string
+}
+
+func (x *KeySignerInfo) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+
+	return ""
+}
+
+// OIDCSignerInfo identifies a signer verified via an OIDC/Sigstore bundle
+// with an exact issuer/identity match.
+type OIDCSignerInfo struct {
+	Issuer   string
+	Identity string
+}
+
+func (x *OIDCSignerInfo) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+
+	return ""
+}
+
+func (x *OIDCSignerInfo) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+
+	return ""
+}
+
+// KeylessSignerInfo identifies a signer verified through a Fulcio-issued,
+// keyless Sigstore certificate matched by identity pattern rather than an
+// exact issuer/identity.
+type KeylessSignerInfo struct {
+	// Issuer is the OIDC issuer recorded in the signing certificate.
+	Issuer string
+
+	// Identity is the certificate SAN (email or URI) identifying the signer.
+	Identity string
+}
+
+func (x *KeylessSignerInfo) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+
+	return ""
+}
+
+func (x *KeylessSignerInfo) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+
+	return ""
+}
+
+// PublicKey represents a public key published as a record referrer.
+type PublicKey struct {
+	Key string
+}
+
+func (x *PublicKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+
+	return ""
+}
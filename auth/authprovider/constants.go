@@ -9,4 +9,9 @@ package authprovider
 const (
 	// ProviderGithub is the identifier for GitHub OAuth2 authentication.
 	ProviderGithub = "github"
+
+	// ProviderDirDevice is the identifier for tokens issued by the dir
+	// server's own RFC 8628 device authorization grant server
+	// (server/deviceauth), as opposed to an external identity provider.
+	ProviderDirDevice = "dir-device"
 )
@@ -0,0 +1,123 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deviceauth implements authprovider.Provider for access tokens
+// issued by the dir server's own RFC 8628 device authorization grant server
+// (server/deviceauth), by calling that server's POST /token/introspect
+// endpoint. This lets the Casbin-backed auth/authzserver authorize
+// device-flow tokens with the same role-based policies it already applies
+// to GitHub-issued tokens, without needing direct access to the dir
+// server's datastore.
+package deviceauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/agntcy/dir/auth/authprovider"
+)
+
+// defaultTimeout bounds how long an introspection request may take before
+// the provider gives up and denies the token.
+const defaultTimeout = 5 * time.Second
+
+var _ authprovider.Provider = (*Provider)(nil)
+
+// introspectResponse mirrors server/deviceauth's introspection response.
+type introspectResponse struct {
+	Active   bool   `json:"active"`
+	Username string `json:"username,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+}
+
+// Provider validates bearer tokens by introspecting them against a
+// server/deviceauth instance's /token/introspect endpoint.
+type Provider struct {
+	introspectURL string
+	httpClient    *http.Client
+}
+
+// NewProvider creates a Provider that introspects tokens against
+// introspectURL, the fully-qualified /token/introspect endpoint of a
+// server/deviceauth instance (e.g. "http://dir-server:8890/token/introspect").
+// A nil httpClient uses a client with defaultTimeout.
+func NewProvider(introspectURL string, httpClient *http.Client) *Provider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &Provider{
+		introspectURL: introspectURL,
+		httpClient:    httpClient,
+	}
+}
+
+// Name implements authprovider.Provider.
+func (p *Provider) Name() string {
+	return authprovider.ProviderDirDevice
+}
+
+// ValidateToken implements authprovider.Provider by introspecting token
+// against the configured server/deviceauth instance.
+func (p *Provider) ValidateToken(ctx context.Context, token string) (*authprovider.UserIdentity, error) {
+	resp, err := p.introspect(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Active {
+		return nil, errors.New("token is not active")
+	}
+
+	return &authprovider.UserIdentity{
+		Provider: authprovider.ProviderDirDevice,
+		UserID:   resp.Username,
+		Username: resp.Username,
+		Attributes: map[string]string{
+			"client_id": resp.ClientID,
+			"scope":     resp.Scope,
+		},
+	}, nil
+}
+
+// GetOrgConstructs implements authprovider.Provider. Device-flow tokens
+// have no org/tenant/domain concept of their own, so authorization for them
+// relies entirely on user-to-role (not org-to-role) Casbin policies.
+func (p *Provider) GetOrgConstructs(_ context.Context, _ string) ([]authprovider.OrgConstruct, error) {
+	return nil, nil //nolint:nilnil
+}
+
+func (p *Provider) introspect(ctx context.Context, token string) (*introspectResponse, error) {
+	body := strings.NewReader(url.Values{"token": {token}}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.introspectURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build introspection request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach device auth introspection endpoint: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device auth introspection endpoint returned status %d", httpResp.StatusCode)
+	}
+
+	var resp introspectResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode introspection response: %w", err)
+	}
+
+	return &resp, nil
+}
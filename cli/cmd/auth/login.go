@@ -13,6 +13,7 @@ import (
 
 	"github.com/agntcy/dir/auth/authprovider/github"
 	"github.com/agntcy/dir/client"
+	"github.com/agntcy/dir/client/presets"
 	"github.com/spf13/cobra"
 )
 
@@ -306,11 +307,10 @@ func runDeviceFlow(cmd *cobra.Command, ctx context.Context, cache *client.TokenC
 	}
 
 	// Start device flow
-	result, err := client.StartDeviceFlow(ctx, &client.DeviceFlowConfig{
-		ClientID: githubCLIClientID,
-		Scopes:   scopeList,
-		Output:   cmd.OutOrStdout(),
-	})
+	deviceAuthConfig := presets.GitHub(githubCLIClientID, scopeList)
+	deviceAuthConfig.Output = cmd.OutOrStdout()
+
+	result, err := client.StartDeviceFlow(ctx, deviceAuthConfig)
 	if err != nil {
 		return fmt.Errorf("device authorization failed: %w", err)
 	}
@@ -318,6 +318,18 @@ func runDeviceFlow(cmd *cobra.Command, ctx context.Context, cache *client.TokenC
 	cmd.Println("✓ Authorization successful!")
 	cmd.Println()
 
+	// Persist the token so --auth-mode=github can pick it up (and silently
+	// refresh it via the refresh_token grant) on future dirctl invocations.
+	tokenStore := client.NewFileTokenStore()
+	if err := tokenStore.SaveToken("github", &client.Token{
+		AccessToken:  result.AccessToken,
+		TokenType:    result.TokenType,
+		RefreshToken: result.RefreshToken,
+		ExpiresAt:    result.ExpiresAt,
+	}); err != nil {
+		cmd.Printf("⚠ Could not persist refreshable token: %v\n", err)
+	}
+
 	// Fetch user info and cache token
 	return fetchUserInfoAndCache(cmd, ctx, TokenMetadata{
 		AccessToken: result.AccessToken,
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -37,6 +38,14 @@ type Config struct {
 	LLMAnalyzer bool `yaml:"llmanalyzer"`
 	CrewAI      bool `yaml:"crewai"`
 
+	// PluginDir is an optional directory to search for dir-build-ext-<name>
+	// out-of-process build extension plugins before falling back to $PATH.
+	PluginDir string `yaml:"plugin-dir"`
+
+	// PluginTimeout bounds how long a build extension plugin may run before
+	// it is killed. clitypes.DefaultPluginTimeout is used when zero.
+	PluginTimeout time.Duration `yaml:"plugin-timeout"`
+
 	Framework framework.Config `yaml:"framework"`
 	Language  language.Config  `yaml:"language"`
 	Skills    skills.Config    `yaml:"skills"`
@@ -49,6 +49,19 @@ func (em *ExtensionManager) RegisterExtensions() error {
 			em.extensions = append(em.extensions, language.New(languageCfg))
 
 		default:
+			if path, ok := discoverPlugin(ext.Name, em.cfg.PluginDir); ok {
+				em.extensions = append(em.extensions, &clitypes.PluginExtensionBuilder{
+					Name:         ext.Name,
+					Path:         path,
+					Source:       em.cfg.Source,
+					SourceIgnore: em.cfg.SourceIgnore,
+					Specs:        ext.Specs,
+					Timeout:      em.cfg.PluginTimeout,
+				})
+
+				continue
+			}
+
 			em.customExtensions = append(em.customExtensions, i)
 
 		}
@@ -0,0 +1,47 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// pluginExecutablePrefix names out-of-process build extension plugins,
+// following the same convention Terraform/Buildkit use for discovering
+// subcommands/plugins on $PATH.
+const pluginExecutablePrefix = "dir-build-ext-"
+
+// discoverPlugin looks for a dir-build-ext-<name> executable for the given
+// extension name, checking the config-declared plugin directory (if any)
+// before falling back to $PATH.
+func discoverPlugin(name, pluginDir string) (string, bool) {
+	execName := pluginExecutablePrefix + pluginSlug(name)
+
+	if pluginDir != "" {
+		candidate := filepath.Join(pluginDir, execName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+
+	if path, err := exec.LookPath(execName); err == nil {
+		return path, true
+	}
+
+	return "", false
+}
+
+// pluginSlug reduces an OASF feature-style extension name
+// (e.g. "oasf.agntcy.org/features/custom/foo") to the last path segment
+// used to name its plugin executable ("foo").
+func pluginSlug(name string) string {
+	if idx := strings.LastIndexByte(name, '/'); idx >= 0 {
+		return name[idx+1:]
+	}
+
+	return name
+}
@@ -6,6 +6,7 @@ package hub
 import (
 	"fmt"
 
+	"github.com/agntcy/dir/cli/cmd/hub/registry"
 	"github.com/spf13/cobra"
 )
 
@@ -22,5 +23,10 @@ func NewCommand(hub Hub) *cobra.Command {
 		DisableFlagParsing: true,
 	}
 
+	// "registry" manages registries.yaml directly, so it keeps normal flag
+	// parsing even though the parent command disables it for everything
+	// else it hands off to the Hub implementation.
+	cmd.AddCommand(registry.NewCommand())
+
 	return cmd
 }
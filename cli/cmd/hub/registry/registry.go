@@ -0,0 +1,194 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package registry implements "dir hub registry", managing the
+// ~/.dir/registries.yaml file that the default RegistryClientResolver
+// reads to pick a registry client per reference.
+package registry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/dir/cli/util/registryconfig"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand builds the "registry" subcommand family for managing
+// registries.yaml: add, remove, list, and login.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Manage registries.yaml, the set of registries dirctl can push to and pull from",
+	}
+
+	cmd.AddCommand(newAddCommand())
+	cmd.AddCommand(newRemoveCommand())
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newLoginCommand())
+
+	return cmd
+}
+
+func newAddCommand() *cobra.Command {
+	var endpoint registryconfig.Endpoint
+
+	var setDefault bool
+
+	cmd := &cobra.Command{
+		Use:   "add <alias>",
+		Short: "Add or update a registry entry",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			alias := args[0]
+
+			path, cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			cfg.Upsert(alias, endpoint)
+
+			if setDefault {
+				cfg.Default = alias
+			}
+
+			if err := cfg.Save(path); err != nil {
+				return fmt.Errorf("failed to save registry config: %w", err)
+			}
+
+			fmt.Printf("Added registry %q (%s)\n", alias, endpoint.URL)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&endpoint.URL, "url", "", "Registry endpoint URL")
+	cmd.Flags().StringVar(&endpoint.Auth, "auth", "", "Auth provider to use for this registry")
+	cmd.Flags().BoolVar(&endpoint.Insecure, "insecure", false, "Skip TLS verification for this registry")
+	cmd.Flags().StringVar(&endpoint.CAFile, "ca-file", "", "Path to a CA bundle to verify this registry's TLS certificate")
+	cmd.Flags().BoolVar(&setDefault, "default", false, "Use this registry for references that don't name one explicitly")
+
+	if err := cmd.MarkFlagRequired("url"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func newRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <alias>",
+		Short: "Remove a registry entry",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			alias := args[0]
+
+			path, cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			cfg.Remove(alias)
+
+			if err := cfg.Save(path); err != nil {
+				return fmt.Errorf("failed to save registry config: %w", err)
+			}
+
+			fmt.Printf("Removed registry %q\n", alias)
+
+			return nil
+		},
+	}
+}
+
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List configured registries",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			_, cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(cfg.Registries) == 0 {
+				fmt.Println("No registries configured; using the default single-registry client")
+
+				return nil
+			}
+
+			for alias, endpoint := range cfg.Registries {
+				marker := ""
+				if alias == cfg.Default {
+					marker = " (default)"
+				}
+
+				fmt.Printf("%s%s | %s\n", alias, marker, endpoint.URL)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newLoginCommand() *cobra.Command {
+	var authProvider string
+
+	cmd := &cobra.Command{
+		Use:   "login <alias>",
+		Short: "Set the auth provider used for a configured registry",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			alias := args[0]
+
+			path, cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			endpoint, ok := cfg.Registries[alias]
+			if !ok {
+				return fmt.Errorf("unknown registry %q; add it first with 'dir hub registry add'", alias)
+			}
+
+			endpoint.Auth = authProvider
+			cfg.Upsert(alias, endpoint)
+
+			if err := cfg.Save(path); err != nil {
+				return fmt.Errorf("failed to save registry config: %w", err)
+			}
+
+			fmt.Printf("Updated auth for registry %q\n", alias)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&authProvider, "auth", "", "Auth provider to use for this registry")
+
+	if err := cmd.MarkFlagRequired("auth"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func loadConfig() (string, *registryconfig.Config, error) {
+	path, err := registryconfig.Path()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to determine registry config path: %w", err)
+	}
+
+	cfg, err := registryconfig.Load(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load registry config: %w", err)
+	}
+
+	if cfg == nil {
+		return "", nil, errors.New("failed to load registry config")
+	}
+
+	return path, cfg, nil
+}
@@ -5,8 +5,11 @@
 package info
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -17,22 +20,51 @@ import (
 	"github.com/agntcy/dir/cli/util/context"
 )
 
+// peerLabelCount is a single peer's contribution to a label's total count.
+type peerLabelCount struct {
+	PeerID string `json:"peer_id"`
+	Count  uint64 `json:"count"`
+}
+
+// labelSummary aggregates a label's count across the peers it was seen on.
+type labelSummary struct {
+	Label string           `json:"label"`
+	Total uint64           `json:"total"`
+	Peers []peerLabelCount `json:"peers,omitempty"`
+}
+
+// treeNode is one segment of a label split on "/", with Count summing the
+// totals of every label rooted under it.
+type treeNode struct {
+	Name     string
+	Count    uint64
+	Children map[string]*treeNode
+}
+
 func NewCommand(baseOption *commonOptions.BaseOption) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "info",
 		Short: "Get summary details about published data",
 		Long: `Get aggregated summary about the data held in your local
-data store or across the network.	
+data store or across the network.
 
 Usage examples:
 
 1. List summary about locally published data:
 
    	dir list info
-	
+
 2. List summary about published data across the network:
 
    	dir list info --network
+
+3. Roll up label counts into a tree, aggregated across all peers:
+
+   	dir list info --network --output tree --aggregate-peers
+
+4. Filter and sort the label counts:
+
+   	dir list info --network --label-prefix skills/ --min-count 5 --sort -count
 `,
 	}
 
@@ -74,22 +106,155 @@ func runCommand(cmd *cobra.Command, opts *options.ListInfoOptions) error {
 		return fmt.Errorf("failed to list peers: %w", err)
 	}
 
-	// Print the results
+	// Aggregate label counts across every peer returned by the request.
+	summaries := make(map[string]*labelSummary)
+
 	for item := range items {
 		peerName := item.GetPeer().GetId()
 
-		// in case we have nothing for that host, skip
-		if len(item.GetLabelCounts()) == 0 {
-			// presenter.Printf(cmd, "Peer %s | <empty>\n", peerName)
+		for label, count := range item.GetLabelCounts() {
+			summary, ok := summaries[label]
+			if !ok {
+				summary = &labelSummary{Label: label}
+				summaries[label] = summary
+			}
 
+			summary.Total += count
+			summary.Peers = append(summary.Peers, peerLabelCount{PeerID: peerName, Count: count})
+		}
+	}
+
+	filtered := filterLabelSummaries(summaries, opts)
+	sortLabelSummaries(filtered, opts.Sort)
+
+	switch opts.Output {
+	case "json":
+		return printInfoJSON(cmd, filtered)
+	case "tree":
+		return printInfoTree(cmd, filtered)
+	default:
+		return printInfoText(cmd, filtered)
+	}
+}
+
+// filterLabelSummaries applies --label-prefix, --min-count, and
+// --aggregate-peers before the results are sorted and printed.
+func filterLabelSummaries(summaries map[string]*labelSummary, opts *options.ListInfoOptions) []*labelSummary {
+	filtered := make([]*labelSummary, 0, len(summaries))
+
+	for _, summary := range summaries {
+		if opts.LabelPrefix != "" && !strings.HasPrefix(summary.Label, opts.LabelPrefix) {
 			continue
 		}
 
-		// otherwise, print each label and count
-		for label, count := range item.GetLabelCounts() {
-			presenter.Printf(cmd, "Peer %s | Label: %s | Total: %d\n", peerName, label, count)
+		if opts.MinCount > 0 && summary.Total < uint64(opts.MinCount) { //nolint:gosec
+			continue
 		}
+
+		if opts.AggregatePeers {
+			summary.Peers = nil
+		}
+
+		filtered = append(filtered, summary)
 	}
 
+	return filtered
+}
+
+// sortLabelSummaries orders summaries by label, count, or descending count,
+// always breaking ties on label so the ordering is deterministic.
+func sortLabelSummaries(summaries []*labelSummary, order string) {
+	sort.Slice(summaries, func(i, j int) bool {
+		switch order {
+		case "count":
+			if summaries[i].Total != summaries[j].Total {
+				return summaries[i].Total < summaries[j].Total
+			}
+		case "-count":
+			if summaries[i].Total != summaries[j].Total {
+				return summaries[i].Total > summaries[j].Total
+			}
+		}
+
+		return summaries[i].Label < summaries[j].Label
+	})
+}
+
+func printInfoText(cmd *cobra.Command, summaries []*labelSummary) error {
+	if len(summaries) == 0 {
+		presenter.Printf(cmd, "No labels found\n")
+
+		return nil
+	}
+
+	for _, summary := range summaries {
+		if len(summary.Peers) == 0 {
+			presenter.Printf(cmd, "Label: %s | Total: %d\n", summary.Label, summary.Total)
+
+			continue
+		}
+
+		for _, peerCount := range summary.Peers {
+			presenter.Printf(cmd, "Peer %s | Label: %s | Total: %d\n", peerCount.PeerID, summary.Label, peerCount.Count)
+		}
+	}
+
+	return nil
+}
+
+func printInfoJSON(cmd *cobra.Command, summaries []*labelSummary) error {
+	output, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal label counts: %w", err)
+	}
+
+	presenter.Printf(cmd, "%s\n", output)
+
+	return nil
+}
+
+// printInfoTree groups label counts under their "/"-separated prefixes,
+// summing leaf counts up the tree, and prints an indented roll-up.
+func printInfoTree(cmd *cobra.Command, summaries []*labelSummary) error {
+	root := &treeNode{Children: make(map[string]*treeNode)}
+
+	for _, summary := range summaries {
+		node := root
+
+		for _, part := range strings.Split(summary.Label, "/") {
+			child, ok := node.Children[part]
+			if !ok {
+				child = &treeNode{Name: part, Children: make(map[string]*treeNode)}
+				node.Children[part] = child
+			}
+
+			child.Count += summary.Total
+			node = child
+		}
+	}
+
+	if len(root.Children) == 0 {
+		presenter.Printf(cmd, "No labels found\n")
+
+		return nil
+	}
+
+	printTreeNode(cmd, root, 0)
+
 	return nil
 }
+
+func printTreeNode(cmd *cobra.Command, node *treeNode, depth int) {
+	names := make([]string, 0, len(node.Children))
+	for name := range node.Children {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := node.Children[name]
+		presenter.Printf(cmd, "%s%s (%d)\n", strings.Repeat("  ", depth), name, child.Count)
+		printTreeNode(cmd, child, depth+1)
+	}
+}
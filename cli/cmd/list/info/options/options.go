@@ -15,6 +15,27 @@ type ListInfoOptions struct {
 
 	PeerID  string
 	Network bool
+
+	// Output selects how the aggregated label counts are rendered:
+	// "text" (default, one line per peer/label/count), "json", or "tree"
+	// (label prefixes grouped and rolled up by splitting on "/").
+	Output string
+
+	// LabelPrefix filters results to labels starting with this prefix,
+	// applied before printing.
+	LabelPrefix string
+
+	// MinCount filters out labels whose count is below this threshold,
+	// applied before printing.
+	MinCount int64
+
+	// Sort orders the printed labels: "label" (default), "count", or
+	// "-count" (descending count).
+	Sort string
+
+	// AggregatePeers collapses per-peer rows into a single total per label
+	// across all peers returned by the list request.
+	AggregatePeers bool
 }
 
 func NewListInfoOptions(baseOption *commonOptions.BaseOption, cmd *cobra.Command) *ListInfoOptions {
@@ -26,6 +47,11 @@ func NewListInfoOptions(baseOption *commonOptions.BaseOption, cmd *cobra.Command
 		flags := cmd.Flags()
 		flags.StringVar(&opts.PeerID, "peer", "", "Get publication summary for a single peer")
 		flags.BoolVar(&opts.Network, "network", false, "Get publication summary for the network")
+		flags.StringVar(&opts.Output, "output", "text", "Output format: text, json, or tree")
+		flags.StringVar(&opts.LabelPrefix, "label-prefix", "", "Only include labels starting with this prefix")
+		flags.Int64Var(&opts.MinCount, "min-count", 0, "Only include labels with at least this count")
+		flags.StringVar(&opts.Sort, "sort", "label", "Sort order: label, count, or -count")
+		flags.BoolVar(&opts.AggregatePeers, "aggregate-peers", false, "Collapse per-peer rows into a single total per label")
 
 		if err := flags.MarkHidden("peer"); err != nil {
 			return fmt.Errorf("unable to mark flag 'peer' as hidden: %w", err)
@@ -82,5 +82,5 @@ func runCommand(cmd *cobra.Command, opts *options.ListOptions, labels []string)
 		return listNetwork(cmd, client, labels)
 	}
 
-	return listPeer(cmd, client, opts.PeerID, labels)
+	return listPeer(cmd, client, opts.PeerID, labels, opts.PageSize, opts.PageToken)
 }
@@ -13,9 +13,11 @@ import (
 type ListOptions struct {
 	*commonOptions.BaseOption
 
-	Digest  string
-	PeerID  string
-	Network bool
+	Digest    string
+	PeerID    string
+	Network   bool
+	PageSize  int32
+	PageToken string
 }
 
 func NewListOptions(baseOption *commonOptions.BaseOption, cmd *cobra.Command) *ListOptions {
@@ -28,6 +30,10 @@ func NewListOptions(baseOption *commonOptions.BaseOption, cmd *cobra.Command) *L
 		flags.StringVar(&opts.Digest, "digest", "", "Get published records for a given object")
 		flags.StringVar(&opts.PeerID, "peer", "", "Get published records for a single peer")
 		flags.BoolVar(&opts.Network, "network", false, "Get published records for the network")
+		flags.Int32Var(&opts.PageSize, "page-size", 0,
+			"Maximum number of records to return per page (0 fetches everything, no pagination)")
+		flags.StringVar(&opts.PageToken, "page-token", "",
+			"Resume a previously interrupted listing from this page token (see the stderr output of an interrupted run)")
 
 		if err := flags.MarkHidden("peer"); err != nil {
 			return fmt.Errorf("unable to mark flag 'peer' as hidden: %w", err)
@@ -13,7 +13,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func listPeer(cmd *cobra.Command, client *client.Client, peerID string, labels []string) error {
+func listPeer(cmd *cobra.Command, client *client.Client, peerID string, labels []string, pageSize int32, pageToken string) error {
 	// Is peer set
 	// if not, run local list only
 	var peer *routetypes.Peer
@@ -23,33 +23,90 @@ func listPeer(cmd *cobra.Command, client *client.Client, peerID string, labels [
 		}
 	}
 
+	resumePeerID, resumeDigest, err := routetypes.DecodePageToken(pageToken)
+	if err != nil {
+		return fmt.Errorf("invalid --page-token: %w", err)
+	}
+
 	// Start the list request
 	items, err := client.List(cmd.Context(), &routetypes.ListRequest{
 		LegacyListRequest: &routetypes.LegacyListRequest{
 			Peer:   peer,
 			Labels: labels,
 		},
+		PageSize:  pageSize,
+		PageToken: pageToken,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to list peer records: %w", err)
 	}
 
-	// Print the results
-	for item := range items {
-		var cid string
-		if ref := item.GetRef(); ref != nil {
-			cid = ref.GetCid()
-		} else {
-			cid = "unknown"
+	// Skip back to the item the caller last saw, since we're resuming.
+	skipping := resumePeerID != "" || resumeDigest != ""
+
+	var lastPeerID, lastDigest string
+
+	var printed int32
+
+	for {
+		select {
+		case <-cmd.Context().Done():
+			printResumeToken(cmd, lastPeerID, lastDigest)
+
+			return fmt.Errorf("list interrupted: %w", cmd.Context().Err())
+		case item, ok := <-items:
+			if !ok {
+				if pageSize > 0 && printed == pageSize {
+					printResumeToken(cmd, lastPeerID, lastDigest)
+				}
+
+				return nil
+			}
+
+			itemPeerID := item.GetPeer().GetId()
+
+			var cid string
+			if ref := item.GetRef(); ref != nil {
+				cid = ref.GetCid()
+			} else {
+				cid = "unknown"
+			}
+
+			if skipping {
+				if itemPeerID == resumePeerID && cid == resumeDigest {
+					skipping = false
+				}
+
+				continue
+			}
+
+			if pageSize > 0 && printed >= pageSize {
+				printResumeToken(cmd, lastPeerID, lastDigest)
+
+				return nil
+			}
+
+			presenter.Printf(cmd,
+				"Peer %s\n  CID: %s\n  Labels: %s\n",
+				itemPeerID,
+				cid,
+				strings.Join(item.GetLabels(), ", "),
+			)
+
+			lastPeerID, lastDigest = itemPeerID, cid
+			printed++
 		}
+	}
+}
 
-		presenter.Printf(cmd,
-			"Peer %s\n  CID: %s\n  Labels: %s\n",
-			item.GetPeer().GetId(),
-			cid,
-			strings.Join(item.GetLabels(), ", "),
-		)
+// printResumeToken surfaces the page token for the last successfully
+// processed item on stderr, so the caller can resume with
+// --page-token=<token> instead of restarting the listing from scratch.
+func printResumeToken(cmd *cobra.Command, peerID, digest string) {
+	token := routetypes.EncodePageToken(peerID, digest)
+	if token == "" {
+		return
 	}
 
-	return nil
+	fmt.Fprintf(cmd.ErrOrStderr(), "resume with: dirctl list peer --page-token=%s\n", token)
 }
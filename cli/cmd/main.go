@@ -15,6 +15,7 @@ import (
 	"github.com/agntcy/dir/cli/cmd/push"
 	"github.com/agntcy/dir/cli/cmd/search"
 	"github.com/agntcy/dir/cli/util"
+	"github.com/agntcy/dir/cli/util/registryconfig"
 	"github.com/agntcy/dir/registry/client"
 
 	"github.com/spf13/cobra"
@@ -25,12 +26,27 @@ var rootCmd = &cobra.Command{
 	Short: "CLI tool to interact with Directory",
 	Long:  ``,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Keep talking to a single registry by default; registries.yaml
+		// (managed via "dir hub registry") lets multi-registry setups
+		// override the client picked per reference.
 		c, err := client.New()
 		if err != nil {
 			return fmt.Errorf("failed to create registry client: %w", err)
 		}
 
-		ctx := util.SetRegistryClientForContext(cmd.Context(), c)
+		configPath, err := registryconfig.Path()
+		if err != nil {
+			return fmt.Errorf("failed to determine registry config path: %w", err)
+		}
+
+		registryCfg, err := registryconfig.Load(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load registry config: %w", err)
+		}
+
+		resolver := registryconfig.NewResolver(registryCfg, c)
+
+		ctx := util.SetRegistryClientResolverForContext(cmd.Context(), resolver)
 		cmd.SetContext(ctx)
 
 		return nil
@@ -34,11 +34,16 @@ claimed in the record's name field. Verification is performed automatically
 when a record is signed using 'dirctl sign'.
 
 The record's name must include a protocol prefix to specify the verification method:
+- dns://domain/path - verify using a DNS TXT record at _dir_nsys.<domain>
 - https://domain/path - verify using JWKS well-known file (RFC 7517)
 - http://domain/path - verify using JWKS via HTTP (testing only)
+- dns-challenge://domain/path - verify an ACME DNS-01-style JWK thumbprint
+  published at _agntcy-challenge.<domain>
+- http-challenge://domain/path - verify an ACME HTTP-01-style JWK thumbprint
+  served at /.well-known/agntcy-challenge, for domains that cannot host a
+  JWKS endpoint
 
-Verification method:
-JWKS well-known file at <scheme>://<domain>/.well-known/jwks.json
+The verified method is reported back in the "method" field of the result.
 
 The server automatically re-verifies records based on TTL to ensure
 domain ownership remains valid.
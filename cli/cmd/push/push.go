@@ -38,12 +38,6 @@ var Command = &cobra.Command{
 }
 
 func runCommand(cmd *cobra.Command) error {
-	// Get the registry client from the context.
-	c, ok := util.GetRegistryClientFromContext(cmd.Context())
-	if !ok {
-		return fmt.Errorf("failed to get registry client from context")
-	}
-
 	// Create a reader from the file or stdin.
 	reader, err := getReader()
 	if err != nil {
@@ -56,6 +50,14 @@ func runCommand(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to unmarshal agent: %w", err)
 	}
 
+	// Resolve the registry client for the agent being pushed, so a
+	// registries.yaml with more than one registry configured routes the
+	// push to the right one.
+	c, ok := util.GetRegistryClientFromContext(cmd.Context(), agent.Name)
+	if !ok {
+		return fmt.Errorf("failed to get registry client from context")
+	}
+
 	// Marshal the Agent struct back to bytes.
 	data, err := json.Marshal(agent)
 	if err != nil {
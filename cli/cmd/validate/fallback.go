@@ -0,0 +1,101 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"net/http"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/cli/cmd/validate/schemacache"
+)
+
+// schemaSource reports where the schema used for validation came from, for
+// CI systems that need to distinguish a live check from a degraded one.
+type schemaSource string
+
+const (
+	// schemaSourceLive means the remote OASF schema was freshly fetched.
+	schemaSourceLive schemaSource = "live"
+	// schemaSourceCache means the remote schema endpoint revalidated
+	// against (or fell back to) a locally cached copy.
+	schemaSourceCache schemaSource = "cache"
+	// schemaSourceEmbedded means --disable-api was used, or no --url was given.
+	schemaSourceEmbedded schemaSource = "embedded"
+	// schemaSourceEmbeddedFallback means --url was given but unreachable, and
+	// --fallback transparently fell back to the embedded schema.
+	schemaSourceEmbeddedFallback schemaSource = "embedded-fallback"
+)
+
+// validationStatus augments a Validate result with the provenance of the
+// schema used and any violations that --fallback downgraded to warnings.
+type validationStatus struct {
+	Source   schemaSource `json:"schema_source"`
+	Warnings []string     `json:"warnings,omitempty"`
+}
+
+// validateRecord validates record against the schema selected by opts,
+// applying --fallback's graceful degradation: falling back to the embedded
+// schema when --url is unreachable, and downgrading strict-only violations
+// to warnings when lax validation would otherwise pass.
+func validateRecord(ctx context.Context, record *corev1.Record, opts *options) (bool, []string, validationStatus, error) {
+	status := validationStatus{Source: schemaSourceEmbedded}
+
+	if opts.SchemaURL != "" {
+		status.Source = schemaSourceLive
+
+		if opts.Fallback && !probeSchemaURL(opts.SchemaURL, &status) {
+			corev1.SetDisableAPIValidation(true)
+
+			status.Source = schemaSourceEmbeddedFallback
+		}
+	}
+
+	valid, errs, err := record.Validate(ctx)
+	if err != nil {
+		return false, nil, status, err
+	}
+
+	if valid || !opts.Fallback || status.Source == schemaSourceEmbeddedFallback || opts.DisableStrict {
+		return valid, errs, status, nil
+	}
+
+	// The strict validation above failed. See whether lax validation would
+	// have passed - if so, the failures were strict-only and are downgraded
+	// to warnings rather than a hard failure.
+	corev1.SetStrictValidation(false)
+
+	laxValid, _, laxErr := record.Validate(ctx)
+
+	corev1.SetStrictValidation(true)
+
+	if laxErr != nil || !laxValid {
+		return valid, errs, status, nil
+	}
+
+	status.Warnings = errs
+
+	return true, nil, status, nil
+}
+
+// probeSchemaURL checks whether url's schema is reachable (live or via a
+// cached fallback), recording the outcome's source on status. It returns
+// false only when the endpoint is unreachable and no cached copy exists.
+func probeSchemaURL(url string, status *validationStatus) bool {
+	cache, err := schemacache.New()
+	if err != nil {
+		return true // Can't use the cache, but don't block validation on that.
+	}
+
+	result, err := cache.Fetch(&http.Client{Timeout: corev1.DefaultValidationTimeout}, url)
+	if err != nil {
+		return false
+	}
+
+	if result.Source == schemacache.SourceCache {
+		status.Source = schemaSourceCache
+	}
+
+	return true
+}
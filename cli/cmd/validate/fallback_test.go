@@ -0,0 +1,93 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetValidationConfig snapshots and restores both the package-level opts
+// (read by validateRecord) and the corev1 validation globals it configures,
+// so these tests don't leak state into the rest of the suite.
+func resetValidationConfig(t *testing.T) {
+	t.Helper()
+
+	savedOpts := *opts
+
+	t.Cleanup(func() {
+		*opts = savedOpts
+		corev1.SetDisableAPIValidation(true)
+		corev1.SetSchemaURL(corev1.DefaultSchemaURL)
+		corev1.SetStrictValidation(true)
+	})
+}
+
+// TestValidateRecord_FallsBackToEmbeddedWhenSchemaURLUnreachable exercises the
+// --fallback path where the schema endpoint can't be reached at all: the
+// probe fails, API validation is disabled, and status reports the degraded
+// source instead of a hard failure.
+func TestValidateRecord_FallsBackToEmbeddedWhenSchemaURLUnreachable(t *testing.T) {
+	resetValidationConfig(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	server.Close() // Unreachable, and nothing has ever been cached.
+
+	record, err := corev1.UnmarshalRecord(testRecordValid)
+	require.NoError(t, err)
+
+	opts.SchemaURL = server.URL
+	opts.Fallback = true
+	configureValidationSettings()
+
+	valid, _, status, err := validateRecord(context.Background(), record, opts)
+	require.NoError(t, err)
+	assert.Equal(t, schemaSourceEmbeddedFallback, status.Source)
+	// Embedded validation of a record known-valid against the embedded schema
+	// should still succeed once fallen back.
+	assert.True(t, valid)
+}
+
+// TestValidateRecord_NoFallbackLeavesSourceLive verifies that without
+// --fallback, validateRecord doesn't probe the schema URL at all and reports
+// the optimistic "live" source, preserving today's behavior.
+func TestValidateRecord_NoFallbackLeavesSourceLive(t *testing.T) {
+	resetValidationConfig(t)
+
+	record, err := corev1.UnmarshalRecord(testRecordValid)
+	require.NoError(t, err)
+
+	opts.SchemaURL = "http://127.0.0.1:0"
+	opts.Fallback = false
+
+	_, _, status, _ := validateRecord(context.Background(), record, opts)
+	assert.Equal(t, schemaSourceLive, status.Source)
+}
+
+// TestValidateRecord_EmbeddedSourceWhenAPIDisabled covers the --disable-api
+// path, which never touches the schema cache.
+func TestValidateRecord_EmbeddedSourceWhenAPIDisabled(t *testing.T) {
+	resetValidationConfig(t)
+
+	record, err := corev1.UnmarshalRecord(testRecordValid)
+	require.NoError(t, err)
+
+	opts.SchemaURL = ""
+	opts.DisableAPI = true
+	configureValidationSettings()
+
+	valid, _, status, err := validateRecord(context.Background(), record, opts)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, schemaSourceEmbedded, status.Source)
+}
@@ -14,6 +14,7 @@ type options struct {
 	DisableStrict bool
 	SchemaURL     string
 	ValidateAll   bool
+	Fallback      bool
 }
 
 func init() {
@@ -27,6 +28,9 @@ func init() {
 		"OASF schema URL for API-based validation (required if --disable-api is not specified)")
 	flags.BoolVar(&opts.ValidateAll, "all", false,
 		"Validate all records in the directory instance (requires connection to Directory server)")
+	flags.BoolVar(&opts.Fallback, "fallback", false,
+		"Gracefully degrade instead of hard-failing: fall back to embedded schemas when --url is unreachable, "+
+			"and downgrade strict-only violations to warnings when lax validation would otherwise pass")
 
 	// Add output format flags
 	presenter.AddOutputFlags(Command)
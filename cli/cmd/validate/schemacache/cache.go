@@ -0,0 +1,153 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schemacache caches fetched OASF schema documents on disk, using an
+// ETag/If-None-Match revalidation flow so repeated validations against the
+// same schema URL avoid re-downloading unchanged schemas, and can fall back
+// to the last-known-good copy when the schema endpoint is unreachable.
+package schemacache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Source reports where a Fetch result's bytes came from.
+type Source string
+
+const (
+	// SourceNetwork means the schema was freshly downloaded.
+	SourceNetwork Source = "network"
+	// SourceCache means the schema came from the on-disk cache, either
+	// because the server returned 304 Not Modified or because the server
+	// was unreachable and a cached copy existed.
+	SourceCache Source = "cache"
+)
+
+// Cache is an on-disk store of fetched schema documents, keyed by URL.
+type Cache struct {
+	dir string
+}
+
+// New returns a Cache rooted at $XDG_CACHE_HOME/dirctl/schemas, falling back
+// to os.UserCacheDir()/dirctl/schemas when XDG_CACHE_HOME is unset.
+func New() (*Cache, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve user cache directory: %w", err)
+		}
+
+		base = userCacheDir
+	}
+
+	dir := filepath.Join(base, "dirctl", "schemas")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create schema cache directory: %w", err)
+	}
+
+	return &Cache{dir: dir}, nil
+}
+
+// Result is the outcome of a Fetch.
+type Result struct {
+	Body   []byte
+	Source Source
+}
+
+// schemaPath and etagPath return the cache's on-disk paths for url, hashed
+// so arbitrary URLs map to safe file names.
+func (c *Cache) schemaPath(url string) string {
+	return filepath.Join(c.dir, hashURL(url)+".json")
+}
+
+func (c *Cache) etagPath(url string) string {
+	return filepath.Join(c.dir, hashURL(url)+".etag")
+}
+
+func hashURL(url string) string {
+	sum := sha256.Sum256([]byte(url))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Fetch retrieves the schema document at url, revalidating against the
+// cached copy's ETag with If-None-Match. A 304 response serves the cached
+// body. When the request itself fails (the endpoint is unreachable) and a
+// cached copy exists, that stale copy is returned instead of the error.
+func (c *Cache) Fetch(client *http.Client, url string) (*Result, error) {
+	cachedBody, cachedETag := c.readCached(url)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("failed to build schema request: %w", err)
+	}
+
+	if cachedETag != "" {
+		req.Header.Set("If-None-Match", cachedETag)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if cachedBody != nil {
+			return &Result{Body: cachedBody, Source: SourceCache}, nil
+		}
+
+		return nil, fmt.Errorf("failed to fetch schema from %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		if cachedBody == nil {
+			return nil, fmt.Errorf("server reported 304 Not Modified for %q but no cached copy exists", url)
+		}
+
+		return &Result{Body: cachedBody, Source: SourceCache}, nil
+
+	case http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema response from %q: %w", url, err)
+		}
+
+		c.writeCached(url, body, resp.Header.Get("ETag"))
+
+		return &Result{Body: body, Source: SourceNetwork}, nil
+
+	default:
+		if cachedBody != nil {
+			return &Result{Body: cachedBody, Source: SourceCache}, nil
+		}
+
+		return nil, fmt.Errorf("unexpected status %d fetching schema from %q", resp.StatusCode, url)
+	}
+}
+
+func (c *Cache) readCached(url string) ([]byte, string) {
+	body, err := os.ReadFile(c.schemaPath(url))
+	if err != nil {
+		return nil, ""
+	}
+
+	etag, err := os.ReadFile(c.etagPath(url))
+	if err != nil {
+		etag = nil
+	}
+
+	return body, string(etag)
+}
+
+func (c *Cache) writeCached(url string, body []byte, etag string) {
+	_ = os.WriteFile(c.schemaPath(url), body, 0o600)
+
+	if etag != "" {
+		_ = os.WriteFile(c.etagPath(url), []byte(etag), 0o600)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package schemacache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(t *testing.T) *Cache {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cache, err := New()
+	require.NoError(t, err)
+
+	return cache
+}
+
+func TestCache_Fetch_NetworkOnFirstRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"schema":"v1"}`))
+	}))
+	defer server.Close()
+
+	cache := newTestCache(t)
+
+	result, err := cache.Fetch(server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, SourceNetwork, result.Source)
+	assert.JSONEq(t, `{"schema":"v1"}`, string(result.Body))
+}
+
+func TestCache_Fetch_RevalidatesWithETagAndServes304FromCache(t *testing.T) {
+	var sawIfNoneMatch string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawIfNoneMatch = r.Header.Get("If-None-Match")
+		if sawIfNoneMatch == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"schema":"v1"}`))
+	}))
+	defer server.Close()
+
+	cache := newTestCache(t)
+
+	first, err := cache.Fetch(server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, SourceNetwork, first.Source)
+
+	second, err := cache.Fetch(server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, `"v1"`, sawIfNoneMatch)
+	assert.Equal(t, SourceCache, second.Source)
+	assert.Equal(t, first.Body, second.Body)
+}
+
+func TestCache_Fetch_FallsBackToStaleCacheWhenUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"schema":"v1"}`))
+	}))
+
+	cache := newTestCache(t)
+
+	primed, err := cache.Fetch(server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, SourceNetwork, primed.Source)
+
+	// Simulate the schema endpoint becoming unreachable.
+	server.Close()
+
+	result, err := cache.Fetch(server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, SourceCache, result.Source)
+	assert.Equal(t, primed.Body, result.Body)
+}
+
+func TestCache_Fetch_ErrorsWhenUnreachableAndNoCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"schema":"v1"}`))
+	}))
+	server.Close() // Never served a request, so nothing is cached.
+
+	cache := newTestCache(t)
+
+	_, err := cache.Fetch(server.Client(), server.URL)
+	require.Error(t, err)
+}
@@ -97,33 +97,33 @@ func runCommand(cmd *cobra.Command, filePath string) error {
 		return errors.New("either --url or --disable-api flag must be specified")
 	}
 
-	// Validate the record
+	// Validate the record, applying --fallback's graceful degradation.
 	ctx := cmd.Context()
 
-	valid, validationErrors, err := record.Validate(ctx)
+	valid, validationErrors, status, err := validateRecord(ctx, record, opts)
 	if err != nil {
 		return fmt.Errorf("validation error: %w", err)
 	}
 
 	// Output results
 	if !valid {
-		return outputValidationErrors(cmd, validationErrors)
+		return outputValidationErrors(cmd, validationErrors, status)
 	}
 
-	return outputValidationSuccess(cmd, record)
+	return outputValidationSuccess(cmd, record, status)
 }
 
-func outputValidationSuccess(cmd *cobra.Command, record *corev1.Record) error {
+func outputValidationSuccess(cmd *cobra.Command, record *corev1.Record, status validationStatus) error {
 	schemaVersion := record.GetSchemaVersion()
-	opts := presenter.GetOutputOptions(cmd)
-
-	if opts.IsStructuredOutput() {
-		// For structured output, use PrintMessage
-		if schemaVersion != "" {
-			return presenter.PrintMessage(cmd, "validation", "Record is valid", fmt.Sprintf("(schema version: %s)", schemaVersion))
-		}
-
-		return presenter.PrintMessage(cmd, "validation", "Record is valid", "")
+	outputOpts := presenter.GetOutputOptions(cmd)
+
+	if outputOpts.IsStructuredOutput() {
+		return presenter.OutputStructuredData(cmd, outputOpts, "validation", map[string]any{
+			"valid":          true,
+			"schema_version": schemaVersion,
+			"schema_source":  status.Source,
+			"warnings":       status.Warnings,
+		})
 	}
 
 	// For human-readable output, print without colon
@@ -133,12 +133,32 @@ func outputValidationSuccess(cmd *cobra.Command, record *corev1.Record) error {
 		presenter.Printf(cmd, "Record is valid\n")
 	}
 
+	presenter.Printf(cmd, "Schema source: %s\n", status.Source)
+
+	for _, warning := range status.Warnings {
+		presenter.Printf(cmd, "Warning (downgraded from strict failure): %s\n", warning)
+	}
+
 	return nil
 }
 
-func outputValidationErrors(cmd *cobra.Command, validationErrors []string) error {
+func outputValidationErrors(cmd *cobra.Command, validationErrors []string, status validationStatus) error {
+	outputOpts := presenter.GetOutputOptions(cmd)
+
+	if outputOpts.IsStructuredOutput() {
+		if err := presenter.OutputStructuredData(cmd, outputOpts, "validation", map[string]any{
+			"valid":         false,
+			"schema_source": status.Source,
+			"errors":        validationErrors,
+		}); err != nil {
+			return err
+		}
+
+		return errors.New("record validation failed")
+	}
+
 	if len(validationErrors) > 0 {
-		presenter.Printf(cmd, "Validation failed with %d error(s):\n", len(validationErrors))
+		presenter.Printf(cmd, "Validation failed with %d error(s) (schema source: %s):\n", len(validationErrors), status.Source)
 
 		for i, errMsg := range validationErrors {
 			presenter.Printf(cmd, "  %d. %s\n", i+1, errMsg)
@@ -24,6 +24,14 @@ type Options struct {
 	IgnoreTsa       bool
 	IgnoreSct       bool
 
+	// Bundle is a path to a standalone Sigstore verification bundle
+	// (bundle.sigstore.dev/v0.3 JSON: DSSE envelope, certificate chain,
+	// inclusion proof, and TSR in one file). When set, verification runs
+	// entirely offline against this file instead of pulling signature
+	// referrers from the store, which is what makes it usable against
+	// records replicated into an air-gapped registry.
+	Bundle string
+
 	// Output file flag
 	OutputFile string
 }
@@ -58,6 +66,10 @@ Supported formats:
 		"Skip timestamp authority (TSA) verification")
 	Command.Flags().BoolVar(&opts.IgnoreSct, "ignore-sct", signv1.DefaultVerifyOptionsOIDC.GetIgnoreSct(),
 		"Skip Signed Certificate Timestamp (SCT) verification")
+	Command.Flags().StringVar(&opts.Bundle, "bundle", "",
+		`Path to a standalone Sigstore verification bundle (bundle.sigstore.dev/v0.3 JSON).
+Verifies a record CID against this bundle without contacting the store or Rekor/TSA,
+for records mirrored into an air-gapped registry via 'dirctl sync'. Requires --trusted-root-path.`)
 
 	// Output file flag
 	Command.Flags().StringVar(&opts.OutputFile, "output-file", "",
@@ -71,4 +83,5 @@ Supported formats:
 	Command.MarkFlagsMutuallyExclusive("key", "ignore-tlog")
 	Command.MarkFlagsMutuallyExclusive("key", "ignore-tsa")
 	Command.MarkFlagsMutuallyExclusive("key", "ignore-sct")
+	Command.MarkFlagsMutuallyExclusive("key", "bundle")
 }
@@ -4,6 +4,7 @@
 package verify
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -12,10 +13,12 @@ import (
 
 	coretypes "github.com/agntcy/dir/api/core/v1alpha1"
 	coretypesv2 "github.com/agntcy/dir/api/core/v1alpha2"
+	signv1 "github.com/agntcy/dir/api/sign/v1"
 	signv1alpha2 "github.com/agntcy/dir/api/sign/v1alpha2"
 	"github.com/agntcy/dir/cli/presenter"
 	agentUtils "github.com/agntcy/dir/cli/util/agent"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	cosign "github.com/agntcy/dir/client/utils/cosign"
 	"github.com/spf13/cobra"
 )
 
@@ -36,8 +39,25 @@ Usage examples:
 
 	dirctl pull <digest> | dirctl verify --stdin
 
+3. Verify a record CID against a standalone bundle, entirely offline
+   (for records synced into an air-gapped registry):
+
+	dirctl verify <cid> --bundle signature.bundle.json --trusted-root-path trusted-root.json
+
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if opts.Bundle != "" {
+			if len(args) != 1 {
+				return errors.New("exactly one record CID is required when using --bundle")
+			}
+
+			if opts.TrustedRootPath == "" {
+				return errors.New("--trusted-root-path is required when using --bundle")
+			}
+
+			return runBundleCommand(cmd, args[0])
+		}
+
 		var path string
 		if len(args) > 1 {
 			return errors.New("only one file path is allowed")
@@ -55,6 +75,53 @@ Usage examples:
 	},
 }
 
+// runBundleCommand verifies recordCID's signature against a standalone
+// Sigstore bundle read from opts.Bundle, without pulling anything from the
+// store. This is the path for air-gapped deployments: a record replicated
+// via 'dirctl sync' into a disconnected registry can still be verified as
+// long as its verification bundle was replicated alongside it.
+func runBundleCommand(cmd *cobra.Command, recordCID string) error {
+	bundleJSON, err := os.ReadFile(filepath.Clean(opts.Bundle))
+	if err != nil {
+		return fmt.Errorf("failed to read bundle file: %w", err)
+	}
+
+	sig := &signv1.Signature{
+		ContentBundle: base64.StdEncoding.EncodeToString(bundleJSON),
+	}
+
+	oidcOpts := &signv1.VerifyOptionsOIDC{
+		TufMirrorUrl:    opts.TufMirrorUrl,
+		TrustedRootPath: opts.TrustedRootPath,
+		IgnoreTlog:      opts.IgnoreTlog,
+		IgnoreTsa:       opts.IgnoreTsa,
+		IgnoreSct:       opts.IgnoreSct,
+	}
+
+	// payload mirrors client.Client.Verify's convention: the signed artifact
+	// is the record's CID, not the record bytes themselves.
+	signerInfo, err := cosign.VerifyWithOIDC([]byte(recordCID), &signv1.VerifyWithOIDC{
+		Options: oidcOpts.GetDefaultOptions(),
+		Issuer:  opts.OIDCIssuer,
+		Subject: opts.OIDCSubject,
+	}, sig)
+	if err != nil {
+		return fmt.Errorf("bundle verification failed: %w", err)
+	}
+
+	// Offline here means the trust root came from a local file rather than a
+	// live TUF fetch - the bundle's own inclusion proof and signed checkpoint
+	// are what let Rekor/TSA be checked without a network round-trip at all.
+	result := map[string]any{
+		"cid":              recordCID,
+		"verified":         true,
+		"verified_offline": oidcOpts.GetTrustedRootPath() != "",
+		"signer":           signerInfo.String(),
+	}
+
+	return presenter.PrintMessage(cmd, "Bundle Verification", "Record signature verified from local bundle", result)
+}
+
 // nolint:mnd
 func runCommand(cmd *cobra.Command, source io.ReadCloser) error {
 	// Get the client from the context
@@ -0,0 +1,101 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"time"
+
+	apicore "github.com/agntcy/dir/api/core/v1alpha1"
+)
+
+// DefaultPluginTimeout bounds how long an out-of-process build extension
+// plugin may run when PluginExtensionBuilder.Timeout is unset.
+const DefaultPluginTimeout = 30 * time.Second
+
+// pluginBuildRequest is the JSON payload an out-of-process build extension
+// plugin receives on stdin.
+type pluginBuildRequest struct {
+	Source       string         `json:"source"`
+	SourceIgnore []string       `json:"source_ignore"`
+	Specs        map[string]any `json:"specs"`
+}
+
+// PluginExtensionBuilder adapts an out-of-process build extension,
+// discovered as a dir-build-ext-<name> executable on $PATH or under a
+// config-declared plugin directory, to the ExtensionBuilder interface. The
+// plugin is handed the builder source path, ignore list, and extension
+// Specs as JSON on stdin, and is expected to write a serialized
+// apicore.Extension to stdout.
+type PluginExtensionBuilder struct {
+	// Name is the extension name the plugin was discovered for, used to
+	// identify the plugin in error messages.
+	Name string
+	// Path is the resolved path to the dir-build-ext-<name> executable.
+	Path string
+
+	Source       string
+	SourceIgnore []string
+	Specs        map[string]any
+
+	// Timeout bounds how long the plugin may run before it is killed.
+	// DefaultPluginTimeout is used when zero.
+	Timeout time.Duration
+}
+
+// Build runs the plugin executable with the builder's source tree as its
+// working directory, and parses its stdout as a serialized apicore.Extension.
+// The plugin's filesystem access is not otherwise restricted.
+func (p *PluginExtensionBuilder) Build(ctx context.Context) (*AgentExtension, error) {
+	reqData, err := json.Marshal(pluginBuildRequest{
+		Source:       p.Source,
+		SourceIgnore: p.SourceIgnore,
+		Specs:        p.Specs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q: failed to marshal build request: %w", p.Name, err)
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultPluginTimeout
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	//nolint:gosec // p.Path is resolved from a trusted $PATH/plugin-dir lookup, not user input
+	cmd := exec.CommandContext(runCtx, p.Path)
+	cmd.Stdin = bytes.NewReader(reqData)
+	// Run the plugin from the tree it was asked to build.
+	cmd.Dir = p.Source
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %q (%s): %w: %s", p.Name, p.Path, err, stderr.String())
+	}
+
+	var apiExt apicore.Extension
+	if err := json.Unmarshal(stdout.Bytes(), &apiExt); err != nil {
+		return nil, fmt.Errorf("plugin %q: failed to parse plugin output: %w", p.Name, err)
+	}
+
+	var specs map[string]any
+	if apiExt.GetSpecs() != nil {
+		specs = apiExt.GetSpecs().AsMap()
+	}
+
+	return &AgentExtension{
+		Name:    apiExt.GetName(),
+		Version: apiExt.GetVersion(),
+		Specs:   specs,
+	}, nil
+}
@@ -11,14 +11,62 @@ import (
 
 type ClientContextKeyType string
 
-const ClientContextKey ClientContextKeyType = "ContextRegistryClient"
+const ClientContextKey ClientContextKeyType = "ContextRegistryClientResolver"
 
+// RegistryClientResolver resolves the registry client that should handle a
+// given reference (e.g. "myregistry.example.com/namespace/agent:tag"),
+// so a single CLI invocation can talk to more than one registry instead of
+// being pinned to whichever client was created at startup.
+type RegistryClientResolver interface {
+	Resolve(ctx context.Context, ref string) (*client.Client, error)
+}
+
+// singleClientResolver resolves every reference to the same client. It
+// backs SetRegistryClientForContext so existing single-registry callers
+// keep working unchanged.
+type singleClientResolver struct {
+	client *client.Client
+}
+
+func (r singleClientResolver) Resolve(_ context.Context, _ string) (*client.Client, error) {
+	return r.client, nil
+}
+
+// SetRegistryClientForContext pins a single registry client into the
+// context, returned for every reference regardless of registry. Kept for
+// callers that only ever talk to one registry; prefer
+// SetRegistryClientResolverForContext for multi-registry workflows.
 func SetRegistryClientForContext(ctx context.Context, c *client.Client) context.Context {
-	return context.WithValue(ctx, ClientContextKey, c)
+	return SetRegistryClientResolverForContext(ctx, singleClientResolver{client: c})
+}
+
+// SetRegistryClientResolverForContext stores a RegistryClientResolver in
+// the context.
+func SetRegistryClientResolverForContext(ctx context.Context, resolver RegistryClientResolver) context.Context {
+	return context.WithValue(ctx, ClientContextKey, resolver)
+}
+
+// GetRegistryClientFromContext resolves the registry client that should
+// handle ref, using the RegistryClientResolver stored in the context.
+func GetRegistryClientFromContext(ctx context.Context, ref string) (*client.Client, bool) {
+	resolver, ok := ctx.Value(ClientContextKey).(RegistryClientResolver)
+	if !ok {
+		return nil, false
+	}
+
+	c, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return nil, false
+	}
+
+	return c, true
 }
 
-func GetRegistryClientFromContext(ctx context.Context) (*client.Client, bool) {
-	cli, ok := ctx.Value(ClientContextKey).(*client.Client)
+// GetRegistryClientResolverFromContext returns the RegistryClientResolver
+// stored in the context, for callers that need to resolve more than one
+// reference without repeating the type assertion.
+func GetRegistryClientResolverFromContext(ctx context.Context) (RegistryClientResolver, bool) {
+	resolver, ok := ctx.Value(ClientContextKey).(RegistryClientResolver)
 
-	return cli, ok
+	return resolver, ok
 }
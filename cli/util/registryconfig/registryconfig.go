@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Cisco and/or its affiliates.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package registryconfig manages the ~/.dir/registries.yaml file that maps
+// registry aliases/hostnames to their connection settings, the same way
+// docker and helm let users configure more than one registry and select
+// between them by reference.
+package registryconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	configDirName  = ".dir"
+	configFileName = "registries.yaml"
+
+	configDirPerm  = 0o700
+	configFilePerm = 0o600
+)
+
+// Endpoint describes how to reach and authenticate against one registry.
+type Endpoint struct {
+	URL      string `yaml:"url"`
+	Auth     string `yaml:"auth,omitempty"`
+	Insecure bool   `yaml:"insecure,omitempty"`
+	CAFile   string `yaml:"ca-file,omitempty"`
+}
+
+// Config is the on-disk shape of ~/.dir/registries.yaml.
+type Config struct {
+	// Default names the registry used for references that don't name one
+	// explicitly (e.g. a bare "namespace/agent:tag").
+	Default string `yaml:"default,omitempty"`
+
+	// Registries maps an alias or hostname to its endpoint settings.
+	Registries map[string]Endpoint `yaml:"registries,omitempty"`
+}
+
+// Path returns the default location of the registry config file.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, configDirName, configFileName), nil
+}
+
+// Load reads the registry config file at path. A missing file is not an
+// error: it returns a zero-value Config so callers can fall back to a
+// single pinned client.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read registry config: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse registry config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Save writes cfg to path, creating its parent directory if needed.
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), configDirPerm); err != nil {
+		return fmt.Errorf("failed to create registry config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal registry config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, configFilePerm); err != nil {
+		return fmt.Errorf("failed to write registry config %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Upsert adds or replaces the endpoint registered under alias.
+func (c *Config) Upsert(alias string, endpoint Endpoint) {
+	if c.Registries == nil {
+		c.Registries = make(map[string]Endpoint)
+	}
+
+	c.Registries[alias] = endpoint
+}
+
+// Remove deletes the endpoint registered under alias, clearing Default if
+// it pointed at the removed alias.
+func (c *Config) Remove(alias string) {
+	delete(c.Registries, alias)
+
+	if c.Default == alias {
+		c.Default = ""
+	}
+}
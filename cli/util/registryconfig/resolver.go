@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Cisco and/or its affiliates.
+// SPDX-License-Identifier: Apache-2.0
+
+package registryconfig
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/agntcy/dir/registry/client"
+)
+
+// Resolver implements util.RegistryClientResolver against a Config,
+// picking the registry client for a reference by its alias or hostname.
+// When the config declares no registries, it falls back to a single
+// pinned client for backwards compatibility with single-registry setups.
+type Resolver struct {
+	cfg      *Config
+	fallback *client.Client
+	clients  map[string]*client.Client
+}
+
+// NewResolver builds a Resolver from cfg. fallback is returned for every
+// reference when cfg declares no registries; it may be nil if the config
+// is expected to always name a registry.
+func NewResolver(cfg *Config, fallback *client.Client) *Resolver {
+	return &Resolver{
+		cfg:      cfg,
+		fallback: fallback,
+		clients:  make(map[string]*client.Client),
+	}
+}
+
+// Resolve implements util.RegistryClientResolver.
+func (r *Resolver) Resolve(_ context.Context, ref string) (*client.Client, error) {
+	if len(r.cfg.Registries) == 0 {
+		if r.fallback == nil {
+			return nil, fmt.Errorf("no registry configured for reference %q", ref)
+		}
+
+		return r.fallback, nil
+	}
+
+	alias := registryAlias(ref, r.cfg.Default)
+
+	if c, ok := r.clients[alias]; ok {
+		return c, nil
+	}
+
+	endpoint, ok := r.cfg.Registries[alias]
+	if !ok {
+		return nil, fmt.Errorf("no registry configured for alias %q (from reference %q)", alias, ref)
+	}
+
+	c, err := client.New(
+		client.WithEndpoint(endpoint.URL),
+		client.WithAuth(endpoint.Auth),
+		client.WithTLS(endpoint.Insecure, endpoint.CAFile),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for registry %q: %w", alias, err)
+	}
+
+	r.clients[alias] = c
+
+	return c, nil
+}
+
+// registryAlias extracts the registry alias/hostname named in ref (the
+// segment before the first "/", if it looks like a host), falling back to
+// def when ref doesn't name one explicitly.
+func registryAlias(ref, def string) string {
+	if idx := strings.IndexByte(ref, '/'); idx > 0 {
+		host := ref[:idx]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			return host
+		}
+	}
+
+	if u, err := url.Parse(ref); err == nil && u.Host != "" {
+		return u.Host
+	}
+
+	return def
+}
@@ -27,7 +27,8 @@ type Client struct {
 }
 
 type options struct {
-	config *Config
+	config      *Config
+	retryPolicy RetryPolicy
 }
 
 type Option func(*options) error
@@ -49,6 +50,17 @@ func WithConfig(config *Config) Option {
 	}
 }
 
+// WithRateLimitRetry overrides the default client-side retry policy applied
+// to calls rejected with ResourceExhausted by server/middleware/ratelimit.
+// Pass RetryPolicy{} (the zero value) to disable retries entirely.
+func WithRateLimitRetry(policy RetryPolicy) Option {
+	return func(opts *options) error {
+		opts.retryPolicy = policy
+
+		return nil
+	}
+}
+
 func (c *Client) Close() error {
 	if c.closeFn == nil {
 		return nil
@@ -59,7 +71,7 @@ func (c *Client) Close() error {
 
 func New(opts ...Option) (*Client, error) {
 	// Load options
-	options := &options{}
+	options := &options{retryPolicy: DefaultRetryPolicy()}
 	for _, opt := range opts {
 		if err := opt(options); err != nil {
 			return nil, fmt.Errorf("failed to load options: %w", err)
@@ -82,6 +94,8 @@ func New(opts ...Option) (*Client, error) {
 		grpc.WithTransportCredentials(
 			grpccredentials.MTLSClientCredentials(source, source, tlsconfig.AuthorizeMemberOf(clientDomain)),
 		),
+		grpc.WithChainUnaryInterceptor(RateLimitUnaryClientInterceptor(options.retryPolicy)),
+		grpc.WithChainStreamInterceptor(RateLimitStreamClientInterceptor(options.retryPolicy)),
 	)
 	if err != nil {
 		defer source.Close()
@@ -26,6 +26,46 @@ var DefaultConfig = Config{
 type Config struct {
 	ServerAddress         string `json:"server_address,omitempty" mapstructure:"server_address"`
 	SpiffeWorkloadAddress string `json:"spiffe_workload_address,omitempty" mapstructure:"spiffe_workload_address"`
+
+	// OIDCIssuerURL is the OIDC provider's issuer URL (Keycloak, Dex,
+	// Auth0, Google, etc.), used to discover its token endpoint via
+	// /.well-known/openid-configuration. Required for AuthMode "oidc".
+	OIDCIssuerURL string `json:"oidc_issuer_url,omitempty" mapstructure:"oidc_issuer_url"`
+	// OIDCClientID is the OAuth2 client identifier registered with the
+	// issuer.
+	OIDCClientID string `json:"oidc_client_id,omitempty" mapstructure:"oidc_client_id"`
+	// OIDCClientSecret authenticates a confidential client via the
+	// client_credentials grant. Leave empty to use OIDCRefreshToken
+	// instead (e.g. for a public client that completed a device or
+	// authorization code flow out of band).
+	OIDCClientSecret string `json:"oidc_client_secret,omitempty" mapstructure:"oidc_client_secret"` //nolint:gosec // G117: intentional field for OAuth credential
+	// OIDCRefreshToken is a previously issued refresh token, used to obtain
+	// an access token via the refresh_token grant when no client secret is
+	// configured.
+	OIDCRefreshToken string `json:"oidc_refresh_token,omitempty" mapstructure:"oidc_refresh_token"` //nolint:gosec // G117: intentional field for OAuth token
+	// OIDCAudience is sent as the `audience` token request parameter, for
+	// providers (Auth0, Keycloak) that use it to select the token's intended
+	// resource server.
+	OIDCAudience string `json:"oidc_audience,omitempty" mapstructure:"oidc_audience"`
+	// OIDCCACertFile is an optional path to a PEM-encoded CA certificate
+	// bundle, appended to the system trust pool when verifying the OIDC
+	// provider's TLS certificate. Leave empty to trust only the system pool.
+	OIDCCACertFile string `json:"oidc_ca_cert_file,omitempty" mapstructure:"oidc_ca_cert_file"`
+
+	// PKCS11Module is the path to the PKCS#11 shared library (.so/.dll) used
+	// to reach the HSM. Required for AuthMode "pkcs11".
+	PKCS11Module string `json:"pkcs11_module,omitempty" mapstructure:"pkcs11_module"`
+	// PKCS11Slot is the PKCS#11 slot number holding the client key and
+	// certificate.
+	PKCS11Slot int `json:"pkcs11_slot,omitempty" mapstructure:"pkcs11_slot"`
+	// PKCS11PIN authenticates the PKCS#11 session. Leave empty for tokens
+	// that don't require a PIN for the operations used here.
+	PKCS11PIN string `json:"pkcs11_pin,omitempty" mapstructure:"pkcs11_pin"` //nolint:gosec // G117: intentional field for HSM PIN
+	// PKCS11KeyLabel is the CKA_LABEL of the private key object to sign with.
+	PKCS11KeyLabel string `json:"pkcs11_key_label,omitempty" mapstructure:"pkcs11_key_label"`
+	// PKCS11CertLabel is the CKA_LABEL of the certificate object presented
+	// as the TLS client certificate.
+	PKCS11CertLabel string `json:"pkcs11_cert_label,omitempty" mapstructure:"pkcs11_cert_label"`
 }
 
 func LoadConfig() (*Config, error) {
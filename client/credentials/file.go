@@ -0,0 +1,92 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchFile loads path via reload and registers it under name, then watches
+// the containing directory for writes to path (editors and secret managers
+// typically replace the file rather than edit it in place, which fsnotify
+// only reliably observes by watching the directory) and reapplies reload on
+// every change. The resulting tls.Config is retrieved via
+// Manager.TransportCredentials(name).
+//
+// WatchFile returns an error only if the initial load fails or the watcher
+// itself cannot be started; later reload failures are recorded in Health
+// and leave the previously loaded tls.Config in place.
+func (m *Manager) WatchFile(name, path string, reload func(data []byte) (*tls.Config, error)) error {
+	apply := func() error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			m.recordError(name, err)
+
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		cfg, err := reload(data)
+		if err != nil {
+			m.recordError(name, err)
+
+			return fmt.Errorf("failed to reload %s: %w", path, err)
+		}
+
+		m.setTLSConfig(name, cfg)
+		m.recordRotation(name, tlsConfigExpiry(cfg))
+
+		return nil
+	}
+
+	if err := apply(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher for %s: %w", name, err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	m.wg.Add(1)
+
+	go func() {
+		defer m.wg.Done()
+		defer watcher.Close()
+
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if event.Name == path && (event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) {
+					_ = apply()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				m.recordError(name, err)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/svid/jwtsvid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+// defaultJWTRefreshMargin is how long before a JWT-SVID's exp claim
+// WatchJWT pre-fetches a replacement, so callers never observe an expired
+// token even if the next RPC lands exactly at expiry.
+const defaultJWTRefreshMargin = 30 * time.Second
+
+// defaultJWTPollInterval bounds how long WatchJWT can sleep between expiry
+// checks, so a source with no expiry information yet (or a clock jump) is
+// still re-checked periodically.
+const defaultJWTPollInterval = 30 * time.Second
+
+// WatchJWT registers audience under name and keeps a fresh JWT-SVID
+// pre-fetched from src, reported via Health. It does not hand the SVID back
+// to the caller - newJWTCredentials fetches its own JWT-SVID per RPC via
+// src.FetchJWTSVID - so this only tracks rotation for health reporting and
+// to warm the Workload API's cache before expiry.
+func (m *Manager) WatchJWT(name string, src *workloadapi.JWTSource, audience string) {
+	m.wg.Add(1)
+
+	go func() {
+		defer m.wg.Done()
+
+		for {
+			svid, err := src.FetchJWTSVID(m.ctx, jwtsvid.Params{Audience: audience})
+			if err != nil {
+				if m.ctx.Err() != nil {
+					return
+				}
+
+				m.recordError(name, err)
+
+				select {
+				case <-m.ctx.Done():
+					return
+				case <-time.After(defaultJWTPollInterval):
+					continue
+				}
+			}
+
+			m.recordRotation(name, svid.Expiry)
+
+			wait := time.Until(svid.Expiry) - defaultJWTRefreshMargin
+			if wait < 0 {
+				wait = defaultJWTPollInterval
+			}
+
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+	}()
+}
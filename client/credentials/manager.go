@@ -0,0 +1,250 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package credentials centralizes rotation and health reporting for the
+// long-lived credential sources opened by the client's auth modes
+// (AuthMode "token", "jwt", "x509"): file-watched SPIFFE tokens, JWT-SVIDs
+// nearing expiry, and X509-SVIDs refreshed by the SPIFFE Workload API.
+//
+// Long-running clients that dial once and never refresh the tls.Config
+// behind credentials.NewTLS eventually fail when the underlying SVID
+// expires. Manager fixes that by watching each source and republishing a
+// fresh tls.Config (or invoking a rotation callback) whenever the
+// credential material changes, without requiring the caller to re-dial.
+package credentials
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	grpccredentials "google.golang.org/grpc/credentials"
+)
+
+// SourceHealth reports the rotation state of one watched credential source.
+type SourceHealth struct {
+	// Name identifies the source (e.g. "spiffe-token", "jwt", "x509").
+	Name string
+
+	// LastRotation is when this source's credential material was last
+	// (re)loaded successfully. Zero if it has never loaded successfully.
+	LastRotation time.Time
+
+	// NextExpectedExpiry is when the currently loaded credential is expected
+	// to expire, if known. Zero if unknown (e.g. a static file with no
+	// embedded expiry).
+	NextExpectedExpiry time.Time
+
+	// LastError is the most recent error encountered while reloading this
+	// source, if any. Cleared on the next successful reload.
+	LastError error
+}
+
+// Manager watches one or more credential sources and keeps their derived
+// tls.Config up to date, so gRPC connections built with TransportCredentials
+// survive credential rotation without being re-dialed. Construct with
+// NewManager and release with Close.
+type Manager struct {
+	ctx    context.Context //nolint:containedctx // lifetime-scoped: cancels all watch goroutines on Close
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	health map[string]SourceHealth
+
+	configs sync.Map // name -> *atomic.Pointer[tls.Config]
+}
+
+// NewManager creates a Manager with no sources watched yet. Register sources
+// with WatchFile, WatchJWT, and WatchX509.
+func NewManager() *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Manager{
+		ctx:    ctx,
+		cancel: cancel,
+		health: make(map[string]SourceHealth),
+	}
+}
+
+// Close stops all watch goroutines started by this Manager and waits for
+// them to exit. It does not close the underlying credential sources (SPIFFE
+// sources, etc.) - those remain owned by their caller.
+func (m *Manager) Close() error {
+	m.cancel()
+	m.wg.Wait()
+
+	return nil
+}
+
+// Health returns the current rotation status of every source registered
+// with this Manager, in no particular order.
+func (m *Manager) Health() []SourceHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]SourceHealth, 0, len(m.health))
+	for _, h := range m.health {
+		out = append(out, h)
+	}
+
+	return out
+}
+
+func (m *Manager) recordRotation(name string, nextExpiry time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.health[name] = SourceHealth{
+		Name:               name,
+		LastRotation:       time.Now(),
+		NextExpectedExpiry: nextExpiry,
+	}
+}
+
+func (m *Manager) recordError(name string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h := m.health[name]
+	h.Name = name
+	h.LastError = err
+	m.health[name] = h
+}
+
+func (m *Manager) configPtr(name string) *atomic.Pointer[tls.Config] {
+	v, _ := m.configs.LoadOrStore(name, &atomic.Pointer[tls.Config]{})
+
+	//nolint:forcetypeassert // value is only ever stored by configPtr itself
+	return v.(*atomic.Pointer[tls.Config])
+}
+
+func (m *Manager) setTLSConfig(name string, cfg *tls.Config) {
+	m.configPtr(name).Store(cfg)
+}
+
+// tlsConfigExpiry returns the NotAfter of cfg's leaf certificate, or the
+// zero time if cfg has no certificate or it can't be parsed.
+func tlsConfigExpiry(cfg *tls.Config) time.Time {
+	if len(cfg.Certificates) == 0 || len(cfg.Certificates[0].Certificate) == 0 {
+		return time.Time{}
+	}
+
+	leaf := cfg.Certificates[0].Leaf
+	if leaf == nil {
+		parsed, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+		if err != nil {
+			return time.Time{}
+		}
+
+		leaf = parsed
+	}
+
+	return leaf.NotAfter
+}
+
+// TransportCredentials returns gRPC transport credentials for the named
+// source. Unlike a plain credentials.NewTLS(cfg), the returned credentials
+// load the source's tls.Config fresh on every handshake instead of freezing
+// it at dial time: grpc-go's tls.Client-based credentials call tls.Client
+// directly on a static config and never consult GetConfigForClient (that
+// hook only fires on the server side), so a rotation recorded by
+// WatchFile/WatchX509 must be picked up by the client handshake itself to
+// take effect on the connection's next reconnect attempt.
+func (m *Manager) TransportCredentials(name string) grpccredentials.TransportCredentials {
+	return &liveTLSCredentials{name: name, ptr: m.configPtr(name)}
+}
+
+// liveTLSCredentials is a grpccredentials.TransportCredentials that loads its
+// tls.Config from ptr at the start of every ClientHandshake, rather than
+// capturing one at construction time.
+type liveTLSCredentials struct {
+	name string
+	ptr  *atomic.Pointer[tls.Config]
+
+	mu                 sync.Mutex
+	serverNameOverride string
+}
+
+func (c *liveTLSCredentials) ClientHandshake(
+	ctx context.Context,
+	authority string,
+	rawConn net.Conn,
+) (net.Conn, grpccredentials.AuthInfo, error) {
+	cfg := c.ptr.Load()
+	if cfg == nil {
+		return nil, nil, errors.New("credentials: no tls config loaded yet for " + c.name)
+	}
+
+	cfg = cfg.Clone()
+
+	c.mu.Lock()
+	override := c.serverNameOverride
+	c.mu.Unlock()
+
+	switch {
+	case override != "":
+		cfg.ServerName = override
+	case cfg.ServerName == "":
+		cfg.ServerName = authority
+	}
+
+	conn := tls.Client(rawConn, cfg)
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- conn.HandshakeContext(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			rawConn.Close()
+
+			return nil, nil, err
+		}
+	case <-ctx.Done():
+		rawConn.Close()
+
+		return nil, nil, ctx.Err() //nolint:wrapcheck
+	}
+
+	info := grpccredentials.TLSInfo{
+		State: conn.ConnectionState(),
+		CommonAuthInfo: grpccredentials.CommonAuthInfo{
+			SecurityLevel: grpccredentials.PrivacyAndIntegrity,
+		},
+	}
+
+	return conn, info, nil
+}
+
+func (c *liveTLSCredentials) ServerHandshake(net.Conn) (net.Conn, grpccredentials.AuthInfo, error) {
+	return nil, nil, errors.New("credentials: liveTLSCredentials is client-only")
+}
+
+func (c *liveTLSCredentials) Info() grpccredentials.ProtocolInfo {
+	return grpccredentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c *liveTLSCredentials) Clone() grpccredentials.TransportCredentials {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &liveTLSCredentials{name: c.name, ptr: c.ptr, serverNameOverride: c.serverNameOverride}
+}
+
+func (c *liveTLSCredentials) OverrideServerName(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.serverNameOverride = name
+
+	return nil
+}
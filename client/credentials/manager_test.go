@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManagerHealthEmpty(t *testing.T) {
+	m := NewManager()
+	defer m.Close()
+
+	if got := m.Health(); len(got) != 0 {
+		t.Fatalf("expected no health entries, got %v", got)
+	}
+}
+
+func TestTransportCredentialsErrorsBeforeLoad(t *testing.T) {
+	m := NewManager()
+	defer m.Close()
+
+	creds := m.TransportCredentials("unloaded")
+	if creds == nil {
+		t.Fatal("expected non-nil TransportCredentials")
+	}
+
+	if _, _, err := creds.ClientHandshake(context.Background(), "localhost:443", nil); err == nil {
+		t.Fatal("expected ClientHandshake to fail before any tls config is loaded")
+	}
+}
+
+func TestWatchFileLoadsAndReloads(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+
+	if err := os.WriteFile(path, []byte("v1"), 0o600); err != nil {
+		t.Fatalf("failed to write initial file: %v", err)
+	}
+
+	reloadCount := 0
+	reload := func(data []byte) (*tls.Config, error) {
+		reloadCount++
+
+		return &tls.Config{ServerName: string(data)}, nil
+	}
+
+	m := NewManager()
+	defer m.Close()
+
+	if err := m.WatchFile("test", path, reload); err != nil {
+		t.Fatalf("WatchFile failed: %v", err)
+	}
+
+	if reloadCount != 1 {
+		t.Fatalf("expected 1 initial reload, got %d", reloadCount)
+	}
+
+	health := m.Health()
+	if len(health) != 1 || health[0].Name != "test" || health[0].LastRotation.IsZero() {
+		t.Fatalf("unexpected health after initial load: %v", health)
+	}
+
+	if err := os.WriteFile(path, []byte("v2"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite file: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for reloadCount < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if reloadCount < 2 {
+		t.Fatalf("expected file watcher to trigger a reload, got %d reloads", reloadCount)
+	}
+}
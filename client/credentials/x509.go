@@ -0,0 +1,84 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// x509SVIDGetter is satisfied by workloadapi.X509Source. Narrowed to a
+// single method so tests can supply a stub without standing up a Workload
+// API.
+type x509SVIDGetter interface {
+	GetX509SVID() (*x509svid.SVID, error)
+}
+
+// defaultX509PollInterval is how often WatchX509 checks src for a new
+// X509-SVID. The Workload API streams updates to src in the background;
+// this is only how promptly the Manager notices and reports the change.
+const defaultX509PollInterval = 10 * time.Second
+
+// WatchX509 registers src (typically a *workloadapi.X509Source) under name
+// and polls it for SVID rotation. Whenever the leaf certificate's serial
+// number changes, onRotate is called with the new SVID so the caller can
+// gracefully re-dial or otherwise react, and Health is updated with the new
+// rotation time and expiry. onRotate may be nil.
+//
+// WatchX509 does not itself build a tls.Config for name - x509Src is
+// normally consumed directly by grpccredentials.MTLSClientCredentials,
+// which already reads the latest SVID from the source on every handshake.
+// WatchX509 exists to surface rotation events and health, not to replace
+// that wiring.
+func (m *Manager) WatchX509(name string, src x509SVIDGetter, onRotate func(*x509svid.SVID)) {
+	m.wg.Add(1)
+
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(defaultX509PollInterval)
+		defer ticker.Stop()
+
+		var lastSerial string
+
+		check := func() {
+			svid, err := src.GetX509SVID()
+			if err != nil {
+				m.recordError(name, err)
+
+				return
+			}
+
+			if svid == nil || len(svid.Certificates) == 0 {
+				return
+			}
+
+			leaf := svid.Certificates[0]
+
+			serial := leaf.SerialNumber.String()
+			if serial == lastSerial {
+				return
+			}
+
+			lastSerial = serial
+			m.recordRotation(name, leaf.NotAfter)
+
+			if onRotate != nil {
+				onRotate(svid)
+			}
+		}
+
+		check()
+
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-ticker.C:
+				check()
+			}
+		}
+	}()
+}
@@ -5,6 +5,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 
 	"google.golang.org/grpc/credentials"
 )
@@ -33,3 +34,35 @@ func newGitHubCredentials(token string) credentials.PerRPCCredentials {
 		token: token,
 	}
 }
+
+// tokenSourceCredentials implements credentials.PerRPCCredentials by pulling
+// a fresh token from a TokenSource on every request, so a refreshed (or
+// about-to-expire) token is picked up automatically without reconnecting.
+type tokenSourceCredentials struct {
+	source TokenSource
+}
+
+// GetRequestMetadata attaches the token source's current access token to the
+// request metadata as a Bearer token, refreshing it first if needed.
+func (c *tokenSourceCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	token, err := c.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	return map[string]string{
+		"authorization": "Bearer " + token.AccessToken,
+	}, nil
+}
+
+// RequireTransportSecurity returns false because the Envoy gateway handles
+// TLS termination externally, same as githubPerRPCCredentials.
+func (c *tokenSourceCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// newTokenSourceCredentials creates a new PerRPCCredentials that injects a
+// Bearer token fetched fresh from source on every call.
+func newTokenSourceCredentials(source TokenSource) credentials.PerRPCCredentials {
+	return &tokenSourceCredentials{source: source}
+}
@@ -268,3 +268,39 @@ func TestGitHubPerRPCCredentials_TokenFormats(t *testing.T) {
 		}
 	})
 }
+
+// staticTokenSource is a TokenSource that always returns the same token, or
+// always fails, used to test tokenSourceCredentials without a real provider.
+type staticTokenSource struct {
+	token *Token
+	err   error
+}
+
+func (s *staticTokenSource) Token() (*Token, error) {
+	return s.token, s.err
+}
+
+func TestTokenSourceCredentials_GetRequestMetadata(t *testing.T) {
+	t.Run("should add the source's current token as a Bearer token", func(t *testing.T) {
+		creds := newTokenSourceCredentials(&staticTokenSource{token: &Token{AccessToken: "gho_fromsource"}})
+
+		metadata, err := creds.GetRequestMetadata(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer gho_fromsource", metadata["authorization"])
+	})
+
+	t.Run("should propagate a source error", func(t *testing.T) {
+		creds := newTokenSourceCredentials(&staticTokenSource{err: assert.AnError})
+
+		_, err := creds.GetRequestMetadata(context.Background())
+
+		require.Error(t, err)
+	})
+
+	t.Run("should not require transport security", func(t *testing.T) {
+		creds := newTokenSourceCredentials(&staticTokenSource{token: &Token{AccessToken: "x"}})
+
+		assert.False(t, creds.RequireTransportSecurity())
+	})
+}
@@ -12,18 +12,28 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/agntcy/dir/client/qrcode"
 )
 
 const (
-	// GitHub OAuth2 Device Flow endpoints.
-	githubDeviceCodeURL  = "https://github.com/login/device/code"        //nolint:gosec // G101: URL endpoint, not a credential
-	githubDeviceTokenURL = "https://github.com/login/oauth/access_token" //nolint:gosec // G101: URL endpoint, not a credential
-
-	// Device flow polling configuration.
+	// Device flow polling configuration. These are the defaults used when a
+	// DeviceAuthConfig doesn't override them via PollInterval/PollTimeout.
 	defaultDeviceInterval = 5 * time.Second
 	devicePollTimeout     = 15 * time.Minute
 
+	// maxPollInterval caps the exponential backoff applied to the polling
+	// interval on slow_down errors that don't carry their own updated
+	// interval, per the latitude RFC 8628 section 3.5 gives clients.
+	maxPollInterval = time.Minute
+
+	// defaultTokenExpiry is used when a provider's token response omits
+	// expires_in entirely (some providers issue long-lived device tokens),
+	// unless a DeviceAuthConfig overrides it via AssumedTokenLifetime.
+	defaultTokenExpiry = 8 * time.Hour
+
 	// HTTP client timeout for API requests.
 	httpTimeout = 30 * time.Second
 
@@ -34,9 +44,34 @@ const (
 
 	// Time conversion constants.
 	secondsPerMinute = 60
+
+	// RFC 8628 section 3.5 polling error codes.
+	errCodeAuthorizationPending = "authorization_pending"
+	errCodeSlowDown             = "slow_down"
+	errCodeAccessDenied         = "access_denied"
+	errCodeExpiredToken         = "expired_token"
+)
+
+// AuthStyle hints how a client authenticates itself to a provider's token
+// endpoint, per RFC 6749 section 2.3.
+type AuthStyle int
+
+const (
+	// AuthStyleAutoDetect sends client credentials as form parameters first,
+	// retries with HTTP Basic auth if the token endpoint answers HTTP 401, and
+	// caches whichever style was accepted per TokenURL.
+	AuthStyleAutoDetect AuthStyle = iota
+	// AuthStyleInParams sends the client_id (and client_secret, if set) as
+	// form parameters in the request body.
+	AuthStyleInParams
+	// AuthStyleInHeader sends the client_id/client_secret as HTTP Basic auth
+	// credentials instead of form parameters.
+	AuthStyleInHeader
 )
 
-// defaultHTTPClient is a shared HTTP client with connection pooling for efficiency.
+// defaultHTTPClient is a shared HTTP client with connection pooling for
+// efficiency, used unless a DeviceAuthConfig supplies its own via HTTPClient
+// (e.g. for callers behind a corporate proxy or with a custom TLS root).
 var defaultHTTPClient = &http.Client{
 	Timeout: httpTimeout,
 	Transport: &http.Transport{
@@ -46,51 +81,173 @@ var defaultHTTPClient = &http.Client{
 	},
 }
 
-// DeviceFlowConfig configures the device authorization flow.
-type DeviceFlowConfig struct {
+// Clock abstracts time so the device flow's polling loop can be driven
+// deterministically in tests. The zero value of DeviceAuthConfig uses
+// defaultClock, a thin wrapper over the time package.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time once d has
+	// elapsed, mirroring time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock used when a DeviceAuthConfig doesn't supply one.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// defaultClock is the real-time Clock used unless a DeviceAuthConfig
+// overrides it via Clock.
+var defaultClock Clock = realClock{}
+
+// DeviceAuthConfig configures an RFC 8628 OAuth2 device authorization flow
+// against any compliant provider. Use a preset from the client/presets
+// subpackage for well-known providers, or construct one directly for a
+// self-hosted or otherwise unlisted provider.
+type DeviceAuthConfig struct {
+	// DeviceAuthURL is the provider's device authorization endpoint.
+	DeviceAuthURL string
+	// TokenURL is the provider's token endpoint, polled during the flow.
+	TokenURL string
+	// ClientID is the OAuth2 client identifier.
 	ClientID string
-	Scopes   []string
-	Output   io.Writer // Where to write user instructions (default: os.Stdout)
+	// ClientSecret is the OAuth2 client secret. Optional: many device flow
+	// clients are public and don't have one.
+	ClientSecret string
+	// Scopes are the OAuth2 scopes to request.
+	Scopes []string
+	// AuthStyle hints how ClientID/ClientSecret are sent to TokenURL.
+	AuthStyle AuthStyle
+	// Output is where user instructions are written (default: io.Discard).
+	Output io.Writer
+	// QRCode controls whether the verification URL is also rendered as a
+	// scannable QR code. Nil (the zero value) defaults to rendering one when
+	// Output is a terminal; set explicitly to force it on or off.
+	QRCode *bool
+
+	// PollInterval overrides the polling interval used when the device
+	// authorization response omits one. Zero uses defaultDeviceInterval.
+	PollInterval time.Duration
+	// PollTimeout overrides how long StartDeviceFlow polls before giving up.
+	// Zero uses devicePollTimeout.
+	PollTimeout time.Duration
+	// AssumedTokenLifetime overrides the lifetime assumed for an access (or
+	// refreshed) token when the provider's response omits expires_in. Zero
+	// uses defaultTokenExpiry.
+	AssumedTokenLifetime time.Duration
+	// Clock overrides the source of time used while polling. Nil uses
+	// defaultClock; set this in tests to drive the polling loop
+	// deterministically.
+	Clock Clock
+	// HTTPClient overrides the HTTP client used for requests to
+	// DeviceAuthURL and TokenURL. Nil uses defaultHTTPClient. Set this to
+	// plug in a client behind a corporate proxy or with a custom TLS root.
+	HTTPClient *http.Client
+}
+
+// pollInterval returns the polling interval to start with when the device
+// authorization response didn't specify one itself.
+func pollInterval(config *DeviceAuthConfig) time.Duration {
+	if config.PollInterval > 0 {
+		return config.PollInterval
+	}
+
+	return defaultDeviceInterval
+}
+
+// pollTimeout returns how long StartDeviceFlow should poll before giving up.
+func pollTimeout(config *DeviceAuthConfig) time.Duration {
+	if config.PollTimeout > 0 {
+		return config.PollTimeout
+	}
+
+	return devicePollTimeout
+}
+
+// assumedTokenLifetime returns the lifetime to assume for a token whose
+// response omitted expires_in.
+func assumedTokenLifetime(config *DeviceAuthConfig) time.Duration {
+	if config.AssumedTokenLifetime > 0 {
+		return config.AssumedTokenLifetime
+	}
+
+	return defaultTokenExpiry
+}
+
+// clockFor returns config's Clock, or defaultClock if it didn't set one.
+func clockFor(config *DeviceAuthConfig) Clock {
+	if config.Clock != nil {
+		return config.Clock
+	}
+
+	return defaultClock
+}
+
+// httpClientFor returns config's HTTPClient, or defaultHTTPClient if it
+// didn't set one.
+func httpClientFor(config *DeviceAuthConfig) *http.Client {
+	if config.HTTPClient != nil {
+		return config.HTTPClient
+	}
+
+	return defaultHTTPClient
+}
+
+// wantsQRCode reports whether config should render a QR code, applying the
+// terminal-detection default when QRCode hasn't been set explicitly.
+func wantsQRCode(config *DeviceAuthConfig) bool {
+	if config.QRCode != nil {
+		return *config.QRCode
+	}
+
+	return isTerminal(config.Output)
 }
 
-// DeviceCodeResponse is the response from GitHub's device code endpoint.
+// DeviceCodeResponse is the RFC 8628 device authorization response.
 type DeviceCodeResponse struct {
-	DeviceCode      string `json:"device_code"`
-	UserCode        string `json:"user_code"`
-	VerificationURI string `json:"verification_uri"`
-	ExpiresIn       int    `json:"expires_in"`
-	Interval        int    `json:"interval"` // Minimum seconds between polls
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"` // Minimum seconds between polls
 }
 
-// DeviceTokenResponse is the response from GitHub's token endpoint during polling.
+// DeviceTokenResponse is the RFC 8628 token endpoint response during polling.
 type DeviceTokenResponse struct {
 	AccessToken      string `json:"access_token"` //nolint:gosec // G117: intentional field for OAuth token
 	TokenType        string `json:"token_type"`
 	Scope            string `json:"scope"`
+	ExpiresIn        int    `json:"expires_in,omitempty"`
+	RefreshToken     string `json:"refresh_token,omitempty"` //nolint:gosec // G117: intentional field for OAuth token
 	Error            string `json:"error,omitempty"`
 	ErrorDescription string `json:"error_description,omitempty"`
-	Interval         int    `json:"interval,omitempty"` // New interval when slow_down is returned
+	Interval         int    `json:"interval,omitempty"` // Updated interval when slow_down is returned
 }
 
 // DeviceFlowResult contains the successful device flow result.
 type DeviceFlowResult struct {
-	AccessToken string //nolint:gosec // G117: intentional field for OAuth token
-	TokenType   string
-	Scope       string
-	ExpiresAt   time.Time // Calculated expiry (GitHub doesn't provide expires_in for device flow)
+	AccessToken  string //nolint:gosec // G117: intentional field for OAuth token
+	TokenType    string
+	Scope        string
+	RefreshToken string    //nolint:gosec // G117: intentional field for OAuth token
+	ExpiresAt    time.Time // Calculated from the token response's expires_in, when present.
 }
 
-// StartDeviceFlow initiates GitHub OAuth2 device authorization flow.
-// This flow is ideal for CLI applications, SSH sessions, and headless environments.
+// StartDeviceFlow runs an RFC 8628 OAuth2 device authorization flow against
+// the provider described by config. This flow is ideal for CLI applications,
+// SSH sessions, and headless environments.
 //
 // The flow:
-//  1. Request device and user codes from GitHub
+//  1. Request device and user codes from config.DeviceAuthURL
 //  2. Display verification URL and user code to the user
-//  3. Poll GitHub until user completes authorization
-//  4. Return access token
+//  3. Poll config.TokenURL until the user completes authorization
+//  4. Return the access token
 //
 // The user can complete authorization on any device (phone, laptop, etc.).
-func StartDeviceFlow(ctx context.Context, config *DeviceFlowConfig) (*DeviceFlowResult, error) {
+func StartDeviceFlow(ctx context.Context, config *DeviceAuthConfig) (*DeviceFlowResult, error) {
 	if config == nil {
 		return nil, errors.New("config is required")
 	}
@@ -99,6 +256,14 @@ func StartDeviceFlow(ctx context.Context, config *DeviceFlowConfig) (*DeviceFlow
 		return nil, errors.New("ClientID is required")
 	}
 
+	if config.DeviceAuthURL == "" {
+		return nil, errors.New("DeviceAuthURL is required")
+	}
+
+	if config.TokenURL == "" {
+		return nil, errors.New("TokenURL is required")
+	}
+
 	if config.Output == nil {
 		config.Output = io.Discard
 	}
@@ -110,7 +275,7 @@ func StartDeviceFlow(ctx context.Context, config *DeviceFlowConfig) (*DeviceFlow
 	}
 
 	// Step 2: Display instructions to user
-	displayDeviceInstructions(config.Output, deviceCode)
+	displayDeviceInstructions(config, deviceCode)
 
 	// Step 3: Poll for access token
 	token, err := pollForDeviceToken(ctx, config, deviceCode)
@@ -118,20 +283,24 @@ func StartDeviceFlow(ctx context.Context, config *DeviceFlowConfig) (*DeviceFlow
 		return nil, fmt.Errorf("failed to complete device authorization: %w", err)
 	}
 
-	// GitHub device flow tokens don't include expires_in, but GitHub OAuth tokens
-	// typically expire after 8 hours. We set a conservative 8-hour expiry.
-	const githubTokenExpiry = 8 * time.Hour
+	now := clockFor(config).Now()
+	expiresAt := now.Add(assumedTokenLifetime(config))
+
+	if token.ExpiresIn > 0 {
+		expiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
 
 	return &DeviceFlowResult{
-		AccessToken: token.AccessToken,
-		TokenType:   token.TokenType,
-		Scope:       token.Scope,
-		ExpiresAt:   time.Now().Add(githubTokenExpiry),
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		Scope:        token.Scope,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    expiresAt,
 	}, nil
 }
 
-// requestDeviceCode requests device and user codes from GitHub.
-func requestDeviceCode(ctx context.Context, config *DeviceFlowConfig) (*DeviceCodeResponse, error) {
+// requestDeviceCode requests device and user codes from config.DeviceAuthURL.
+func requestDeviceCode(ctx context.Context, config *DeviceAuthConfig) (*DeviceCodeResponse, error) {
 	data := url.Values{}
 	data.Set("client_id", config.ClientID)
 
@@ -139,7 +308,7 @@ func requestDeviceCode(ctx context.Context, config *DeviceFlowConfig) (*DeviceCo
 		data.Set("scope", strings.Join(config.Scopes, " "))
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, githubDeviceCodeURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.DeviceAuthURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -147,7 +316,7 @@ func requestDeviceCode(ctx context.Context, config *DeviceFlowConfig) (*DeviceCo
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := defaultHTTPClient.Do(req) //nolint:gosec // G704: request URL is from configured base URL (githubDeviceCodeURL); caller must use a trusted endpoint
+	resp, err := httpClientFor(config).Do(req) //nolint:gosec // G704: request URL comes from the caller-supplied, trusted DeviceAuthConfig
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -156,7 +325,7 @@ func requestDeviceCode(ctx context.Context, config *DeviceFlowConfig) (*DeviceCo
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 
-		return nil, fmt.Errorf("GitHub API error (HTTP %d): %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("device authorization endpoint error (HTTP %d): %s", resp.StatusCode, string(body))
 	}
 
 	var deviceResp DeviceCodeResponse
@@ -167,35 +336,62 @@ func requestDeviceCode(ctx context.Context, config *DeviceFlowConfig) (*DeviceCo
 	return &deviceResp, nil
 }
 
-// displayDeviceInstructions shows the user how to complete authorization.
-func displayDeviceInstructions(w io.Writer, deviceCode *DeviceCodeResponse) {
+// displayDeviceInstructions shows the user how to complete authorization. If
+// deviceCode carries a verification_uri_complete and config opts into it
+// (directly or via TTY auto-detection), it's also rendered as a QR code so a
+// phone can scan it instead of the user typing the code by hand. Any QR
+// encoding failure (e.g. an unusually long URL) falls back to the plain text
+// instructions.
+func displayDeviceInstructions(config *DeviceAuthConfig, deviceCode *DeviceCodeResponse) {
+	w := config.Output
+
 	fmt.Fprintf(w, "\n")
 	fmt.Fprintf(w, "🔐 To authenticate, please follow these steps:\n")
 	fmt.Fprintf(w, "\n")
-	fmt.Fprintf(w, "  1. Visit: %s\n", deviceCode.VerificationURI)
-	fmt.Fprintf(w, "  2. Enter code: %s\n", deviceCode.UserCode)
+
+	qrRendered := false
+
+	if deviceCode.VerificationURIComplete != "" && wantsQRCode(config) {
+		if code, err := qrcode.Encode(deviceCode.VerificationURIComplete); err == nil {
+			fmt.Fprintf(w, "  Scan this QR code, or visit: %s\n\n", deviceCode.VerificationURIComplete)
+			fmt.Fprint(w, code.String())
+			fmt.Fprintf(w, "\n")
+
+			qrRendered = true
+		}
+	}
+
+	if !qrRendered {
+		if deviceCode.VerificationURIComplete != "" {
+			fmt.Fprintf(w, "  1. Visit: %s\n", deviceCode.VerificationURIComplete)
+		} else {
+			fmt.Fprintf(w, "  1. Visit: %s\n", deviceCode.VerificationURI)
+			fmt.Fprintf(w, "  2. Enter code: %s\n", deviceCode.UserCode)
+		}
+	}
+
 	fmt.Fprintf(w, "\n")
 	fmt.Fprintf(w, "💡 You can complete this on any device (phone, laptop, etc.)\n")
 	fmt.Fprintf(w, "⏱️  Code expires in %d minutes\n", deviceCode.ExpiresIn/secondsPerMinute)
 	fmt.Fprintf(w, "\n")
 }
 
-// pollForDeviceToken polls GitHub until user completes authorization.
-func pollForDeviceToken(ctx context.Context, config *DeviceFlowConfig, deviceCode *DeviceCodeResponse) (*DeviceTokenResponse, error) {
+// pollForDeviceToken polls config.TokenURL until the user completes authorization.
+func pollForDeviceToken(ctx context.Context, config *DeviceAuthConfig, deviceCode *DeviceCodeResponse) (*DeviceTokenResponse, error) {
 	interval := time.Duration(deviceCode.Interval) * time.Second
 	if interval == 0 {
-		interval = defaultDeviceInterval
+		interval = pollInterval(config)
 	}
 
 	// Create timeout context
-	pollCtx, cancel := context.WithTimeout(ctx, devicePollTimeout)
+	pollCtx, cancel := context.WithTimeout(ctx, pollTimeout(config))
 	defer cancel()
 
 	// Show waiting message
 	fmt.Fprintf(config.Output, "Waiting for authorization...\n")
 
 	// Poll for token
-	token, err := pollForToken(pollCtx, config.ClientID, deviceCode.DeviceCode, interval)
+	token, err := pollForToken(pollCtx, config, deviceCode.DeviceCode, interval)
 	if err != nil {
 		return nil, err
 	}
@@ -205,24 +401,27 @@ func pollForDeviceToken(ctx context.Context, config *DeviceFlowConfig, deviceCod
 	return token, nil
 }
 
-// pollForToken polls GitHub's token endpoint until authorization completes or fails.
-func pollForToken(ctx context.Context, clientID, deviceCode string, initialInterval time.Duration) (*DeviceTokenResponse, error) {
-	ticker := time.NewTicker(initialInterval)
-	defer ticker.Stop()
+// pollForToken polls the token endpoint until authorization completes or fails.
+// The wait between attempts is driven by config's Clock (config.Clock, or
+// defaultClock) rather than a ticker, so tests can drive the loop without
+// real waits.
+func pollForToken(ctx context.Context, config *DeviceAuthConfig, deviceCode string, initialInterval time.Duration) (*DeviceTokenResponse, error) {
+	clock := clockFor(config)
+	interval := initialInterval
 
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("authorization timed out after %v", devicePollTimeout)
+			return nil, fmt.Errorf("authorization timed out after %v", pollTimeout(config))
 
-		case <-ticker.C:
-			tokenResp, err := checkDeviceToken(ctx, clientID, deviceCode)
+		case <-clock.After(interval):
+			tokenResp, err := checkDeviceToken(ctx, config, deviceCode)
 			if err != nil {
 				// Check if it's a retryable error
 				if isRetryableDeviceError(err) {
-					// Adjust polling interval if GitHub tells us to slow down
-					if adjustedInterval := getAdjustedInterval(err); adjustedInterval > 0 {
-						ticker.Reset(adjustedInterval)
+					// Adjust polling interval if the provider tells us to slow down
+					if adjusted := getAdjustedInterval(err, interval); adjusted > 0 {
+						interval = adjusted
 					}
 
 					continue // Keep polling
@@ -242,43 +441,37 @@ func pollForToken(ctx context.Context, clientID, deviceCode string, initialInter
 	}
 }
 
-// getAdjustedInterval extracts the new polling interval from a slow_down error.
-// Returns 0 if no adjustment is needed.
-func getAdjustedInterval(err error) time.Duration {
+// getAdjustedInterval computes the new polling interval from a slow_down
+// error. It uses the provider's NewInterval when given, otherwise doubles
+// the current interval up to maxPollInterval, per the latitude RFC 8628
+// section 3.5 gives clients. Returns 0 for any other error, meaning no
+// adjustment is needed.
+func getAdjustedInterval(err error, current time.Duration) time.Duration {
 	var deviceErr *DeviceFlowError
-	if errors.As(err, &deviceErr) && deviceErr.Code == "slow_down" {
-		if deviceErr.NewInterval > 0 {
-			return time.Duration(deviceErr.NewInterval) * time.Second
-		}
+	if !errors.As(err, &deviceErr) || deviceErr.Code != errCodeSlowDown {
+		return 0
+	}
+
+	if deviceErr.NewInterval > 0 {
+		return time.Duration(deviceErr.NewInterval) * time.Second
+	}
+
+	if doubled := current * 2; doubled < maxPollInterval {
+		return doubled
 	}
 
-	return 0
+	return maxPollInterval
 }
 
-// checkDeviceToken attempts to exchange device code for access token.
-func checkDeviceToken(ctx context.Context, clientID, deviceCode string) (*DeviceTokenResponse, error) {
+// checkDeviceToken attempts to exchange the device code for an access token.
+func checkDeviceToken(ctx context.Context, config *DeviceAuthConfig, deviceCode string) (*DeviceTokenResponse, error) {
 	data := url.Values{}
-	data.Set("client_id", clientID)
 	data.Set("device_code", deviceCode)
 	data.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, githubDeviceTokenURL, strings.NewReader(data.Encode()))
+	tokenResp, err := exchangeWithAuthStyle(ctx, config, data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := defaultHTTPClient.Do(req) //nolint:gosec // G704: request URL is from configured base URL (githubDeviceTokenURL); caller must use a trusted endpoint
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var tokenResp DeviceTokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
 
 	// Handle OAuth2 error responses
@@ -292,18 +485,162 @@ func checkDeviceToken(ctx context.Context, clientID, deviceCode string) (*Device
 
 	// Access token received, authorization complete
 	if tokenResp.AccessToken != "" {
-		return &tokenResp, nil
+		return tokenResp, nil
 	}
 
 	// No error, no token - should not happen
-	return nil, errors.New("unexpected empty response from GitHub")
+	return nil, errors.New("unexpected empty response from token endpoint")
+}
+
+// refreshDeviceToken exchanges refreshToken for a new access token using the
+// RFC 6749 section 6 refresh_token grant against config.TokenURL.
+func refreshDeviceToken(ctx context.Context, config *DeviceAuthConfig, refreshToken string) (*Token, error) {
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", refreshToken)
+
+	tokenResp, err := exchangeWithAuthStyle(ctx, config, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenResp.Error != "" {
+		return nil, &DeviceFlowError{Code: tokenResp.Error, Description: tokenResp.ErrorDescription}
+	}
+
+	if tokenResp.AccessToken == "" {
+		return nil, errors.New("unexpected empty response from token endpoint")
+	}
+
+	now := clockFor(config).Now()
+	expiresAt := now.Add(assumedTokenLifetime(config))
+
+	if tokenResp.ExpiresIn > 0 {
+		expiresAt = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	// Some providers omit refresh_token on renewal, meaning the original one
+	// stays valid for the next refresh too.
+	newRefreshToken := tokenResp.RefreshToken
+	if newRefreshToken == "" {
+		newRefreshToken = refreshToken
+	}
+
+	return &Token{
+		AccessToken:  tokenResp.AccessToken,
+		TokenType:    tokenResp.TokenType,
+		RefreshToken: newRefreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
 }
 
-// DeviceFlowError represents an OAuth2 device flow error.
+// authStyleCache remembers, per TokenURL, which AuthStyle a provider
+// accepted the last time AuthStyleAutoDetect had to probe for it. This
+// mirrors the cache golang.org/x/oauth2 keeps internally, and means only
+// the first device or refresh flow against a given provider in the process
+// pays for the extra round-trip.
+var authStyleCache sync.Map // TokenURL string -> AuthStyle
+
+// addClientCredentials sets client_id (and client_secret, if set) as form
+// parameters, unless style is AuthStyleInHeader (handled instead via HTTP
+// Basic auth in attemptTokenExchange).
+func addClientCredentials(data url.Values, config *DeviceAuthConfig, style AuthStyle) {
+	if style == AuthStyleInHeader {
+		return
+	}
+
+	data.Set("client_id", config.ClientID)
+
+	if config.ClientSecret != "" {
+		data.Set("client_secret", config.ClientSecret)
+	}
+}
+
+// exchangeWithAuthStyle POSTs data to config.TokenURL and decodes the RFC
+// 8628 token response, resolving config.AuthStyle first. When AuthStyle is
+// AuthStyleAutoDetect, it uses the style cached for TokenURL if a prior call
+// already discovered one; otherwise it tries AuthStyleInParams first and
+// falls back to AuthStyleInHeader on an HTTP 401, caching whichever style
+// the provider accepted so later calls skip the probe.
+func exchangeWithAuthStyle(ctx context.Context, config *DeviceAuthConfig, data url.Values) (*DeviceTokenResponse, error) {
+	if config.AuthStyle != AuthStyleAutoDetect {
+		tokenResp, _, err := attemptTokenExchange(ctx, config, data, config.AuthStyle)
+
+		return tokenResp, err
+	}
+
+	if cached, ok := authStyleCache.Load(config.TokenURL); ok {
+		tokenResp, _, err := attemptTokenExchange(ctx, config, data, cached.(AuthStyle)) //nolint:forcetypeassert
+
+		return tokenResp, err
+	}
+
+	tokenResp, status, err := attemptTokenExchange(ctx, config, data, AuthStyleInParams)
+	if status == http.StatusUnauthorized {
+		if altResp, altStatus, altErr := attemptTokenExchange(ctx, config, data, AuthStyleInHeader); altErr == nil && altStatus != http.StatusUnauthorized {
+			authStyleCache.Store(config.TokenURL, AuthStyleInHeader)
+
+			return altResp, nil
+		}
+	}
+
+	authStyleCache.Store(config.TokenURL, AuthStyleInParams)
+
+	return tokenResp, err
+}
+
+// attemptTokenExchange POSTs data to config.TokenURL using style to decide
+// whether client credentials go in the body or an HTTP Basic auth header,
+// and decodes the RFC 8628 token response. It also returns the raw HTTP
+// status code so callers can detect a style-related 401 even when the body
+// isn't valid JSON.
+func attemptTokenExchange(ctx context.Context, config *DeviceAuthConfig, data url.Values, style AuthStyle) (*DeviceTokenResponse, int, error) {
+	attempt := cloneValues(data)
+	addClientCredentials(attempt, config, style)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.TokenURL, strings.NewReader(attempt.Encode()))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if style == AuthStyleInHeader {
+		req.SetBasicAuth(config.ClientID, config.ClientSecret)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClientFor(config).Do(req) //nolint:gosec // G704: request URL comes from the caller-supplied, trusted DeviceAuthConfig
+	if err != nil {
+		return nil, 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tokenResp DeviceTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &tokenResp, resp.StatusCode, nil
+}
+
+// cloneValues returns a shallow copy of v, so a retry with a different
+// AuthStyle doesn't see form fields left over from a prior attempt.
+func cloneValues(v url.Values) url.Values {
+	clone := make(url.Values, len(v))
+
+	for key, vals := range v {
+		clone[key] = append([]string(nil), vals...)
+	}
+
+	return clone
+}
+
+// DeviceFlowError represents an RFC 8628 section 3.5 device flow polling error.
 type DeviceFlowError struct {
 	Code        string
 	Description string
-	NewInterval int // New polling interval (for slow_down errors)
+	NewInterval int // Updated polling interval (for slow_down errors that carry one)
 }
 
 // Error implements the error interface.
@@ -315,7 +652,9 @@ func (e *DeviceFlowError) Error() string {
 	return e.Code
 }
 
-// isRetryableDeviceError checks if the error is expected during polling.
+// isRetryableDeviceError checks if err is one of the two RFC 8628 polling
+// errors that mean "keep polling" (authorization_pending, slow_down), as
+// opposed to the two that mean "stop" (access_denied, expired_token).
 func isRetryableDeviceError(err error) bool {
 	var deviceErr *DeviceFlowError
 	if !errors.As(err, &deviceErr) {
@@ -323,17 +662,9 @@ func isRetryableDeviceError(err error) bool {
 	}
 
 	switch deviceErr.Code {
-	case "authorization_pending":
-		// User hasn't completed authorization yet - keep polling
+	case errCodeAuthorizationPending, errCodeSlowDown:
 		return true
-	case "slow_down":
-		// We're polling too fast - keep polling but will use longer interval
-		return true
-	case "expired_token":
-		// Device code expired - stop polling
-		return false
-	case "access_denied":
-		// User declined authorization - stop polling
+	case errCodeAccessDenied, errCodeExpiredToken:
 		return false
 	default:
 		return false
@@ -8,6 +8,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -106,26 +110,37 @@ func TestIsRetryableDeviceError(t *testing.T) {
 }
 
 func TestGetAdjustedInterval(t *testing.T) {
-	t.Run("should return adjusted interval for slow_down error", func(t *testing.T) {
+	t.Run("should return adjusted interval for slow_down error with NewInterval", func(t *testing.T) {
 		err := &DeviceFlowError{
 			Code:        "slow_down",
 			NewInterval: 10,
 		}
 
-		interval := getAdjustedInterval(err)
+		interval := getAdjustedInterval(err, 5*time.Second)
 
 		assert.Equal(t, 10*time.Second, interval)
 	})
 
-	t.Run("should return 0 for slow_down without NewInterval", func(t *testing.T) {
+	t.Run("should double interval for slow_down without NewInterval", func(t *testing.T) {
 		err := &DeviceFlowError{
 			Code:        "slow_down",
 			NewInterval: 0,
 		}
 
-		interval := getAdjustedInterval(err)
+		interval := getAdjustedInterval(err, 5*time.Second)
 
-		assert.Equal(t, time.Duration(0), interval)
+		assert.Equal(t, 10*time.Second, interval)
+	})
+
+	t.Run("should cap doubled interval at maxPollInterval", func(t *testing.T) {
+		err := &DeviceFlowError{
+			Code:        "slow_down",
+			NewInterval: 0,
+		}
+
+		interval := getAdjustedInterval(err, 50*time.Second)
+
+		assert.Equal(t, maxPollInterval, interval)
 	})
 
 	t.Run("should return 0 for non-slow_down error", func(t *testing.T) {
@@ -134,7 +149,7 @@ func TestGetAdjustedInterval(t *testing.T) {
 			NewInterval: 10,
 		}
 
-		interval := getAdjustedInterval(err)
+		interval := getAdjustedInterval(err, 5*time.Second)
 
 		assert.Equal(t, time.Duration(0), interval)
 	})
@@ -142,13 +157,13 @@ func TestGetAdjustedInterval(t *testing.T) {
 	t.Run("should return 0 for non-DeviceFlowError", func(t *testing.T) {
 		err := errors.New("some other error")
 
-		interval := getAdjustedInterval(err)
+		interval := getAdjustedInterval(err, 5*time.Second)
 
 		assert.Equal(t, time.Duration(0), interval)
 	})
 
 	t.Run("should return 0 for nil error", func(t *testing.T) {
-		interval := getAdjustedInterval(nil)
+		interval := getAdjustedInterval(nil, 5*time.Second)
 
 		assert.Equal(t, time.Duration(0), interval)
 	})
@@ -164,7 +179,7 @@ func TestDisplayDeviceInstructions(t *testing.T) {
 			ExpiresIn:       900, // 15 minutes
 		}
 
-		displayDeviceInstructions(&buf, deviceCode)
+		displayDeviceInstructions(&DeviceAuthConfig{Output: &buf}, deviceCode)
 
 		output := buf.String()
 
@@ -183,7 +198,7 @@ func TestDisplayDeviceInstructions(t *testing.T) {
 			ExpiresIn:       0,
 		}
 
-		displayDeviceInstructions(&buf, deviceCode)
+		displayDeviceInstructions(&DeviceAuthConfig{Output: &buf}, deviceCode)
 
 		output := buf.String()
 
@@ -200,7 +215,7 @@ func TestDisplayDeviceInstructions(t *testing.T) {
 			ExpiresIn:       300,
 		}
 
-		displayDeviceInstructions(&buf, deviceCode)
+		displayDeviceInstructions(&DeviceAuthConfig{Output: &buf}, deviceCode)
 
 		assert.NotEmpty(t, buf.String())
 	})
@@ -213,7 +228,63 @@ func TestDisplayDeviceInstructions(t *testing.T) {
 		}
 
 		// Should not panic
-		displayDeviceInstructions(io.Discard, deviceCode)
+		displayDeviceInstructions(&DeviceAuthConfig{Output: io.Discard}, deviceCode)
+	})
+
+	t.Run("should render a QR code when VerificationURIComplete is set and QRCode is forced on", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		deviceCode := &DeviceCodeResponse{
+			VerificationURI:         "https://github.com/login/device",
+			VerificationURIComplete: "https://github.com/login/device?user_code=ABCD-1234",
+			UserCode:                "ABCD-1234",
+			ExpiresIn:               900,
+		}
+
+		forceOn := true
+		displayDeviceInstructions(&DeviceAuthConfig{Output: &buf, QRCode: &forceOn}, deviceCode)
+
+		output := buf.String()
+
+		assert.Contains(t, output, "Scan this QR code")
+		assert.Contains(t, output, "██")
+	})
+
+	t.Run("should fall back to text instructions when QRCode is forced off", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		deviceCode := &DeviceCodeResponse{
+			VerificationURI:         "https://github.com/login/device",
+			VerificationURIComplete: "https://github.com/login/device?user_code=ABCD-1234",
+			UserCode:                "ABCD-1234",
+			ExpiresIn:               900,
+		}
+
+		forceOff := false
+		displayDeviceInstructions(&DeviceAuthConfig{Output: &buf, QRCode: &forceOff}, deviceCode)
+
+		output := buf.String()
+
+		assert.Contains(t, output, "1. Visit: https://github.com/login/device?user_code=ABCD-1234")
+		assert.NotContains(t, output, "██")
+	})
+
+	t.Run("should fall back to text instructions when not a TTY and QRCode is unset", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		deviceCode := &DeviceCodeResponse{
+			VerificationURI:         "https://github.com/login/device",
+			VerificationURIComplete: "https://github.com/login/device?user_code=ABCD-1234",
+			UserCode:                "ABCD-1234",
+			ExpiresIn:               900,
+		}
+
+		displayDeviceInstructions(&DeviceAuthConfig{Output: &buf}, deviceCode)
+
+		output := buf.String()
+
+		assert.Contains(t, output, "1. Visit: https://github.com/login/device?user_code=ABCD-1234")
+		assert.NotContains(t, output, "██")
 	})
 }
 
@@ -231,7 +302,7 @@ func TestStartDeviceFlow_Validation(t *testing.T) {
 	t.Run("should error when ClientID is empty", func(t *testing.T) {
 		ctx := context.Background()
 
-		config := &DeviceFlowConfig{
+		config := &DeviceAuthConfig{
 			ClientID: "",
 		}
 
@@ -242,15 +313,47 @@ func TestStartDeviceFlow_Validation(t *testing.T) {
 		assert.Contains(t, err.Error(), "ClientID is required")
 	})
 
+	t.Run("should error when DeviceAuthURL is empty", func(t *testing.T) {
+		ctx := context.Background()
+
+		config := &DeviceAuthConfig{
+			ClientID: "test-client-id",
+			TokenURL: "https://example.com/token",
+		}
+
+		result, err := StartDeviceFlow(ctx, config)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "DeviceAuthURL is required")
+	})
+
+	t.Run("should error when TokenURL is empty", func(t *testing.T) {
+		ctx := context.Background()
+
+		config := &DeviceAuthConfig{
+			ClientID:      "test-client-id",
+			DeviceAuthURL: "https://example.com/device/code",
+		}
+
+		result, err := StartDeviceFlow(ctx, config)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "TokenURL is required")
+	})
+
 	t.Run("should set Output to Discard if nil", func(t *testing.T) {
 		// We can't easily test the full flow without HTTP, but we can
 		// verify the validation passes and fails at the HTTP stage
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel() // Cancel immediately to fail fast
 
-		config := &DeviceFlowConfig{
-			ClientID: "test-client-id",
-			Output:   nil, // Should be set to Discard
+		config := &DeviceAuthConfig{
+			ClientID:      "test-client-id",
+			DeviceAuthURL: "https://example.com/device/code",
+			TokenURL:      "https://example.com/token",
+			Output:        nil, // Should be set to Discard
 		}
 
 		// This will fail due to cancelled context, but that's expected
@@ -281,6 +384,15 @@ func TestDeviceCodeResponse_Structure(t *testing.T) {
 		assert.NotZero(t, resp.ExpiresIn)
 		assert.NotZero(t, resp.Interval)
 	})
+
+	t.Run("should accept an optional verification_uri_complete", func(t *testing.T) {
+		resp := DeviceCodeResponse{
+			VerificationURI:         "https://example.com/device",
+			VerificationURIComplete: "https://example.com/device?user_code=ABCD-1234",
+		}
+
+		assert.NotEmpty(t, resp.VerificationURIComplete)
+	})
 }
 
 func TestDeviceTokenResponse_Structure(t *testing.T) {
@@ -317,6 +429,15 @@ func TestDeviceTokenResponse_Structure(t *testing.T) {
 		assert.Equal(t, "slow_down", resp.Error)
 		assert.Equal(t, 10, resp.Interval)
 	})
+
+	t.Run("should accept an optional expires_in", func(t *testing.T) {
+		resp := DeviceTokenResponse{
+			AccessToken: "token123",
+			ExpiresIn:   3600,
+		}
+
+		assert.Equal(t, 3600, resp.ExpiresIn)
+	})
 }
 
 func TestDeviceFlowResult_Structure(t *testing.T) {
@@ -337,14 +458,17 @@ func TestDeviceFlowResult_Structure(t *testing.T) {
 	})
 }
 
-func TestDeviceFlowConfig_Structure(t *testing.T) {
+func TestDeviceAuthConfig_Structure(t *testing.T) {
 	t.Run("should accept valid configuration", func(t *testing.T) {
 		var buf bytes.Buffer
 
-		config := DeviceFlowConfig{
-			ClientID: "test-client-id",
-			Scopes:   []string{"read:user", "read:org"},
-			Output:   &buf,
+		config := DeviceAuthConfig{
+			DeviceAuthURL: "https://example.com/device/code",
+			TokenURL:      "https://example.com/token",
+			ClientID:      "test-client-id",
+			Scopes:        []string{"read:user", "read:org"},
+			AuthStyle:     AuthStyleInParams,
+			Output:        &buf,
 		}
 
 		assert.Equal(t, "test-client-id", config.ClientID)
@@ -353,7 +477,7 @@ func TestDeviceFlowConfig_Structure(t *testing.T) {
 	})
 
 	t.Run("should handle empty scopes", func(t *testing.T) {
-		config := DeviceFlowConfig{
+		config := DeviceAuthConfig{
 			Scopes: []string{},
 		}
 
@@ -361,18 +485,26 @@ func TestDeviceFlowConfig_Structure(t *testing.T) {
 	})
 
 	t.Run("should handle nil output", func(t *testing.T) {
-		config := DeviceFlowConfig{
+		config := DeviceAuthConfig{
 			Output: nil,
 		}
 
 		assert.Nil(t, config.Output)
 	})
+
+	t.Run("should default AuthStyle to AuthStyleAutoDetect", func(t *testing.T) {
+		config := DeviceAuthConfig{}
+
+		assert.Equal(t, AuthStyleAutoDetect, config.AuthStyle)
+	})
 }
 
 func TestDeviceFlowConstants(t *testing.T) {
 	t.Run("should have reasonable default values", func(t *testing.T) {
 		assert.Equal(t, 5*time.Second, defaultDeviceInterval)
 		assert.Equal(t, 15*time.Minute, devicePollTimeout)
+		assert.Equal(t, time.Minute, maxPollInterval)
+		assert.Equal(t, 8*time.Hour, defaultTokenExpiry)
 		assert.Equal(t, 30*time.Second, httpTimeout)
 		assert.Equal(t, 10, maxIdleConns)
 		assert.Equal(t, 2, maxIdleConnsPerHost)
@@ -380,9 +512,11 @@ func TestDeviceFlowConstants(t *testing.T) {
 		assert.Equal(t, 60, secondsPerMinute)
 	})
 
-	t.Run("should have valid GitHub endpoints", func(t *testing.T) {
-		assert.Contains(t, githubDeviceCodeURL, "github.com")
-		assert.Contains(t, githubDeviceTokenURL, "github.com")
+	t.Run("should define all four RFC 8628 polling error codes", func(t *testing.T) {
+		assert.Equal(t, "authorization_pending", errCodeAuthorizationPending)
+		assert.Equal(t, "slow_down", errCodeSlowDown)
+		assert.Equal(t, "access_denied", errCodeAccessDenied)
+		assert.Equal(t, "expired_token", errCodeExpiredToken)
 	})
 }
 
@@ -393,3 +527,131 @@ func TestDefaultHTTPClient(t *testing.T) {
 		assert.NotNil(t, defaultHTTPClient.Transport)
 	})
 }
+
+// fakeClock drives pollForToken's waits without a real sleep: After
+// immediately fires, advancing the simulated clock by the requested
+// duration first so elapsed() reflects the backoff that was applied.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+func TestPollForToken_ConfigOverrides(t *testing.T) {
+	t.Run("honors PollInterval, Clock and HTTPClient overrides while backing off on slow_down", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			n := atomic.AddInt32(&calls, 1)
+
+			w.Header().Set("Content-Type", "application/json")
+
+			if n < 3 {
+				_, _ = w.Write([]byte(`{"error":"slow_down"}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"access_token":"tok-123","token_type":"bearer"}`))
+		}))
+		defer srv.Close()
+
+		start := time.Now()
+		clock := &fakeClock{now: start}
+		config := &DeviceAuthConfig{
+			TokenURL:   srv.URL,
+			ClientID:   "client-id",
+			Clock:      clock,
+			HTTPClient: srv.Client(),
+		}
+
+		token, err := pollForToken(context.Background(), config, "device-code", time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, "tok-123", token.AccessToken)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+		// Waits: 1s (initial), then 2s and 4s (each slow_down doubles the
+		// interval) before the call that finally succeeds.
+		assert.Equal(t, 7*time.Second, clock.now.Sub(start))
+	})
+}
+
+func TestExchangeWithAuthStyle(t *testing.T) {
+	t.Run("falls back to header style on 401 and caches it for TokenURL", func(t *testing.T) {
+		var bodyCalls, headerCalls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, _, ok := r.BasicAuth(); ok {
+				atomic.AddInt32(&headerCalls, 1)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token":"tok-header","token_type":"bearer"}`))
+
+				return
+			}
+
+			atomic.AddInt32(&bodyCalls, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`unauthorized`))
+		}))
+		defer srv.Close()
+
+		config := &DeviceAuthConfig{
+			TokenURL:   srv.URL,
+			ClientID:   "client-id",
+			HTTPClient: srv.Client(),
+		}
+
+		tokenResp, err := exchangeWithAuthStyle(context.Background(), config, url.Values{"grant_type": {"refresh_token"}})
+		require.NoError(t, err)
+		assert.Equal(t, "tok-header", tokenResp.AccessToken)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&bodyCalls))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&headerCalls))
+
+		cached, ok := authStyleCache.Load(srv.URL)
+		require.True(t, ok)
+		assert.Equal(t, AuthStyleInHeader, cached)
+
+		// A second call against the same TokenURL should go straight to the
+		// cached style, skipping the body-style probe entirely.
+		tokenResp, err = exchangeWithAuthStyle(context.Background(), config, url.Values{"grant_type": {"refresh_token"}})
+		require.NoError(t, err)
+		assert.Equal(t, "tok-header", tokenResp.AccessToken)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&bodyCalls))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&headerCalls))
+	})
+
+	t.Run("honors an explicit AuthStyle without probing", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+
+			_, _, ok := r.BasicAuth()
+			assert.True(t, ok)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"tok-explicit","token_type":"bearer"}`))
+		}))
+		defer srv.Close()
+
+		config := &DeviceAuthConfig{
+			TokenURL:   srv.URL,
+			ClientID:   "client-id",
+			AuthStyle:  AuthStyleInHeader,
+			HTTPClient: srv.Client(),
+		}
+
+		tokenResp, err := exchangeWithAuthStyle(context.Background(), config, url.Values{"grant_type": {"refresh_token"}})
+		require.NoError(t, err)
+		assert.Equal(t, "tok-explicit", tokenResp.AccessToken)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
@@ -0,0 +1,328 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// oidcWellKnownPath is appended to a provider's issuer URL to fetch its
+// discovery document, per the OpenID Connect Discovery 1.0 specification.
+const oidcWellKnownPath = "/.well-known/openid-configuration"
+
+// oidcHTTPTimeout bounds discovery and token requests made while setting up
+// OIDC authentication.
+const oidcHTTPTimeout = 30 * time.Second
+
+// oidcRefreshBuffer is how long before expiry the background refresh loop
+// renews the cached token.
+const oidcRefreshBuffer = 30 * time.Second
+
+// oidcRetryBackoff is how long the background refresh loop waits before
+// retrying after a failed refresh, so a transient provider outage doesn't
+// spin the goroutine in a tight loop.
+const oidcRetryBackoff = 10 * time.Second
+
+// oidcDiscoveryDocument is the subset of an OIDC provider's
+// /.well-known/openid-configuration response this client uses.
+type oidcDiscoveryDocument struct {
+	Issuer        string `json:"issuer"`
+	TokenEndpoint string `json:"token_endpoint"`
+	JWKSURI       string `json:"jwks_uri"`
+}
+
+// discoverOIDC fetches and decodes issuerURL's discovery document.
+func discoverOIDC(ctx context.Context, httpClient *http.Client, issuerURL string) (*oidcDiscoveryDocument, error) {
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + oidcWellKnownPath
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OIDC discovery request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery endpoint returned status %d", resp.StatusCode)
+	}
+
+	var doc oidcDiscoveryDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %w", err)
+	}
+
+	if doc.TokenEndpoint == "" {
+		return nil, errors.New("OIDC discovery document is missing token_endpoint")
+	}
+
+	return &doc, nil
+}
+
+// oidcTLSConfig builds the TLS config used to verify the OIDC provider's
+// certificate: the system trust pool, plus config.OIDCCACertFile if set.
+func oidcTLSConfig(config *Config) (*tls.Config, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if config.OIDCCACertFile != "" {
+		pem, err := os.ReadFile(config.OIDCCACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OIDC CA certificate file: %w", err)
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to append OIDC CA certificate to trust pool")
+		}
+	}
+
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+}
+
+// requestClientCredentialsToken obtains a token via the RFC 6749 section 4.4
+// client_credentials grant against authConfig.TokenURL, optionally scoping
+// it to audience (a non-standard but widely supported parameter, e.g. by
+// Auth0 and Keycloak).
+func requestClientCredentialsToken(ctx context.Context, authConfig *DeviceAuthConfig, audience string) (*Token, error) {
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+
+	if audience != "" {
+		data.Set("audience", audience)
+	}
+
+	tokenResp, err := exchangeWithAuthStyle(ctx, authConfig, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenResp.Error != "" {
+		return nil, &DeviceFlowError{Code: tokenResp.Error, Description: tokenResp.ErrorDescription}
+	}
+
+	if tokenResp.AccessToken == "" {
+		return nil, errors.New("unexpected empty response from token endpoint")
+	}
+
+	expiresAt := time.Now().Add(defaultTokenExpiry)
+	if tokenResp.ExpiresIn > 0 {
+		expiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	return &Token{
+		AccessToken:  tokenResp.AccessToken,
+		TokenType:    tokenResp.TokenType,
+		RefreshToken: tokenResp.RefreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// oidcTokenSource is a TokenSource that keeps a cached token fresh via a
+// background goroutine, renewing it oidcRefreshBuffer before it expires
+// instead of waiting for a caller to notice it's stale.
+type oidcTokenSource struct {
+	mu    sync.Mutex
+	token *Token
+
+	refresh func(ctx context.Context, current *Token) (*Token, error)
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newOIDCTokenSource starts a background refresh loop seeded with initial,
+// using refresh to obtain each subsequent token. Call Close to stop the loop.
+func newOIDCTokenSource(initial *Token, refresh func(ctx context.Context, current *Token) (*Token, error)) *oidcTokenSource {
+	s := &oidcTokenSource{
+		token:   initial,
+		refresh: refresh,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go s.refreshLoop()
+
+	return s
+}
+
+// Token implements TokenSource, returning the cached token.
+func (s *oidcTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil || s.token.AccessToken == "" {
+		return nil, errors.New("oidc token source has no token available")
+	}
+
+	return s.token, nil
+}
+
+// Close stops the background refresh loop, implementing io.Closer so it can
+// be released alongside the SPIFFE sources in options' cleanup path.
+func (s *oidcTokenSource) Close() error {
+	close(s.stop)
+	<-s.done
+
+	return nil
+}
+
+// refreshLoop wakes up oidcRefreshBuffer before the cached token's expiry
+// and renews it, retrying after oidcRetryBackoff on failure so a transient
+// provider outage doesn't spin the loop.
+func (s *oidcTokenSource) refreshLoop() {
+	defer close(s.done)
+
+	for {
+		wait := s.timeUntilRefresh()
+
+		select {
+		case <-time.After(wait):
+		case <-s.stop:
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), oidcHTTPTimeout)
+
+		s.mu.Lock()
+		current := s.token
+		s.mu.Unlock()
+
+		refreshed, err := s.refresh(ctx, current)
+		cancel()
+
+		if err != nil {
+			select {
+			case <-time.After(oidcRetryBackoff):
+			case <-s.stop:
+				return
+			}
+
+			continue
+		}
+
+		s.mu.Lock()
+		s.token = refreshed
+		s.mu.Unlock()
+	}
+}
+
+func (s *oidcTokenSource) timeUntilRefresh() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil || s.token.ExpiresAt.IsZero() {
+		return oidcRefreshBuffer
+	}
+
+	wait := time.Until(s.token.ExpiresAt) - oidcRefreshBuffer
+	if wait < 0 {
+		return 0
+	}
+
+	return wait
+}
+
+// setupOIDCAuth configures the client to authenticate against a standard
+// OIDC provider (Keycloak, Dex, Auth0, Google, etc.), discovering its token
+// endpoint and obtaining a bearer token via either the client_credentials
+// grant (when config.OIDCClientSecret is set) or the refresh_token grant
+// (when config.OIDCRefreshToken is set instead, e.g. for a public client
+// that completed a device or authorization code flow out of band). The
+// token is cached in memory and proactively refreshed ~30s before expiry by
+// a background goroutine, symmetric to setupJWTAuth's use of a long-lived
+// JWT source.
+func (o *options) setupOIDCAuth() error {
+	if o.config.OIDCIssuerURL == "" {
+		return errors.New("OIDC issuer URL is required for OIDC authentication")
+	}
+
+	if o.config.OIDCClientID == "" {
+		return errors.New("OIDC client ID is required for OIDC authentication")
+	}
+
+	tlsConfig, err := oidcTLSConfig(o.config)
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{
+		Timeout:   oidcHTTPTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+
+	// NOTE: this context is only used for setup; the background refresh
+	// loop below builds its own short-lived context per attempt.
+	ctx := context.Background()
+
+	discovery, err := discoverOIDC(ctx, httpClient, o.config.OIDCIssuerURL)
+	if err != nil {
+		return fmt.Errorf("failed to discover OIDC configuration: %w", err)
+	}
+
+	authConfig := &DeviceAuthConfig{
+		TokenURL:     discovery.TokenEndpoint,
+		ClientID:     o.config.OIDCClientID,
+		ClientSecret: o.config.OIDCClientSecret,
+		AuthStyle:    AuthStyleAutoDetect,
+		HTTPClient:   httpClient,
+	}
+
+	var (
+		initial *Token
+		refresh func(ctx context.Context, current *Token) (*Token, error)
+	)
+
+	switch {
+	case o.config.OIDCClientSecret != "":
+		initial, err = requestClientCredentialsToken(ctx, authConfig, o.config.OIDCAudience)
+		if err != nil {
+			return fmt.Errorf("failed to obtain OIDC token: %w", err)
+		}
+
+		refresh = func(ctx context.Context, _ *Token) (*Token, error) {
+			return requestClientCredentialsToken(ctx, authConfig, o.config.OIDCAudience)
+		}
+	case o.config.OIDCRefreshToken != "":
+		initial, err = refreshDeviceToken(ctx, authConfig, o.config.OIDCRefreshToken)
+		if err != nil {
+			return fmt.Errorf("failed to obtain OIDC token: %w", err)
+		}
+
+		refresh = func(ctx context.Context, current *Token) (*Token, error) {
+			return refreshDeviceToken(ctx, authConfig, current.RefreshToken)
+		}
+	default:
+		return errors.New("OIDC authentication requires OIDCClientSecret or OIDCRefreshToken")
+	}
+
+	source := newOIDCTokenSource(initial, refresh)
+	o.oidcSource = source
+	o.authOpts = append(o.authOpts,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithPerRPCCredentials(newTokenSourceCredentials(source)),
+	)
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverOIDC(t *testing.T) {
+	t.Run("should decode a valid discovery document", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, oidcWellKnownPath, r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"issuer":"https://issuer.example","token_endpoint":"https://issuer.example/token","jwks_uri":"https://issuer.example/jwks"}`))
+		}))
+		defer srv.Close()
+
+		doc, err := discoverOIDC(context.Background(), srv.Client(), srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, srv.URL+"/token", doc.TokenEndpoint)
+		assert.Equal(t, srv.URL+"/jwks", doc.JWKSURI)
+	})
+
+	t.Run("should error when token_endpoint is missing", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"issuer":"https://issuer.example"}`))
+		}))
+		defer srv.Close()
+
+		_, err := discoverOIDC(context.Background(), srv.Client(), srv.URL)
+		require.Error(t, err)
+	})
+
+	t.Run("should error on non-200 status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		_, err := discoverOIDC(context.Background(), srv.Client(), srv.URL)
+		require.Error(t, err)
+	})
+}
+
+func TestRequestClientCredentialsToken(t *testing.T) {
+	t.Run("should return a token on success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+			assert.Equal(t, "my-audience", r.FormValue("audience"))
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"tok-cc","token_type":"bearer","expires_in":60}`))
+		}))
+		defer srv.Close()
+
+		authConfig := &DeviceAuthConfig{TokenURL: srv.URL, ClientID: "client-id", HTTPClient: srv.Client()}
+
+		token, err := requestClientCredentialsToken(context.Background(), authConfig, "my-audience")
+		require.NoError(t, err)
+		assert.Equal(t, "tok-cc", token.AccessToken)
+		assert.WithinDuration(t, time.Now().Add(60*time.Second), token.ExpiresAt, 5*time.Second)
+	})
+
+	t.Run("should surface an OAuth2 error response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"bad secret"}`))
+		}))
+		defer srv.Close()
+
+		authConfig := &DeviceAuthConfig{TokenURL: srv.URL, ClientID: "client-id", HTTPClient: srv.Client()}
+
+		_, err := requestClientCredentialsToken(context.Background(), authConfig, "")
+		require.Error(t, err)
+
+		var deviceErr *DeviceFlowError
+		require.ErrorAs(t, err, &deviceErr)
+		assert.Equal(t, "invalid_client", deviceErr.Code)
+	})
+}
+
+func TestOIDCTokenSource(t *testing.T) {
+	t.Run("should proactively refresh before expiry and stop cleanly", func(t *testing.T) {
+		var refreshes int32
+
+		initial := &Token{AccessToken: "tok-0", ExpiresAt: time.Now().Add(50 * time.Millisecond)}
+
+		refreshed := make(chan struct{}, 1)
+		source := newOIDCTokenSource(initial, func(_ context.Context, _ *Token) (*Token, error) {
+			n := atomic.AddInt32(&refreshes, 1)
+			refreshed <- struct{}{}
+
+			return &Token{AccessToken: "tok-" + string(rune('0'+n)), ExpiresAt: time.Now().Add(time.Hour)}, nil
+		})
+
+		select {
+		case <-refreshed:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for background refresh")
+		}
+
+		token, err := source.Token()
+		require.NoError(t, err)
+		assert.NotEqual(t, "tok-0", token.AccessToken)
+
+		require.NoError(t, source.Close())
+	})
+
+	t.Run("Token errors when no token has ever been obtained", func(t *testing.T) {
+		source := &oidcTokenSource{}
+
+		_, err := source.Token()
+		require.Error(t, err)
+	})
+}
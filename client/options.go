@@ -14,13 +14,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/spiffe/go-spiffe/v2/spiffegrpc/grpccredentials"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+
+	credmgr "github.com/agntcy/dir/client/credentials"
 )
 
 type Option func(*options) error
@@ -35,6 +37,43 @@ type options struct {
 	bundleSrc io.Closer
 	x509Src   io.Closer
 	jwtSource io.Closer
+
+	// oidcSource stops the background token-refresh goroutine started by
+	// setupOIDCAuth, alongside the SPIFFE sources above.
+	oidcSource io.Closer
+
+	// hsmSession holds the HSM/KMS session opened by setupPKCS11Auth, kept
+	// alive for the client lifetime alongside the SPIFFE sources above.
+	hsmSession io.Closer
+
+	// credManager watches the credential sources above for rotation (token
+	// file changes, JWT-SVID expiry, X509-SVID renewal) and keeps their
+	// derived tls.Config fresh so long-lived connections survive past the
+	// initial SVID's expiry. Present whenever withAuth ran.
+	credManager *credmgr.Manager
+}
+
+// Close releases the auth sources opened while applying Options, if any.
+func (o *options) Close() error {
+	var errs []error
+
+	if o.credManager != nil {
+		if err := o.credManager.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, c := range []io.Closer{o.bundleSrc, o.x509Src, o.jwtSource, o.oidcSource, o.hsmSession} {
+		if c == nil {
+			continue
+		}
+
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func WithEnvConfig() Option {
@@ -62,6 +101,10 @@ func withAuth(ctx context.Context) Option {
 			return errors.New("config is required: use WithConfig() or WithEnvConfig()")
 		}
 
+		// credManager centralizes rotation/health for whichever auth mode is
+		// selected below; Close() shuts down its watchers deterministically.
+		o.credManager = credmgr.NewManager()
+
 		// Setup authentication based on AuthMode
 		switch o.config.AuthMode {
 		case "jwt":
@@ -70,6 +113,12 @@ func withAuth(ctx context.Context) Option {
 			return o.setupX509Auth()
 		case "token":
 			return o.setupSpiffeAuth()
+		case "github":
+			return o.setupGitHubAuth()
+		case "oidc":
+			return o.setupOIDCAuth()
+		case "pkcs11":
+			return o.setupPKCS11Auth()
 		default:
 			// Use insecure access for all other cases
 			o.authOpts = append(o.authOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -128,6 +177,11 @@ func (o *options) setupJWTAuth() error {
 		grpc.WithPerRPCCredentials(newJWTCredentials(jwtSource, o.config.JWTAudience)),
 	)
 
+	// Pre-fetch JWT-SVIDs ahead of expiry and report rotation health; the
+	// PerRPCCredentials above still fetches its own SVID per RPC, so this is
+	// purely for warm caching and observability.
+	o.credManager.WatchJWT("jwt", jwtSource, o.config.JWTAudience)
+
 	return nil
 }
 
@@ -171,36 +225,36 @@ func (o *options) setupX509Auth() error {
 		grpccredentials.MTLSClientCredentials(x509Src, bundleSrc, tlsconfig.AuthorizeAny()),
 	))
 
+	// MTLSClientCredentials already reads the latest SVID from x509Src on
+	// every handshake, so rotation needs no re-dial here; WatchX509 only
+	// surfaces it for Health and gives callers that do need to re-dial (e.g.
+	// long-lived streams) a rotation signal via onRotate.
+	o.credManager.WatchX509("x509", x509Src, nil)
+
 	return nil
 }
 
-func (o *options) setupSpiffeAuth() error {
-	// Validate token file is set
-	if o.config.SpiffeToken == "" {
-		return errors.New("spiffe token file path is required for token authentication")
-	}
-
-	// Read token file
-	tokenData, err := os.ReadFile(o.config.SpiffeToken)
-	if err != nil {
-		return fmt.Errorf("failed to read SPIFFE token file: %w", err)
-	}
-
-	// SpiffeTokenData represents the structure of SPIFFE token JSON
-	type SpiffeTokenData struct {
-		X509SVID   []string `json:"x509_svid"`   // DER-encoded certificates in base64
-		PrivateKey string   `json:"private_key"` // DER-encoded private key in base64
-		RootCAs    []string `json:"root_cas"`    // DER-encoded root CA certificates in base64
-	}
+// spiffeTokenData represents the structure of SPIFFE token JSON.
+type spiffeTokenData struct {
+	X509SVID   []string `json:"x509_svid"`   // DER-encoded certificates in base64
+	PrivateKey string   `json:"private_key"` // DER-encoded private key in base64
+	RootCAs    []string `json:"root_cas"`    // DER-encoded root CA certificates in base64
+}
 
+// buildSpiffeTLSConfig parses a SPIFFE token file's contents into a
+// tls.Config presenting the embedded X.509-SVID and trusting the embedded
+// root CAs. Used as the initial load and as the reload callback passed to
+// credManager.WatchFile, so a rotated token file takes effect without
+// re-dialing.
+func (o *options) buildSpiffeTLSConfig(tokenData []byte) (*tls.Config, error) {
 	// Parse SPIFFE token JSON
-	var spiffeData []SpiffeTokenData
+	var spiffeData []spiffeTokenData
 	if err := json.Unmarshal(tokenData, &spiffeData); err != nil {
-		return fmt.Errorf("failed to parse SPIFFE token: %w", err)
+		return nil, fmt.Errorf("failed to parse SPIFFE token: %w", err)
 	}
 
 	if len(spiffeData) == 0 {
-		return errors.New("no SPIFFE data found in token")
+		return nil, errors.New("no SPIFFE data found in token")
 	}
 
 	// Use the first SPIFFE data entry
@@ -208,13 +262,13 @@ func (o *options) setupSpiffeAuth() error {
 
 	// Parse the certificate chain
 	if len(data.X509SVID) == 0 {
-		return errors.New("no X.509 SVID certificates found")
+		return nil, errors.New("no X.509 SVID certificates found")
 	}
 
 	// From base64 DER to PEM
 	certDER, err := base64.StdEncoding.DecodeString(data.X509SVID[0])
 	if err != nil {
-		return fmt.Errorf("failed to decode certificate: %w", err)
+		return nil, fmt.Errorf("failed to decode certificate: %w", err)
 	}
 
 	certPEM := pem.EncodeToMemory(&pem.Block{
@@ -225,7 +279,7 @@ func (o *options) setupSpiffeAuth() error {
 	// The private key is base64-encoded DER format
 	keyDER, err := base64.StdEncoding.DecodeString(data.PrivateKey)
 	if err != nil {
-		return fmt.Errorf("failed to decode private key: %w", err)
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
 	}
 
 	keyPEM := pem.EncodeToMemory(&pem.Block{
@@ -236,7 +290,7 @@ func (o *options) setupSpiffeAuth() error {
 	// Create certificate from PEM data
 	cert, err := tls.X509KeyPair(certPEM, keyPEM)
 	if err != nil {
-		return fmt.Errorf("failed to create certificate from SPIFFE data: %w", err)
+		return nil, fmt.Errorf("failed to create certificate from SPIFFE data: %w", err)
 	}
 
 	// Create CA pool from root CAs
@@ -246,7 +300,7 @@ func (o *options) setupSpiffeAuth() error {
 		// Root CAs are also base64-encoded DER
 		caDER, err := base64.StdEncoding.DecodeString(rootCA)
 		if err != nil {
-			return fmt.Errorf("failed to decode root CA: %w", err)
+			return nil, fmt.Errorf("failed to decode root CA: %w", err)
 		}
 
 		caPEM := pem.EncodeToMemory(&pem.Block{
@@ -255,19 +309,77 @@ func (o *options) setupSpiffeAuth() error {
 		})
 
 		if !capool.AppendCertsFromPEM(caPEM) {
-			return errors.New("failed to append root CA certificate to CA pool")
+			return nil, errors.New("failed to append root CA certificate to CA pool")
 		}
 	}
 
-	// Create TLS config
-	tlsConfig := &tls.Config{
+	return &tls.Config{
 		Certificates:       []tls.Certificate{cert},
 		RootCAs:            capool,
 		InsecureSkipVerify: o.config.TlsSkipVerify, //nolint:gosec
+	}, nil
+}
+
+func (o *options) setupSpiffeAuth() error {
+	// Validate token file is set
+	if o.config.SpiffeToken == "" {
+		return errors.New("spiffe token file path is required for token authentication")
 	}
 
-	// Update options
-	o.authOpts = append(o.authOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	// Load the token once up front, then watch it for rotation so a renewed
+	// token file (e.g. rewritten by a SPIFFE Workload API sidecar) is picked
+	// up without re-dialing.
+	if err := o.credManager.WatchFile("spiffe-token", o.config.SpiffeToken, o.buildSpiffeTLSConfig); err != nil {
+		return fmt.Errorf("failed to watch SPIFFE token file: %w", err)
+	}
+
+	o.authOpts = append(o.authOpts,
+		grpc.WithTransportCredentials(o.credManager.TransportCredentials("spiffe-token")),
+	)
+
+	return nil
+}
+
+// githubTokenProvider is the FileTokenStore provider name used for GitHub
+// device flow tokens, shared with `dirctl auth login`.
+const githubTokenProvider = "github"
+
+// githubDeviceFlowClientID is GitHub's publicly documented CLI OAuth App
+// client ID, used for the device flow when no other client ID is configured.
+const githubDeviceFlowClientID = "178c6fc778ccc68e1d6a"
+
+// setupGitHubAuth configures the client to authenticate with a GitHub device
+// flow token cached by a prior `dirctl auth login`, refreshing it
+// automatically via the PerRPCCredentials attached below. It does not run an
+// interactive device flow itself - that would block client construction on
+// user input - so it errors out if no cached token is found.
+func (o *options) setupGitHubAuth() error {
+	clientID := os.Getenv("DIRECTORY_CLIENT_GITHUB_CLIENT_ID")
+	if clientID == "" {
+		clientID = githubDeviceFlowClientID
+	}
+
+	config := &DeviceAuthConfig{
+		DeviceAuthURL: "https://github.com/login/device/code",
+		TokenURL:      "https://github.com/login/oauth/access_token",
+		ClientID:      clientID,
+		Scopes:        strings.Split(DefaultOAuthScopes, ","),
+		AuthStyle:     AuthStyleInParams,
+	}
+
+	source, err := LoadTokenSource(githubTokenProvider, config, NewFileTokenStore())
+	if err != nil {
+		return fmt.Errorf("failed to load cached GitHub token: %w", err)
+	}
+
+	if source == nil {
+		return errors.New("no cached GitHub token found; run 'dirctl auth login' first")
+	}
+
+	o.authOpts = append(o.authOpts,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(newTokenSourceCredentials(source)),
+	)
 
 	return nil
 }
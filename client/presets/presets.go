@@ -0,0 +1,50 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package presets ships built-in client.DeviceAuthConfig values for
+// well-known OAuth2 device flow providers, so callers keep a one-liner
+// instead of hand-assembling endpoints. Providers not listed here (e.g. a
+// self-hosted dir authz server) can be wired in by constructing a
+// client.DeviceAuthConfig directly.
+package presets
+
+import (
+	"fmt"
+
+	"github.com/agntcy/dir/client"
+)
+
+// GitHub returns a DeviceAuthConfig for GitHub's OAuth2 device flow.
+func GitHub(clientID string, scopes []string) *client.DeviceAuthConfig {
+	return &client.DeviceAuthConfig{
+		DeviceAuthURL: "https://github.com/login/device/code",
+		TokenURL:      "https://github.com/login/oauth/access_token",
+		ClientID:      clientID,
+		Scopes:        scopes,
+		AuthStyle:     client.AuthStyleInParams,
+	}
+}
+
+// Google returns a DeviceAuthConfig for Google's OAuth2 device flow.
+func Google(clientID, clientSecret string, scopes []string) *client.DeviceAuthConfig {
+	return &client.DeviceAuthConfig{
+		DeviceAuthURL: "https://oauth2.googleapis.com/device/code",
+		TokenURL:      "https://oauth2.googleapis.com/token",
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+		Scopes:        scopes,
+		AuthStyle:     client.AuthStyleInParams,
+	}
+}
+
+// AzureAD returns a DeviceAuthConfig for Azure AD's (Microsoft identity
+// platform) OAuth2 device flow, scoped to the given tenant.
+func AzureAD(tenantID, clientID string, scopes []string) *client.DeviceAuthConfig {
+	return &client.DeviceAuthConfig{
+		DeviceAuthURL: fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/devicecode", tenantID),
+		TokenURL:      fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", tenantID),
+		ClientID:      clientID,
+		Scopes:        scopes,
+		AuthStyle:     client.AuthStyleInParams,
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package presets
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHub(t *testing.T) {
+	cfg := GitHub("client-id", []string{"read:user"})
+
+	assert.Equal(t, "https://github.com/login/device/code", cfg.DeviceAuthURL)
+	assert.Equal(t, "https://github.com/login/oauth/access_token", cfg.TokenURL)
+	assert.Equal(t, "client-id", cfg.ClientID)
+	assert.Equal(t, []string{"read:user"}, cfg.Scopes)
+	assert.Equal(t, client.AuthStyleInParams, cfg.AuthStyle)
+}
+
+func TestGoogle(t *testing.T) {
+	cfg := Google("client-id", "client-secret", []string{"openid"})
+
+	assert.Equal(t, "https://oauth2.googleapis.com/device/code", cfg.DeviceAuthURL)
+	assert.Equal(t, "https://oauth2.googleapis.com/token", cfg.TokenURL)
+	assert.Equal(t, "client-id", cfg.ClientID)
+	assert.Equal(t, "client-secret", cfg.ClientSecret)
+	assert.Equal(t, []string{"openid"}, cfg.Scopes)
+}
+
+func TestAzureAD(t *testing.T) {
+	cfg := AzureAD("tenant-id", "client-id", []string{"User.Read"})
+
+	assert.Equal(t, "https://login.microsoftonline.com/tenant-id/oauth2/v2.0/devicecode", cfg.DeviceAuthURL)
+	assert.Equal(t, "https://login.microsoftonline.com/tenant-id/oauth2/v2.0/token", cfg.TokenURL)
+	assert.Equal(t, "client-id", cfg.ClientID)
+	assert.Equal(t, []string{"User.Read"}, cfg.Scopes)
+}
@@ -0,0 +1,73 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package qrcode
+
+const (
+	byteModeIndicator  = 0b0100
+	byteModeBits       = 4
+	countIndicatorBits = 8 // byte mode, versions 1-9
+
+	terminatorMaxBits = 4
+
+	padByteOne = 0xEC
+	padByteTwo = 0x11
+)
+
+// bitWriter accumulates a stream of bits, MSB first, later packed into bytes.
+type bitWriter struct {
+	bits []bool
+}
+
+func (b *bitWriter) writeBits(val, length int) {
+	for i := length - 1; i >= 0; i-- {
+		b.bits = append(b.bits, (val>>uint(i))&1 == 1)
+	}
+}
+
+func (b *bitWriter) writeBytes(data []byte) {
+	for _, by := range data {
+		b.writeBits(int(by), 8) //nolint:mnd // one byte is always 8 bits
+	}
+}
+
+// buildDataCodewords encodes raw as a byte-mode QR data segment, terminates
+// and pads it to dataCodewords bytes per the ISO/IEC 18004 padding rule
+// (terminator, zero-pad to a byte boundary, then alternate the two standard
+// pad bytes 0xEC/0x11 until the block is full).
+func buildDataCodewords(raw []byte, dataCodewords int) []int {
+	bw := &bitWriter{}
+	bw.writeBits(byteModeIndicator, byteModeBits)
+	bw.writeBits(len(raw), countIndicatorBits)
+	bw.writeBytes(raw)
+
+	capacityBits := dataCodewords * 8
+
+	for i := 0; i < terminatorMaxBits && len(bw.bits) < capacityBits; i++ {
+		bw.bits = append(bw.bits, false)
+	}
+
+	for len(bw.bits)%8 != 0 {
+		bw.bits = append(bw.bits, false)
+	}
+
+	codewords := make([]int, len(bw.bits)/8)
+	for i := range codewords {
+		word := 0
+		for j := 0; j < 8; j++ {
+			word <<= 1
+			if bw.bits[i*8+j] {
+				word |= 1
+			}
+		}
+
+		codewords[i] = word
+	}
+
+	padBytes := [2]int{padByteOne, padByteTwo}
+	for i := 0; len(codewords) < dataCodewords; i++ {
+		codewords = append(codewords, padBytes[i%2])
+	}
+
+	return codewords
+}
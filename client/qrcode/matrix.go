@@ -0,0 +1,202 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package qrcode
+
+// matrix is the module grid being built up for a QR symbol, plus a parallel
+// bitmap tracking which cells are function patterns (finders, timing,
+// alignment, format info, the always-dark module) so data placement and
+// masking know to skip them.
+type matrix struct {
+	size       int
+	modules    [][]bool // [row][col], true = dark
+	isFunction [][]bool // [row][col]
+}
+
+func newMatrix(size int) *matrix {
+	modules := make([][]bool, size)
+	isFunction := make([][]bool, size)
+
+	for i := range modules {
+		modules[i] = make([]bool, size)
+		isFunction[i] = make([]bool, size)
+	}
+
+	return &matrix{size: size, modules: modules, isFunction: isFunction}
+}
+
+func (m *matrix) set(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns draws the finder patterns, timing patterns, the
+// version's single alignment pattern (versions 2-5), and the always-dark
+// module. Format info is drawn separately by drawFormatBits, once these
+// patterns have reserved their cells.
+func (m *matrix) drawFunctionPatterns(version int) {
+	m.drawFinderPattern(3, 3)
+	m.drawFinderPattern(m.size-4, 3)
+	m.drawFinderPattern(3, m.size-4)
+
+	for i := 8; i < m.size-8; i++ {
+		dark := i%2 == 0
+		m.set(6, i, dark)
+		m.set(i, 6, dark)
+	}
+
+	if version >= 2 {
+		pos := 4*version + 10
+		m.drawAlignmentPattern(pos, pos)
+	}
+
+	m.set(8, 4*version+9, true)
+}
+
+// drawFinderPattern draws one 7x7 finder pattern (plus its 1-module
+// separator) centered at (cx, cy).
+func (m *matrix) drawFinderPattern(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= m.size || y < 0 || y >= m.size {
+				continue
+			}
+
+			dist := maxAbs(dx, dy)
+			m.set(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern draws one 5x5 alignment pattern centered at (cx, cy).
+func (m *matrix) drawAlignmentPattern(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.set(cx+dx, cy+dy, maxAbs(dx, dy) != 1)
+		}
+	}
+}
+
+func maxAbs(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+
+	if b < 0 {
+		b = -b
+	}
+
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// drawFormatBits draws the two redundant copies of the 15-bit format info
+// (error correction level L, mask pattern 0, protected by a BCH(15,5) code),
+// per ISO/IEC 18004 section 7.9. Versions 7+ also require version info
+// elsewhere in the symbol; this package doesn't support those versions, so
+// that step is omitted.
+func (m *matrix) drawFormatBits() {
+	const (
+		eccLevelLBits   = 0b01
+		maskPattern     = 0b000
+		formatGenerator = 0b10100110111
+		formatMaskConst = 0x5412
+		formatDataBits  = 5
+		formatECCBits   = 10
+		formatTotalBits = 15
+	)
+
+	data := eccLevelLBits<<3 | maskPattern
+
+	rem := data
+	for i := 0; i < formatECCBits; i++ {
+		rem = (rem << 1) ^ ((rem >> (formatECCBits - 1)) * formatGenerator)
+	}
+
+	bits := (data<<formatECCBits | rem&(1<<formatECCBits-1)) ^ formatMaskConst
+
+	get := func(i int) bool { return (bits>>uint(i))&1 == 1 }
+
+	// First copy: around the top-left finder.
+	for i := 0; i <= 5; i++ {
+		m.set(8, i, get(i))
+	}
+
+	m.set(8, 7, get(6))
+	m.set(8, 8, get(7))
+	m.set(7, 8, get(8))
+
+	for i := 9; i < formatTotalBits; i++ {
+		m.set(formatTotalBits-1-i, 8, get(i))
+	}
+
+	// Second copy: split across the top-right and bottom-left finders.
+	for i := 0; i < 8; i++ {
+		m.set(m.size-1-i, 8, get(i))
+	}
+
+	for i := 8; i < formatTotalBits; i++ {
+		m.set(8, m.size-formatTotalBits+i, get(i))
+	}
+
+	m.set(8, m.size-8, true) // always dark
+}
+
+// drawCodewords places allCodewords into the matrix using the standard QR
+// zigzag scan: two-column strips scanned bottom-to-top then top-to-bottom,
+// skipping the vertical timing column and any cell already claimed by a
+// function pattern.
+func (m *matrix) drawCodewords(allCodewords []int) {
+	bitIndex := 0
+	totalBits := len(allCodewords) * 8
+
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		upward := (right+1)&2 == 0
+
+		for vert := 0; vert < m.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+
+				y := vert
+				if upward {
+					y = m.size - 1 - vert
+				}
+
+				if m.isFunction[y][x] || bitIndex >= totalBits {
+					continue
+				}
+
+				word := allCodewords[bitIndex/8]
+				bit := (word>>uint(7-bitIndex%8))&1 == 1
+				m.modules[y][x] = bit
+				bitIndex++
+			}
+		}
+	}
+}
+
+// applyMask XORs mask pattern 0 ((row+col)%2==0) over every non-function
+// module. Mask pattern 0 is always used; this package doesn't implement the
+// penalty-scoring evaluation of all eight patterns, since masking only
+// affects scan reliability, not whether the symbol decodes correctly.
+func (m *matrix) applyMask() {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.isFunction[y][x] {
+				continue
+			}
+
+			if (x+y)%2 == 0 {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
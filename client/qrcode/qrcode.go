@@ -0,0 +1,126 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package qrcode is a minimal, dependency-free QR code (ISO/IEC 18004)
+// encoder for rendering short strings (such as an OAuth2
+// verification_uri_complete) to a terminal.
+//
+// It is deliberately narrow in scope: only byte-mode encoding, only error
+// correction level L, and only versions 1-5 (single Reed-Solomon block
+// each), which together top out at 106 bytes of capacity - plenty for a
+// device flow verification URL, but not a general-purpose QR library.
+// Masking always uses mask pattern 0 rather than evaluating all eight
+// patterns for the lowest penalty score, since that only affects scan
+// optimization, not whether the code decodes correctly.
+package qrcode
+
+import (
+	"errors"
+	"strings"
+)
+
+// dataCodewordsByVersion and eccCodewordsByVersion are the ECC level L
+// codeword counts for QR versions 1-5 (index 0 is version 1), each of which
+// fits in a single Reed-Solomon block.
+var (
+	dataCodewordsByVersion = [5]int{19, 34, 55, 80, 108}
+	eccCodewordsByVersion  = [5]int{7, 10, 15, 20, 26}
+)
+
+// ErrDataTooLong is returned by Encode when data exceeds the 106-byte
+// capacity of the largest supported version (5) at ECC level L.
+var ErrDataTooLong = errors.New("qrcode: data too long for supported versions (max 106 bytes)")
+
+// QRCode is an encoded QR code symbol, ready to render to a terminal.
+type QRCode struct {
+	size    int
+	modules [][]bool // [row][col], true = dark module
+}
+
+// Encode builds a QR code for data using the smallest supported version (1-5)
+// that fits it, at error correction level L. It returns ErrDataTooLong if
+// data exceeds the 106-byte capacity of version 5.
+func Encode(data string) (*QRCode, error) {
+	raw := []byte(data)
+
+	version, dataCodewords, eccCodewords, err := chooseVersion(len(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	dataWords := buildDataCodewords(raw, dataCodewords)
+
+	gf := newGaloisField()
+	generator := gf.generatorPoly(eccCodewords)
+	eccWords := gf.computeRemainder(dataWords, generator)
+
+	allWords := make([]int, 0, len(dataWords)+len(eccWords))
+	allWords = append(allWords, dataWords...)
+	allWords = append(allWords, eccWords...)
+
+	m := newMatrix(4*version + 17)
+	m.drawFunctionPatterns(version)
+	m.drawFormatBits()
+	m.drawCodewords(allWords)
+	m.applyMask()
+
+	return &QRCode{size: m.size, modules: m.modules}, nil
+}
+
+// chooseVersion picks the smallest version (1-5) whose byte-mode capacity at
+// ECC level L fits numBytes, returning its data and ECC codeword counts.
+func chooseVersion(numBytes int) (version, dataCodewords, eccCodewords int, err error) {
+	const (
+		modeIndicatorBits  = 4
+		countIndicatorBits = 8 // byte mode, versions 1-9
+	)
+
+	for i, dcw := range dataCodewordsByVersion {
+		capacity := (dcw*8 - modeIndicatorBits - countIndicatorBits) / 8
+		if numBytes <= capacity {
+			return i + 1, dcw, eccCodewordsByVersion[i], nil
+		}
+	}
+
+	return 0, 0, 0, ErrDataTooLong
+}
+
+// String renders the QR code as text, one line per row, with dark modules
+// drawn as a doubled block character so they approximate square modules in a
+// typical monospace terminal font (character cells are usually taller than
+// they are wide).
+func (q *QRCode) String() string {
+	const (
+		darkCell  = "██"
+		lightCell = "  "
+		quietZone = 2 // modules of light border on each side, per row/column
+	)
+
+	var sb strings.Builder
+
+	blankLine := strings.Repeat(lightCell, q.size+quietZone*2) + "\n"
+	for i := 0; i < quietZone; i++ {
+		sb.WriteString(blankLine)
+	}
+
+	for _, row := range q.modules {
+		sb.WriteString(strings.Repeat(lightCell, quietZone))
+
+		for _, dark := range row {
+			if dark {
+				sb.WriteString(darkCell)
+			} else {
+				sb.WriteString(lightCell)
+			}
+		}
+
+		sb.WriteString(strings.Repeat(lightCell, quietZone))
+		sb.WriteString("\n")
+	}
+
+	for i := 0; i < quietZone; i++ {
+		sb.WriteString(blankLine)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_Sizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantSize int
+	}{
+		{"fits in version 1", strings.Repeat("a", 17), 21},
+		{"requires version 2", strings.Repeat("a", 18), 25},
+		{"requires version 5", strings.Repeat("a", 106), 37},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSize, code.size)
+			assert.Len(t, code.modules, tt.wantSize)
+		})
+	}
+}
+
+func TestEncode_TooLong(t *testing.T) {
+	_, err := Encode(strings.Repeat("a", 107))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrDataTooLong)
+}
+
+func TestEncode_FinderPatternsPresent(t *testing.T) {
+	code, err := Encode("https://github.com/login/device")
+	require.NoError(t, err)
+
+	// The center module of each finder pattern is always dark.
+	assert.True(t, code.modules[3][3])
+
+	corner := code.size - 4
+	assert.True(t, code.modules[3][corner])
+	assert.True(t, code.modules[corner][3])
+}
+
+func TestEncode_StringRendersNonEmpty(t *testing.T) {
+	code, err := Encode("https://github.com/login/device/ABCD-EFGH")
+	require.NoError(t, err)
+
+	out := code.String()
+	assert.NotEmpty(t, out)
+	assert.Contains(t, out, "██")
+
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	assert.Len(t, lines, code.size+4) // +4 for the 2-module quiet zone on top and bottom
+}
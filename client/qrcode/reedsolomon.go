@@ -0,0 +1,80 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package qrcode
+
+// galoisField implements GF(256) arithmetic over the QR code standard's
+// primitive polynomial x^8 + x^4 + x^3 + x^2 + 1 (0x11D), used for both the
+// error correction generator polynomial and the remainder computation below.
+type galoisField struct {
+	exp [255]int
+	log [256]int
+}
+
+const gfPrimitivePoly = 0x11D
+
+func newGaloisField() *galoisField {
+	gf := &galoisField{}
+
+	x := 1
+	for i := 0; i < 255; i++ {
+		gf.exp[i] = x
+		gf.log[x] = i
+
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= gfPrimitivePoly
+		}
+	}
+
+	return gf
+}
+
+func (gf *galoisField) mul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gf.exp[(gf.log[a]+gf.log[b])%255]
+}
+
+// generatorPoly returns the Reed-Solomon generator polynomial of the given
+// degree (the number of ECC codewords), as coefficients from highest to
+// lowest degree with an implicit leading 1.
+func (gf *galoisField) generatorPoly(degree int) []int {
+	result := make([]int, degree)
+	result[degree-1] = 1
+
+	root := 1
+	for i := 0; i < degree; i++ {
+		for j := 0; j < len(result); j++ {
+			result[j] = gf.mul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+
+		root = gf.mul(root, 0x02)
+	}
+
+	return result
+}
+
+// computeRemainder divides data by divisor over GF(256), returning the
+// remainder codewords (one per divisor coefficient) used as the ECC block.
+func (gf *galoisField) computeRemainder(data, divisor []int) []int {
+	result := make([]int, len(divisor))
+
+	for _, b := range data {
+		factor := b ^ result[0]
+
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+
+		for i := range result {
+			result[i] ^= gf.mul(divisor[i], factor)
+		}
+	}
+
+	return result
+}
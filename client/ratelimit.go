@@ -0,0 +1,209 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	searchv1 "github.com/agntcy/dir/api/search/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// retryAfterTrailerKey is the trailer set by server/middleware/ratelimit on a
+// rejected request, carrying the exact delay until the next token.
+const retryAfterTrailerKey = "retry-after-ms"
+
+// RetryPolicy controls how RateLimitUnaryClientInterceptor and
+// RateLimitStreamClientInterceptor retry a call rejected with
+// ResourceExhausted by server/middleware/ratelimit. The backoff is truncated
+// exponential with full jitter: each attempt waits a random duration between
+// zero and min(BaseDelay*2^attempt, MaxDelay, retryAfter), where retryAfter
+// is the server-reported delay when present.
+type RetryPolicy struct {
+	// Enabled gates retries. The zero value RetryPolicy{} has Enabled false,
+	// so passing it to WithRateLimitRetry disables retries entirely.
+	Enabled bool
+
+	// BaseDelay is the first backoff step, doubled on every subsequent attempt.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff step regardless of the server's retry-after hint.
+	MaxDelay time.Duration
+
+	// MaxAttempts is the number of retries attempted after the initial call.
+	MaxAttempts int
+
+	// RetryableMethods is the set of full gRPC method names (e.g.
+	// "/dir.store.v1.StoreService/Lookup") that are safe to retry because
+	// they're idempotent. Methods outside this set are never retried.
+	RetryableMethods map[string]bool
+}
+
+// DefaultRetryPolicy retries only idempotent read RPCs - lookups, pulls, and
+// list/search calls. Mutating RPCs such as Push, PushReferrer, and Delete are
+// deliberately excluded since retrying them could duplicate side effects.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Enabled:     true,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		MaxAttempts: 5,
+		RetryableMethods: map[string]bool{
+			storev1.StoreService_Lookup_FullMethodName:          true,
+			storev1.StoreService_Pull_FullMethodName:            true,
+			storev1.StoreService_PullReferrer_FullMethodName:    true,
+			storev1.SyncService_ListSyncs_FullMethodName:        true,
+			storev1.SyncService_GetSync_FullMethodName:          true,
+			routingv1.RoutingService_List_FullMethodName:        true,
+			routingv1.RoutingService_Search_FullMethodName:      true,
+			searchv1.SearchService_SearchCIDs_FullMethodName:    true,
+			searchv1.SearchService_SearchRecords_FullMethodName: true,
+		},
+	}
+}
+
+// canRetry reports whether attempt (0-indexed) may be retried for method
+// under this policy.
+func (p RetryPolicy) canRetry(method string, attempt int) bool {
+	return p.Enabled && attempt < p.MaxAttempts && p.RetryableMethods[method]
+}
+
+// backoff computes a truncated-exponential, fully-jittered delay for the
+// given attempt (0-indexed). The exponential step is capped at MaxDelay and,
+// when the server reported a retryAfter hint, at that hint too - so a short
+// server-advertised delay is always honored even if it's below MaxDelay.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	ceiling := p.MaxDelay
+	if retryAfter > 0 && retryAfter < ceiling {
+		ceiling = retryAfter
+	}
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	step := p.BaseDelay
+	for i := 0; i < attempt && step < ceiling; i++ {
+		step *= 2
+	}
+
+	if step > ceiling || step <= 0 {
+		step = ceiling
+	}
+
+	return time.Duration(rand.Int63n(int64(step) + 1)) //nolint:gosec // jitter does not need a CSPRNG
+}
+
+// RateLimitUnaryClientInterceptor returns a gRPC unary client interceptor
+// that retries a request rejected with ResourceExhausted by
+// server/middleware/ratelimit, backing off per policy. Only methods in
+// policy.RetryableMethods are ever retried; every other error, status code,
+// or method is returned to the caller untouched.
+func RateLimitUnaryClientInterceptor(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		var err error
+
+		for attempt := 0; ; attempt++ {
+			var trailer metadata.MD
+
+			callOpts := append(append([]grpc.CallOption{}, opts...), grpc.Trailer(&trailer))
+
+			err = invoker(ctx, method, req, reply, cc, callOpts...)
+			if status.Code(err) != codes.ResourceExhausted || !policy.canRetry(method, attempt) {
+				return err
+			}
+
+			retryAfter, _ := retryAfterFromTrailer(trailer)
+
+			select {
+			case <-time.After(policy.backoff(attempt, retryAfter)):
+			case <-ctx.Done():
+				return err
+			}
+		}
+	}
+}
+
+// RateLimitStreamClientInterceptor returns a gRPC stream client interceptor
+// that retries stream-open failures rejected with ResourceExhausted by
+// server/middleware/ratelimit, backing off per policy. It only ever retries
+// the call that creates the stream; once a stream has been successfully
+// opened, errors from it are returned to the caller as-is, since messages
+// may have already been sent or received.
+func RateLimitStreamClientInterceptor(policy RetryPolicy) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		for attempt := 0; ; attempt++ {
+			stream, err := streamer(ctx, desc, cc, method, opts...)
+			if status.Code(err) != codes.ResourceExhausted || !policy.canRetry(method, attempt) {
+				return stream, err
+			}
+
+			retryAfter, _ := retryAfterFromError(err)
+
+			select {
+			case <-time.After(policy.backoff(attempt, retryAfter)):
+			case <-ctx.Done():
+				return stream, err
+			}
+		}
+	}
+}
+
+// retryAfterFromTrailer parses the retry-after-ms trailer, reporting ok=false
+// if it's absent or malformed.
+func retryAfterFromTrailer(trailer metadata.MD) (time.Duration, bool) {
+	values := trailer.Get(retryAfterTrailerKey)
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	ms, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil || ms < 0 {
+		return 0, false
+	}
+
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// retryAfterFromError extracts the google.rpc.RetryInfo detail attached by
+// server/middleware/ratelimit, reporting ok=false if it's absent. Unlike
+// trailers, status details are already available on a failed stream-open
+// call, since no trailer frame has been received yet.
+func retryAfterFromError(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay() != nil {
+			return retryInfo.GetRetryDelay().AsDuration(), true
+		}
+	}
+
+	return 0, false
+}
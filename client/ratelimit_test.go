@@ -0,0 +1,212 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	fakeCheckMethod = "/grpc.health.v1.Health/Check"
+	fakeWatchMethod = "/grpc.health.v1.Health/Watch"
+)
+
+// startFakeHealthServer starts a real gRPC server whose unary/stream server
+// interceptors reject the first rejectCount calls with ResourceExhausted
+// (carrying a RetryInfo detail), then let every later call through to a
+// trivially-healthy grpc.health.v1.Health service. This exercises the client
+// interceptors exactly as they'd see a server/middleware/ratelimit rejection.
+func startFakeHealthServer(t *testing.T, rejectCount int32) string {
+	t.Helper()
+
+	var calls atomic.Int32
+
+	reject := func() error {
+		if calls.Add(1) <= rejectCount {
+			st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+
+			withDetails, err := st.WithDetails(&errdetails.RetryInfo{
+				RetryDelay: durationpb.New(time.Millisecond),
+			})
+			if err != nil {
+				return st.Err()
+			}
+
+			return withDetails.Err()
+		}
+
+		return nil
+	}
+
+	unaryInterceptor := func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := reject(); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+
+	streamInterceptor := func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		if err := reject(); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptor),
+	)
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	return lis.Addr().String()
+}
+
+func dialFakeHealthServer(t *testing.T, addr string, policy RetryPolicy) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient(
+		addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(RateLimitUnaryClientInterceptor(policy)),
+		grpc.WithChainStreamInterceptor(RateLimitStreamClientInterceptor(policy)),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func testPolicy(retryable ...string) RetryPolicy {
+	methods := make(map[string]bool, len(retryable))
+	for _, m := range retryable {
+		methods[m] = true
+	}
+
+	return RetryPolicy{
+		Enabled:          true,
+		BaseDelay:        time.Millisecond,
+		MaxDelay:         50 * time.Millisecond,
+		MaxAttempts:      5,
+		RetryableMethods: methods,
+	}
+}
+
+func TestRateLimitUnaryClientInterceptor_RetriesAllowlistedMethod(t *testing.T) {
+	addr := startFakeHealthServer(t, 2)
+	conn := dialFakeHealthServer(t, addr, testPolicy(fakeCheckMethod))
+
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("expected Check to succeed after retries, got: %v", err)
+	}
+}
+
+func TestRateLimitUnaryClientInterceptor_NeverRetriesMethodOutsideAllowlist(t *testing.T) {
+	addr := startFakeHealthServer(t, 2)
+	conn := dialFakeHealthServer(t, addr, testPolicy("/grpc.health.v1.Health/OtherMethod"))
+
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected un-retried ResourceExhausted, got: %v", err)
+	}
+}
+
+func TestRateLimitUnaryClientInterceptor_StopsAtMaxAttempts(t *testing.T) {
+	addr := startFakeHealthServer(t, 100)
+
+	policy := testPolicy(fakeCheckMethod)
+	policy.MaxAttempts = 2
+
+	conn := dialFakeHealthServer(t, addr, policy)
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted after exhausting retries, got: %v", err)
+	}
+}
+
+func TestRateLimitStreamClientInterceptor_RetriesStreamOpenFailure(t *testing.T) {
+	addr := startFakeHealthServer(t, 2)
+	conn := dialFakeHealthServer(t, addr, testPolicy(fakeWatchMethod))
+
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("expected Watch to succeed after retries, got: %v", err)
+	}
+
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("expected first Recv to succeed, got: %v", err)
+	}
+}
+
+func TestRateLimitStreamClientInterceptor_NeverRetriesMethodOutsideAllowlist(t *testing.T) {
+	addr := startFakeHealthServer(t, 2)
+	conn := dialFakeHealthServer(t, addr, testPolicy("/grpc.health.v1.Health/OtherMethod"))
+
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected un-retried ResourceExhausted, got: %v", err)
+	}
+}
+
+func TestRetryPolicy_Disabled(t *testing.T) {
+	policy := RetryPolicy{}
+	if policy.canRetry(fakeCheckMethod, 0) {
+		t.Error("expected zero-value RetryPolicy to never retry")
+	}
+}
+
+func TestRetryPolicy_BackoffRespectsRetryAfterCeiling(t *testing.T) {
+	policy := testPolicy(fakeCheckMethod)
+	policy.MaxDelay = time.Hour
+
+	for attempt := 0; attempt < 5; attempt++ {
+		delay := policy.backoff(attempt, 5*time.Millisecond)
+		if delay > 5*time.Millisecond {
+			t.Errorf("attempt %d: delay %v exceeds retryAfter ceiling", attempt, delay)
+		}
+	}
+}
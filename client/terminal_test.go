@@ -0,0 +1,33 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTerminal(t *testing.T) {
+	t.Run("should return false for a bytes.Buffer", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.False(t, isTerminal(&buf))
+	})
+
+	t.Run("should return false for io.Discard", func(t *testing.T) {
+		assert.False(t, isTerminal(io.Discard))
+	})
+
+	t.Run("should return false for a regular *os.File", func(t *testing.T) {
+		f, err := os.CreateTemp(t.TempDir(), "isterminal-test")
+		assert.NoError(t, err)
+
+		defer f.Close()
+
+		assert.False(t, isTerminal(f))
+	})
+}
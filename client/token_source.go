@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Token is a refreshable OAuth2 access token, as persisted by a TokenStore
+// and vended by a TokenSource.
+type Token struct {
+	AccessToken  string `json:"access_token"` //nolint:gosec // G117: intentional field for OAuth token
+	TokenType    string `json:"token_type,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// valid reports whether t can be used as-is, without refreshing first.
+func (t *Token) valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+
+	if t.ExpiresAt.IsZero() {
+		return true
+	}
+
+	return time.Now().Add(TokenExpiryBuffer).Before(t.ExpiresAt)
+}
+
+// TokenSource supplies an access token on demand, transparently refreshing
+// it when expired. Modeled on golang.org/x/oauth2.TokenSource, so gRPC/HTTP
+// clients can call Token() once per request rather than caching a token
+// string themselves.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// TokenStore persists and retrieves a Token for a given provider (e.g.
+// "github"), so a TokenSource can survive across process invocations
+// without re-running the device flow every time. FileTokenStore is the
+// default implementation; alternative backends (an OS keyring, a Kubernetes
+// Secret) can implement this interface instead.
+type TokenStore interface {
+	LoadToken(provider string) (*Token, error)
+	SaveToken(provider string, token *Token) error
+}
+
+// deviceTokenSource is a TokenSource backed by an RFC 8628 device flow
+// result, refreshing via the provider's refresh_token grant (RFC 6749
+// section 6) when the access token has expired.
+type deviceTokenSource struct {
+	mu sync.Mutex
+
+	provider string
+	config   *DeviceAuthConfig
+	store    TokenStore
+	token    *Token
+}
+
+// NewTokenSource wraps a device flow result into a refreshing TokenSource,
+// persisting refreshed tokens to store under provider. config should be the
+// same DeviceAuthConfig passed to the original StartDeviceFlow call, since
+// refreshing reuses its TokenURL/ClientID/AuthStyle. store may be nil, in
+// which case refreshed tokens aren't persisted.
+func NewTokenSource(provider string, config *DeviceAuthConfig, store TokenStore, result *DeviceFlowResult) TokenSource {
+	return &deviceTokenSource{
+		provider: provider,
+		config:   config,
+		store:    store,
+		token: &Token{
+			AccessToken:  result.AccessToken,
+			TokenType:    result.TokenType,
+			RefreshToken: result.RefreshToken,
+			ExpiresAt:    result.ExpiresAt,
+		},
+	}
+}
+
+// LoadTokenSource returns a TokenSource backed by a token previously
+// persisted to store under provider, or (nil, nil) if none is cached.
+// Callers should fall back to StartDeviceFlow + NewTokenSource when no
+// cached source is found.
+func LoadTokenSource(provider string, config *DeviceAuthConfig, store TokenStore) (TokenSource, error) {
+	token, err := store.LoadToken(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil {
+		//nolint:nilnil // (nil, nil) means "no cached token", not an error
+		return nil, nil
+	}
+
+	return &deviceTokenSource{provider: provider, config: config, store: store, token: token}, nil
+}
+
+// Token returns the current access token, transparently refreshing it first
+// if it's expired and a refresh_token is available.
+func (s *deviceTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.valid() {
+		return s.token, nil
+	}
+
+	if s.token.RefreshToken == "" {
+		return nil, errors.New("token expired and no refresh_token is available; please re-authenticate")
+	}
+
+	refreshed, err := refreshDeviceToken(context.Background(), s.config, s.token.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	s.token = refreshed
+
+	if s.store != nil {
+		if err := s.store.SaveToken(s.provider, s.token); err != nil {
+			return nil, fmt.Errorf("failed to persist refreshed token: %w", err)
+		}
+	}
+
+	return s.token, nil
+}
@@ -0,0 +1,130 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTokenStore is an in-memory TokenStore used to test TokenSource without
+// touching disk.
+type fakeTokenStore struct {
+	tokens map[string]*Token
+}
+
+func newFakeTokenStore() *fakeTokenStore {
+	return &fakeTokenStore{tokens: make(map[string]*Token)}
+}
+
+func (s *fakeTokenStore) LoadToken(provider string) (*Token, error) {
+	return s.tokens[provider], nil
+}
+
+func (s *fakeTokenStore) SaveToken(provider string, token *Token) error {
+	s.tokens[provider] = token
+
+	return nil
+}
+
+func TestToken_Valid(t *testing.T) {
+	t.Run("should be invalid for a nil token", func(t *testing.T) {
+		var token *Token
+		assert.False(t, token.valid())
+	})
+
+	t.Run("should be invalid for an empty access token", func(t *testing.T) {
+		token := &Token{}
+		assert.False(t, token.valid())
+	})
+
+	t.Run("should be valid when ExpiresAt is zero", func(t *testing.T) {
+		token := &Token{AccessToken: "x"}
+		assert.True(t, token.valid())
+	})
+
+	t.Run("should be valid well before ExpiresAt", func(t *testing.T) {
+		token := &Token{AccessToken: "x", ExpiresAt: time.Now().Add(time.Hour)}
+		assert.True(t, token.valid())
+	})
+
+	t.Run("should be invalid past ExpiresAt", func(t *testing.T) {
+		token := &Token{AccessToken: "x", ExpiresAt: time.Now().Add(-time.Minute)}
+		assert.False(t, token.valid())
+	})
+}
+
+func TestDeviceTokenSource_Token(t *testing.T) {
+	t.Run("should return the cached token without refreshing when still valid", func(t *testing.T) {
+		store := newFakeTokenStore()
+		source := &deviceTokenSource{
+			provider: "github",
+			store:    store,
+			token:    &Token{AccessToken: "still-good", ExpiresAt: time.Now().Add(time.Hour)},
+		}
+
+		token, err := source.Token()
+
+		require.NoError(t, err)
+		assert.Equal(t, "still-good", token.AccessToken)
+	})
+
+	t.Run("should error when expired with no refresh token", func(t *testing.T) {
+		source := &deviceTokenSource{
+			provider: "github",
+			token:    &Token{AccessToken: "expired", ExpiresAt: time.Now().Add(-time.Hour)},
+		}
+
+		_, err := source.Token()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "re-authenticate")
+	})
+}
+
+func TestNewTokenSource(t *testing.T) {
+	t.Run("should wrap a device flow result", func(t *testing.T) {
+		store := newFakeTokenStore()
+		result := &DeviceFlowResult{
+			AccessToken:  "access",
+			TokenType:    "bearer",
+			RefreshToken: "refresh",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}
+
+		source := NewTokenSource("github", &DeviceAuthConfig{}, store, result)
+
+		token, err := source.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "access", token.AccessToken)
+		assert.Equal(t, "refresh", token.RefreshToken)
+	})
+}
+
+func TestLoadTokenSource(t *testing.T) {
+	t.Run("should return nil, nil when no token is cached", func(t *testing.T) {
+		store := newFakeTokenStore()
+
+		source, err := LoadTokenSource("github", &DeviceAuthConfig{}, store)
+
+		require.NoError(t, err)
+		assert.Nil(t, source)
+	})
+
+	t.Run("should return a source backed by the cached token", func(t *testing.T) {
+		store := newFakeTokenStore()
+		require.NoError(t, store.SaveToken("github", &Token{AccessToken: "cached", ExpiresAt: time.Now().Add(time.Hour)}))
+
+		source, err := LoadTokenSource("github", &DeviceAuthConfig{}, store)
+		require.NoError(t, err)
+		require.NotNil(t, source)
+
+		token, err := source.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "cached", token.AccessToken)
+	})
+}
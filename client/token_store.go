@@ -0,0 +1,86 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// tokenStoreDirPerms and tokenStoreFilePerms mirror TokenCache's
+	// permissions: owner-only access, since these files hold live credentials.
+	tokenStoreDirPerms  = 0o700
+	tokenStoreFilePerms = 0o600
+)
+
+// FileTokenStore persists tokens as JSON files under
+// $XDG_CONFIG_HOME/dir/tokens/<provider>.json (falling back to
+// ~/.config/dir/tokens when XDG_CONFIG_HOME is unset), with 0600 file
+// permissions.
+type FileTokenStore struct {
+	// Dir is the directory tokens are stored in, one file per provider.
+	Dir string
+}
+
+// NewFileTokenStore creates a FileTokenStore at the default XDG-based path.
+func NewFileTokenStore() *FileTokenStore {
+	return &FileTokenStore{Dir: defaultTokenStoreDir()}
+}
+
+func defaultTokenStoreDir() string {
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+		return filepath.Join(xdgConfigHome, "dir", "tokens")
+	}
+
+	home, _ := os.UserHomeDir()
+
+	return filepath.Join(home, ".config", "dir", "tokens")
+}
+
+func (s *FileTokenStore) path(provider string) string {
+	return filepath.Join(s.Dir, provider+".json")
+}
+
+// LoadToken loads the token persisted for provider. Returns (nil, nil) if no
+// token file exists yet.
+func (s *FileTokenStore) LoadToken(provider string) (*Token, error) {
+	data, err := os.ReadFile(s.path(provider))
+	if err != nil {
+		if os.IsNotExist(err) {
+			//nolint:nilnil // (nil, nil) means "not found", not an error
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token file: %w", err)
+	}
+
+	return &token, nil
+}
+
+// SaveToken persists token for provider, creating the store directory if
+// needed.
+func (s *FileTokenStore) SaveToken(provider string, token *Token) error {
+	if err := os.MkdirAll(s.Dir, tokenStoreDirPerms); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	if err := os.WriteFile(s.path(provider), data, tokenStoreFilePerms); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTokenStore_SaveAndLoad(t *testing.T) {
+	t.Run("should round-trip a token", func(t *testing.T) {
+		store := &FileTokenStore{Dir: t.TempDir()}
+
+		token := &Token{
+			AccessToken:  "access-123",
+			TokenType:    "bearer",
+			RefreshToken: "refresh-456",
+			ExpiresAt:    time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+
+		require.NoError(t, store.SaveToken("github", token))
+
+		loaded, err := store.LoadToken("github")
+		require.NoError(t, err)
+		require.NotNil(t, loaded)
+		assert.Equal(t, token.AccessToken, loaded.AccessToken)
+		assert.Equal(t, token.RefreshToken, loaded.RefreshToken)
+		assert.True(t, token.ExpiresAt.Equal(loaded.ExpiresAt))
+	})
+
+	t.Run("should return nil, nil for a missing provider", func(t *testing.T) {
+		store := &FileTokenStore{Dir: t.TempDir()}
+
+		loaded, err := store.LoadToken("does-not-exist")
+		require.NoError(t, err)
+		assert.Nil(t, loaded)
+	})
+
+	t.Run("should write the file with 0600 permissions", func(t *testing.T) {
+		dir := t.TempDir()
+		store := &FileTokenStore{Dir: dir}
+
+		require.NoError(t, store.SaveToken("github", &Token{AccessToken: "x"}))
+
+		info, err := os.Stat(filepath.Join(dir, "github.json"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("should keep separate files per provider", func(t *testing.T) {
+		store := &FileTokenStore{Dir: t.TempDir()}
+
+		require.NoError(t, store.SaveToken("github", &Token{AccessToken: "gh"}))
+		require.NoError(t, store.SaveToken("google", &Token{AccessToken: "goog"}))
+
+		ghToken, err := store.LoadToken("github")
+		require.NoError(t, err)
+		googToken, err := store.LoadToken("google")
+		require.NoError(t, err)
+
+		assert.Equal(t, "gh", ghToken.AccessToken)
+		assert.Equal(t, "goog", googToken.AccessToken)
+	})
+}
+
+func TestNewFileTokenStore(t *testing.T) {
+	t.Run("should default to a dir/tokens path", func(t *testing.T) {
+		store := NewFileTokenStore()
+
+		require.NotNil(t, store)
+		assert.Contains(t, store.Dir, filepath.Join("dir", "tokens"))
+	})
+
+	t.Run("should respect XDG_CONFIG_HOME", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", "/custom/xdg")
+
+		store := NewFileTokenStore()
+
+		assert.Equal(t, filepath.Join("/custom/xdg", "dir", "tokens"), store.Dir)
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	sharedcosign "github.com/agntcy/dir/utils/cosign"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// inTotoPayloadType is the DSSE payload type used by in-toto attestations.
+const inTotoPayloadType = "application/vnd.in-toto+json"
+
+// VerifyAttestationWithOIDC verifies an in-toto attestation wrapped in a DSSE
+// envelope against an OIDC-issued (Fulcio) certificate, mirroring
+// VerifyWithOIDC's trust model. Unlike VerifyWithOIDC, the signature covers
+// the PAE-encoded DSSE payload rather than a raw artifact, and the artifact
+// is instead checked against the attestation's subject digests.
+func VerifyAttestationWithOIDC(req *signv1.VerifyAttestationWithOIDC, signature *signv1.Signature) (*signv1.SignerInfo, error) {
+	opts := req.GetOptions().GetDefaultOptions()
+
+	envelope, err := decodeDSSEEnvelope(signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode DSSE envelope: %w", err)
+	}
+
+	predicateBytes, err := verifyAttestationStatement(envelope, req.GetArtifactDigest(), req.GetPredicateType())
+	if err != nil {
+		return nil, err
+	}
+
+	leaf, err := extractSignerCertificate(signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract signer certificate: %w", err)
+	}
+
+	trustedMaterial, err := getOIDCTrustedMaterial(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trusted material: %w", err)
+	}
+
+	if err := verifyLeafAgainstFulcio(leaf, trustedMaterial); err != nil {
+		return nil, err
+	}
+
+	oidcInfo, err := sharedcosign.ExtractOIDCInfoFromCert(leaf)
+	if err != nil && (req.GetSubject() != "" || req.GetIssuer() != "") {
+		return nil, fmt.Errorf("failed to extract identity from certificate: %w", err)
+	}
+
+	if req.GetSubject() != "" && (oidcInfo == nil || oidcInfo.Identity != req.GetSubject()) {
+		return nil, fmt.Errorf("certificate subject does not match expected %q", req.GetSubject())
+	}
+
+	if req.GetIssuer() != "" && (oidcInfo == nil || oidcInfo.Issuer != req.GetIssuer()) {
+		return nil, fmt.Errorf("certificate issuer does not match expected %q", req.GetIssuer())
+	}
+
+	pubKeyPEM, err := cryptoutils.MarshalPublicKeyToPEM(leaf.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal leaf public key: %w", err)
+	}
+
+	pae := dssePAE(envelope.PayloadType, envelope.payload)
+
+	if err := verifyAnyDSSESignature(envelope, []string{string(pubKeyPEM)}, pae, nil); err != nil {
+		return nil, fmt.Errorf("attestation signature verification failed: %w", err)
+	}
+
+	signerInfo := &signv1.SignerInfo{
+		Type:           &signv1.SignerInfo_Oidc{Oidc: &signv1.SignerInfoOIDC{}},
+		PredicateBytes: predicateBytes,
+	}
+
+	if oidcInfo != nil {
+		signerInfo.GetOidc().Issuer = oidcInfo.Issuer
+		signerInfo.GetOidc().Subject = oidcInfo.Identity
+	}
+
+	return signerInfo, nil
+}
+
+// VerifyAttestationWithKeys verifies an in-toto attestation wrapped in a DSSE
+// envelope against caller-supplied public keys, mirroring VerifyWithKeys.
+// Unlike VerifyWithKeys, every envelope signature is checked against the
+// PAE-encoded DSSE payload rather than a raw payload, and artifactDigest /
+// predicateType are matched against the inner in-toto Statement instead.
+func VerifyAttestationWithKeys(
+	ctx context.Context,
+	pubKeys []string,
+	signature *signv1.Signature,
+	artifactDigest, predicateType string,
+	opts *LoadOptions,
+) (*signv1.SignerInfo, error) {
+	envelope, err := decodeDSSEEnvelope(signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode DSSE envelope: %w", err)
+	}
+
+	predicateBytes, err := verifyAttestationStatement(envelope, artifactDigest, predicateType)
+	if err != nil {
+		return nil, err
+	}
+
+	pae := dssePAE(envelope.PayloadType, envelope.payload)
+
+	for _, dsseSig := range envelope.Signatures {
+		for _, publicKey := range pubKeys {
+			pubKeyPEM, err := verifySignatureWithKey(ctx, publicKey, dsseSig.Sig, pae, opts)
+			if err != nil {
+				continue
+			}
+
+			return &signv1.SignerInfo{
+				Type: &signv1.SignerInfo_Key{
+					Key: &signv1.SignerInfoKey{
+						PublicKey: pubKeyPEM,
+						Algorithm: detectKeyAlgorithm(pubKeyPEM, opts),
+					},
+				},
+				PredicateBytes: predicateBytes,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no valid signature found for the provided public keys")
+}
+
+// dsseEnvelope is a decoded DSSE envelope (https://github.com/secure-systems-lab/dsse),
+// with payload already base64-decoded.
+type dsseEnvelope struct {
+	PayloadType string
+	Signatures  []dsseSignature
+	payload     []byte
+}
+
+type dsseSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// rawDSSEEnvelope is the wire format of a DSSE envelope.
+type rawDSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+// decodeDSSEEnvelope extracts a DSSE envelope from signature, preferring a
+// Sigstore bundle's embedded "dsseEnvelope" field and falling back to a bare
+// DSSE envelope JSON in either the content bundle or the detached signature
+// field.
+func decodeDSSEEnvelope(signature *signv1.Signature) (*dsseEnvelope, error) {
+	raw := signature.GetContentBundle()
+	if raw == "" {
+		raw = signature.GetSignature()
+	}
+
+	if raw == "" {
+		return nil, fmt.Errorf("signature has neither a content bundle nor a signature field")
+	}
+
+	var bundleWrapper struct {
+		DSSEEnvelope *rawDSSEEnvelope `json:"dsseEnvelope"`
+	}
+
+	envelopeJSON := &rawDSSEEnvelope{}
+
+	if err := json.Unmarshal([]byte(raw), &bundleWrapper); err == nil && bundleWrapper.DSSEEnvelope != nil {
+		envelopeJSON = bundleWrapper.DSSEEnvelope
+	} else if err := json.Unmarshal([]byte(raw), envelopeJSON); err != nil {
+		return nil, fmt.Errorf("failed to parse DSSE envelope: %w", err)
+	}
+
+	if envelopeJSON.PayloadType == "" || envelopeJSON.Payload == "" {
+		return nil, fmt.Errorf("content does not contain a DSSE envelope")
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(envelopeJSON.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode DSSE payload: %w", err)
+	}
+
+	return &dsseEnvelope{
+		PayloadType: envelopeJSON.PayloadType,
+		Signatures:  envelopeJSON.Signatures,
+		payload:     payload,
+	}, nil
+}
+
+// dssePAE computes the DSSE v1 "pre-authentication encoding" that envelope
+// signatures are computed over, per the DSSE spec:
+// PAE = "DSSEv1" + SP + len(payloadType) + SP + payloadType + SP + len(payload) + SP + payload.
+func dssePAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// verifyAnyDSSESignature returns nil if any of envelope's signatures
+// verifies against pae with one of pubKeys.
+func verifyAnyDSSESignature(envelope *dsseEnvelope, pubKeys []string, pae []byte, opts *LoadOptions) error {
+	for _, dsseSig := range envelope.Signatures {
+		for _, publicKey := range pubKeys {
+			if _, err := verifySignatureWithKey(context.Background(), publicKey, dsseSig.Sig, pae, opts); err == nil {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("no envelope signature verified with the signer's public key")
+}
+
+// inTotoStatement is the subset of the in-toto Statement layer
+// (https://github.com/in-toto/attestation) needed to match a verified
+// attestation against a caller-supplied artifact digest and predicate type.
+type inTotoStatement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Subject       []inTotoSubject `json:"subject"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+type inTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// verifyAttestationStatement JSON-parses the in-toto Statement carried in
+// envelope's payload and checks that artifactDigest (if set) appears among
+// its subject digests and that predicateType (if set) matches the
+// statement's predicate type. Returns the statement's raw predicate bytes.
+func verifyAttestationStatement(envelope *dsseEnvelope, artifactDigest, predicateType string) ([]byte, error) {
+	if envelope.PayloadType != inTotoPayloadType {
+		return nil, fmt.Errorf("unsupported DSSE payload type %q", envelope.PayloadType)
+	}
+
+	var statement inTotoStatement
+	if err := json.Unmarshal(envelope.payload, &statement); err != nil {
+		return nil, fmt.Errorf("failed to parse in-toto statement: %w", err)
+	}
+
+	if predicateType != "" && statement.PredicateType != predicateType {
+		return nil, fmt.Errorf("predicate type %q does not match expected %q", statement.PredicateType, predicateType)
+	}
+
+	if artifactDigest != "" {
+		var found bool
+
+		for _, subject := range statement.Subject {
+			for _, digest := range subject.Digest {
+				if digest == artifactDigest {
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("artifact digest %q not found in attestation subjects", artifactDigest)
+		}
+	}
+
+	return statement.Predicate, nil
+}
+
+// verifyLeafAgainstFulcio chains leaf against the Fulcio certificate
+// authorities carried by trustedMaterial, the same way VerifyWithCABundle
+// chains against a caller-supplied CA bundle.
+func verifyLeafAgainstFulcio(leaf *x509.Certificate, trustedMaterial root.TrustedMaterial) error {
+	rootPool := x509.NewCertPool()
+	intermediatePool := x509.NewCertPool()
+
+	for _, ca := range trustedMaterial.FulcioCertificateAuthorities() {
+		fca, ok := ca.(*root.FulcioCertificateAuthority)
+		if !ok {
+			continue
+		}
+
+		if fca.Root != nil {
+			rootPool.AddCert(fca.Root)
+		}
+
+		for _, intermediate := range fca.Intermediates {
+			intermediatePool.AddCert(intermediate)
+		}
+	}
+
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediatePool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("certificate chain verification failed: %w", err)
+	}
+
+	return nil
+}
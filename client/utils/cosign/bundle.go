@@ -0,0 +1,70 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	sharedcosign "github.com/agntcy/dir/utils/cosign"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// UpgradeLegacySignature wraps a legacy signature's raw base64 signature
+// and, optionally, base64 DER certificate into a Sigstore bundle via
+// sharedcosign.BuildBundle, so old cosign v1-style signatures can be
+// verified through VerifyWithOIDC instead of VerifyWithKeys. rekorEntryJSON
+// and tsaTokens are forwarded to BuildBundle unchanged; see its doc comment.
+//
+// The legacy signature, certificate, and content type are preserved
+// alongside the new ContentBundle so callers that still inspect the
+// detached fields keep working.
+func UpgradeLegacySignature(payload []byte, legacy *signv1.Signature, rekorEntryJSON []byte, tsaTokens [][]byte) (*signv1.Signature, error) {
+	sigBytes, err := base64.StdEncoding.DecodeString(legacy.GetSignature())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode legacy signature: %w", err)
+	}
+
+	var certPEM string
+
+	if b64Cert := legacy.GetCertificate(); b64Cert != "" {
+		rawCert, err := base64.StdEncoding.DecodeString(b64Cert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode legacy certificate: %w", err)
+		}
+
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse legacy certificate: %w", err)
+		}
+
+		pemBytes, err := cryptoutils.MarshalCertificateToPEM(cert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal legacy certificate to PEM: %w", err)
+		}
+
+		certPEM = string(pemBytes)
+	}
+
+	b, err := sharedcosign.BuildBundle(payload, sigBytes, certPEM, rekorEntryJSON, tsaTokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build bundle: %w", err)
+	}
+
+	bundleJSON, err := b.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	return &signv1.Signature{
+		Signature:     legacy.GetSignature(),
+		Certificate:   legacy.GetCertificate(),
+		ContentType:   legacy.GetContentType(),
+		ContentBundle: string(bundleJSON),
+		SignedAt:      legacy.GetSignedAt(),
+		Annotations:   legacy.GetAnnotations(),
+	}, nil
+}
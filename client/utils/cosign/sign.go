@@ -6,6 +6,10 @@ package cosign
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
 	"encoding/base64"
 	"fmt"
 	"time"
@@ -70,20 +74,16 @@ func SignBlobWithOIDC(ctx context.Context, payload []byte, req *signv1.SignWithO
 		}, nil
 }
 
-// SignBlobWithKey signs a blob using a private key.
-func SignBlobWithKey(_ context.Context, payload []byte, req *signv1.SignWithKey) (*signv1.Signature, *signv1.PublicKey, error) {
+// SignBlobWithKey signs a blob using a private key. Ed25519 keys sign via
+// Ed25519ph (payload pre-hashed with SHA-512) instead of plain Ed25519, so
+// signing round-trips with VerifyWithKeys' Ed25519ph verification path.
+func SignBlobWithKey(ctx context.Context, payload []byte, req *signv1.SignWithKey) (*signv1.Signature, *signv1.PublicKey, error) {
 	// Load private key
 	sv, err := cosign.LoadPrivateKey(req.GetPrivateKey(), req.GetPassword(), nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("loading private key: %w", err)
 	}
 
-	// Sign the message
-	sig, err := sv.SignMessage(bytes.NewReader(payload))
-	if err != nil {
-		return nil, nil, fmt.Errorf("signing blob: %w", err)
-	}
-
 	// Get public key
 	pubKey, err := sv.PublicKey()
 	if err != nil {
@@ -95,10 +95,32 @@ func SignBlobWithKey(_ context.Context, payload []byte, req *signv1.SignWithKey)
 		return nil, nil, fmt.Errorf("getting public key: %w", err)
 	}
 
+	var sig []byte
+
+	if _, ok := pubKey.(ed25519.PublicKey); ok {
+		signer, _, err := sv.CryptoSigner(ctx, func(error) {})
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting crypto signer: %w", err)
+		}
+
+		digest := sha512.Sum512(payload)
+
+		sig, err = signer.Sign(rand.Reader, digest[:], &ed25519.Options{Hash: crypto.SHA512})
+		if err != nil {
+			return nil, nil, fmt.Errorf("signing blob with Ed25519ph: %w", err)
+		}
+	} else {
+		// Sign the message
+		sig, err = sv.SignMessage(bytes.NewReader(payload))
+		if err != nil {
+			return nil, nil, fmt.Errorf("signing blob: %w", err)
+		}
+	}
+
 	return &signv1.Signature{
 			SignedAt:  time.Now().UTC().Format(time.RFC3339),
 			Signature: base64.StdEncoding.EncodeToString(sig),
-			Algorithm: detectKeyAlgorithm(string(publicKeyPEM)),
+			Algorithm: detectKeyAlgorithm(string(publicKeyPEM), nil),
 		}, &signv1.PublicKey{
 			Key: string(publicKeyPEM),
 		}, nil
@@ -0,0 +1,203 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/tuf"
+)
+
+// DefaultRefreshWindow is how long before a cached trusted root's TUF
+// metadata expires that a background refresh is triggered.
+const DefaultRefreshWindow = time.Hour
+
+// DefaultTrustedRootCache is the package-level cache used by
+// getOIDCTrustedMaterial, so repeated VerifyWithOIDC calls against the same
+// TUF mirror reuse already-fetched trusted root material instead of doing a
+// full TUF metadata round-trip on every verification.
+var DefaultTrustedRootCache = NewTrustedRootCache(defaultTrustedRootCacheDir(), DefaultRefreshWindow)
+
+// trustedRootCacheKey identifies one cached trusted root by mirror URL and
+// whether it is the Sigstore staging instance, which uses a different TUF
+// root of trust than production.
+type trustedRootCacheKey struct {
+	mirrorURL string
+	staging   bool
+}
+
+type trustedRootCacheEntry struct {
+	material   root.TrustedMaterial
+	expiresAt  time.Time
+	refreshing bool
+}
+
+// TrustedRootCache caches parsed TUF trusted root material keyed by
+// (mirror URL, staging-vs-prod). It serves cached material until it is
+// within refreshWindow of expiring, at which point it kicks off a
+// non-blocking background refresh and keeps serving the still-valid cached
+// copy in the meantime.
+type TrustedRootCache struct {
+	mu            sync.Mutex
+	entries       map[trustedRootCacheKey]*trustedRootCacheEntry
+	cacheDir      string
+	refreshWindow time.Duration
+}
+
+// NewTrustedRootCache creates a TrustedRootCache that persists TUF metadata
+// under cacheDir (each mirror gets its own subdirectory) and refreshes
+// cached trusted roots refreshWindow before they expire.
+func NewTrustedRootCache(cacheDir string, refreshWindow time.Duration) *TrustedRootCache {
+	return &TrustedRootCache{
+		entries:       make(map[trustedRootCacheKey]*trustedRootCacheEntry),
+		cacheDir:      cacheDir,
+		refreshWindow: refreshWindow,
+	}
+}
+
+// Get returns trusted material for mirrorURL, fetching and caching it (and
+// persisting TUF metadata under the cache directory) on first use, or
+// triggering a background refresh when the cached copy is within the
+// refresh window of expiring.
+func (c *TrustedRootCache) Get(ctx context.Context, mirrorURL string, staging bool) (root.TrustedMaterial, error) {
+	key := trustedRootCacheKey{mirrorURL: mirrorURL, staging: staging}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if !ok {
+		material, expiresAt, err := c.fetch(mirrorURL, staging)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.entries[key] = &trustedRootCacheEntry{material: material, expiresAt: expiresAt}
+		c.mu.Unlock()
+
+		return material, nil
+	}
+
+	if time.Until(entry.expiresAt) <= c.refreshWindow {
+		c.refreshInBackground(key, mirrorURL, staging)
+	}
+
+	return entry.material, nil
+}
+
+// Invalidate drops any cached trusted material for mirrorURL (both the
+// production and staging variants), forcing the next Get to fetch fresh.
+func (c *TrustedRootCache) Invalidate(mirrorURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, trustedRootCacheKey{mirrorURL: mirrorURL, staging: false})
+	delete(c.entries, trustedRootCacheKey{mirrorURL: mirrorURL, staging: true})
+}
+
+// Prewarm fetches and caches trusted material for mirrorURL synchronously,
+// so CLI invocations can pay the TUF round-trip cost up front instead of on
+// the first verification.
+func (c *TrustedRootCache) Prewarm(ctx context.Context, mirrorURL string) error {
+	staging := strings.Contains(mirrorURL, "sigstage")
+
+	_, err := c.Get(ctx, mirrorURL, staging)
+
+	return err
+}
+
+// refreshInBackground starts (at most one) goroutine refreshing key's entry
+// and swaps in the result once it completes. Fetch errors are ignored - the
+// stale-but-still-valid entry keeps being served, and the next Get retries.
+func (c *TrustedRootCache) refreshInBackground(key trustedRootCacheKey, mirrorURL string, staging bool) {
+	c.mu.Lock()
+	entry := c.entries[key]
+	if entry == nil || entry.refreshing {
+		c.mu.Unlock()
+
+		return
+	}
+
+	entry.refreshing = true
+	c.mu.Unlock()
+
+	go func() {
+		material, expiresAt, err := c.fetch(mirrorURL, staging)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		current := c.entries[key]
+		if current == nil {
+			return
+		}
+
+		current.refreshing = false
+
+		if err != nil {
+			return
+		}
+
+		current.material = material
+		current.expiresAt = expiresAt
+	}()
+}
+
+func (c *TrustedRootCache) fetch(mirrorURL string, staging bool) (root.TrustedMaterial, time.Time, error) {
+	tufOpts := tuf.DefaultOptions()
+	tufOpts.RepositoryBaseURL = mirrorURL
+	tufOpts.CachePath = filepath.Join(c.cacheDir, cacheDirName(mirrorURL))
+
+	if staging {
+		tufOpts.Root = tuf.StagingRoot()
+	}
+
+	trustedRoot, err := root.FetchTrustedRootWithOptions(tufOpts)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to fetch trusted root from TUF: %w", err)
+	}
+
+	return trustedRoot, time.Now().Add(tufCacheValidity(tufOpts)), nil
+}
+
+// cacheDirName derives a filesystem-safe subdirectory name for mirrorURL, so
+// multiple mirrors can share one cache root without path collisions.
+func cacheDirName(mirrorURL string) string {
+	replacer := strings.NewReplacer("://", "_", "/", "_", ":", "_")
+
+	return replacer.Replace(mirrorURL)
+}
+
+// tufCacheValidity returns how long a freshly-fetched trusted root should be
+// considered valid before a refresh is attempted, based on the TUF client's
+// own cache validity window (falling back to a day when unset).
+func tufCacheValidity(tufOpts *tuf.Options) time.Duration {
+	if tufOpts.CacheValidity > 0 {
+		return time.Duration(tufOpts.CacheValidity) * 24 * time.Hour
+	}
+
+	return 24 * time.Hour
+}
+
+// defaultTrustedRootCacheDir returns $XDG_CACHE_HOME/agntcy-dir/tuf, falling
+// back to os.UserCacheDir() when XDG_CACHE_HOME is unset.
+func defaultTrustedRootCacheDir() string {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "agntcy-dir", "tuf")
+	}
+
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "agntcy-dir", "tuf")
+	}
+
+	return filepath.Join(".cache", "agntcy-dir", "tuf")
+}
@@ -10,17 +10,22 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
 	signv1 "github.com/agntcy/dir/api/sign/v1"
+	sharedcosign "github.com/agntcy/dir/utils/cosign"
 	sigs "github.com/sigstore/cosign/v3/pkg/signature"
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	"github.com/sigstore/sigstore-go/pkg/bundle"
 	"github.com/sigstore/sigstore-go/pkg/root"
-	"github.com/sigstore/sigstore-go/pkg/tuf"
 	"github.com/sigstore/sigstore-go/pkg/verify"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 )
@@ -41,6 +46,14 @@ func VerifyWithOIDC(payload []byte, req *signv1.VerifyWithOIDC, signature *signv
 		return nil, fmt.Errorf("failed to parse bundle: %w", err)
 	}
 
+	// A pre-fetched RFC3161 timestamp token lets fully offline setups attach
+	// their TSA response without a live round-trip at signing time.
+	if path := signature.GetRfc3161TimestampPath(); path != "" {
+		if err := attachRFC3161Timestamp(bundle, path); err != nil {
+			return nil, fmt.Errorf("failed to attach RFC3161 timestamp: %w", err)
+		}
+	}
+
 	// Get trusted material
 	trustedMaterial, err := getOIDCTrustedMaterial(opts)
 	if err != nil {
@@ -89,6 +102,17 @@ func VerifyWithOIDC(payload []byte, req *signv1.VerifyWithOIDC, signature *signv
 	}, nil
 }
 
+// LoadOptions configures how a public key's signature is verified (or
+// generated). Ed25519 keys always verify/sign through Ed25519ph (the
+// payload pre-hashed with SHA-512), detected automatically from the key
+// type, since plain Ed25519 cannot be used in a streaming/pre-hashed
+// signing flow. HashAlgorithm only affects RSA/ECDSA keys.
+type LoadOptions struct {
+	// HashAlgorithm overrides the digest algorithm used for RSA/ECDSA keys.
+	// Defaults to crypto.SHA256 when zero. Ignored for Ed25519 keys.
+	HashAlgorithm crypto.Hash
+}
+
 // VerifyWithKeys verifies signatures against public keys using cosign.
 // It iterates through all combinations of public keys and signatures to find
 // a valid match.
@@ -97,10 +121,10 @@ func VerifyWithOIDC(payload []byte, req *signv1.VerifyWithOIDC, signature *signv
 // Returns true with metadata if any signature verifies with any public key.
 // Returns false with nil error if no valid combination is found or if
 // no signatures/public keys are provided.
-func VerifyWithKeys(ctx context.Context, payload []byte, pubKeys []string, signature *signv1.Signature) (*signv1.SignerInfo, error) {
+func VerifyWithKeys(ctx context.Context, payload []byte, pubKeys []string, signature *signv1.Signature, opts *LoadOptions) (*signv1.SignerInfo, error) {
 	// Try each public key against each signature
 	for _, publicKey := range pubKeys {
-		pubKeyPEM, err := verifySignatureWithKey(ctx, publicKey, signature.GetSignature(), payload)
+		pubKeyPEM, err := verifySignatureWithKey(ctx, publicKey, signature.GetSignature(), payload, opts)
 		if err != nil {
 			// Log and continue to try next combination
 			continue
@@ -111,7 +135,7 @@ func VerifyWithKeys(ctx context.Context, payload []byte, pubKeys []string, signa
 			Type: &signv1.SignerInfo_Key{
 				Key: &signv1.SignerInfoKey{
 					PublicKey: pubKeyPEM,
-					Algorithm: detectKeyAlgorithm(pubKeyPEM),
+					Algorithm: detectKeyAlgorithm(pubKeyPEM, opts),
 				},
 			},
 		}, nil
@@ -121,6 +145,164 @@ func VerifyWithKeys(ctx context.Context, payload []byte, pubKeys []string, signa
 	return nil, fmt.Errorf("no valid signature found for the provided public keys")
 }
 
+// VerifyWithCABundle verifies a signature's leaf certificate against a
+// caller-supplied CA bundle instead of Sigstore's public Fulcio root or TUF
+// trust root. This lets enterprises with a private Fulcio-style CA verify
+// signatures without needing connectivity to Sigstore's public services.
+//
+// The signer certificate is taken from the signature's detached certificate
+// if present, falling back to the certificate embedded in a Sigstore bundle's
+// verification material. The certificate is chained against req's CA roots
+// and intermediates with x509.Verify, then the expected subject/issuer (if
+// set) are matched against the certificate's OIDC extensions before the raw
+// signature is checked against the certificate's public key.
+func VerifyWithCABundle(payload []byte, req *signv1.VerifyWithCABundle, signature *signv1.Signature) (*signv1.SignerInfo, error) {
+	rootPool, err := certPoolFromPEM(req.GetCaRootsPem())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA roots: %w", err)
+	}
+
+	intermediatePool, err := certPoolFromPEM(req.GetCaIntermediatesPem())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA intermediates: %w", err)
+	}
+
+	leaf, err := extractSignerCertificate(signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract signer certificate: %w", err)
+	}
+
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediatePool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("certificate chain verification failed: %w", err)
+	}
+
+	oidcInfo, err := sharedcosign.ExtractOIDCInfoFromCert(leaf)
+	if err != nil && (req.GetSubject() != "" || req.GetIssuer() != "") {
+		return nil, fmt.Errorf("failed to extract identity from certificate: %w", err)
+	}
+
+	if req.GetSubject() != "" && (oidcInfo == nil || oidcInfo.Identity != req.GetSubject()) {
+		return nil, fmt.Errorf("certificate subject does not match expected %q", req.GetSubject())
+	}
+
+	if req.GetIssuer() != "" && (oidcInfo == nil || oidcInfo.Issuer != req.GetIssuer()) {
+		return nil, fmt.Errorf("certificate issuer does not match expected %q", req.GetIssuer())
+	}
+
+	pubKeyPEM, err := cryptoutils.MarshalPublicKeyToPEM(leaf.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal leaf public key: %w", err)
+	}
+
+	if _, err := verifySignatureWithKey(context.Background(), string(pubKeyPEM), signature.GetSignature(), payload, nil); err != nil {
+		return nil, fmt.Errorf("signature verification failed: %w", err)
+	}
+
+	matchedRoot := chains[0][len(chains[0])-1]
+
+	matchedRootPEM, err := cryptoutils.MarshalCertificateToPEM(matchedRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal matched root certificate: %w", err)
+	}
+
+	return &signv1.SignerInfo{
+		Type: &signv1.SignerInfo_CaBundle{
+			CaBundle: &signv1.SignerInfoCABundle{
+				Subject:     leaf.Subject.String(),
+				Issuer:      leaf.Issuer.String(),
+				MatchedRoot: string(matchedRootPEM),
+			},
+		},
+	}, nil
+}
+
+// certPoolFromPEM parses a PEM bundle of certificates into an x509.CertPool.
+// An empty pemBundle yields an empty (non-nil) pool, matching x509.Verify's
+// treatment of an empty intermediates pool.
+func certPoolFromPEM(pemBundle string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+
+	if pemBundle == "" {
+		return pool, nil
+	}
+
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(pemBundle))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificates: %w", err)
+	}
+
+	for _, cert := range certs {
+		pool.AddCert(cert)
+	}
+
+	return pool, nil
+}
+
+// extractSignerCertificate returns the signer certificate for signature,
+// preferring a detached certificate over one embedded in a Sigstore bundle.
+func extractSignerCertificate(signature *signv1.Signature) (*x509.Certificate, error) {
+	if b64Cert := signature.GetCertificate(); b64Cert != "" {
+		rawCert, err := base64.StdEncoding.DecodeString(b64Cert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode detached certificate: %w", err)
+		}
+
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse detached certificate: %w", err)
+		}
+
+		return cert, nil
+	}
+
+	if signature.GetContentBundle() == "" {
+		return nil, fmt.Errorf("signature has neither a detached certificate nor a content bundle")
+	}
+
+	parsed, err := sharedcosign.ParseBundle(signature.GetContentBundle())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse content bundle: %w", err)
+	}
+
+	if parsed.Certificate == nil {
+		return nil, fmt.Errorf("content bundle does not contain a verification certificate")
+	}
+
+	return parsed.Certificate, nil
+}
+
+// attachRFC3161Timestamp reads a detached RFC3161 timestamp token from path
+// and attaches it to b's verification material, so a bundle signed without a
+// live TSA round-trip can still be verified with verify.WithSignedTimestamps.
+func attachRFC3161Timestamp(b *bundle.Bundle, path string) error {
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read timestamp token: %w", err)
+	}
+
+	vm := b.VerificationMaterial
+	if vm == nil {
+		vm = &protobundle.VerificationMaterial{}
+		b.VerificationMaterial = vm
+	}
+
+	if vm.TimestampVerificationData == nil {
+		vm.TimestampVerificationData = &protobundle.TimestampVerificationData{}
+	}
+
+	vm.TimestampVerificationData.Rfc3161Timestamps = append(
+		vm.TimestampVerificationData.Rfc3161Timestamps,
+		&protocommon.RFC3161SignedTimestamp{SignedTimestamp: token},
+	)
+
+	return nil
+}
+
 // ResolvePublicKeyToPEM resolves a key reference to PEM-encoded public key content.
 // The keyRef can be PEM content, file path, URL, KMS URI, etc. (same as VerifyWithKey).
 func ResolvePublicKeyToPEM(ctx context.Context, keyRef string) (string, error) {
@@ -171,18 +353,15 @@ func PublicKeyPEMsEqual(pem1, pem2 string) bool {
 // The publicKey can be either:
 // - PEM-encoded public key content
 // - A key reference (file path, URL, or KMS URI)
+// Ed25519 keys are always verified as Ed25519ph (payload pre-hashed with
+// SHA-512); opts.HashAlgorithm only applies to RSA/ECDSA keys.
 // Returns the PEM-encoded public key content on success.
-func verifySignatureWithKey(ctx context.Context, publicKey string, sig string, expectedPayload []byte) (string, error) {
+func verifySignatureWithKey(ctx context.Context, publicKey string, sig string, expectedPayload []byte, opts *LoadOptions) (string, error) {
 	pubKeyPEM, err := ResolvePublicKeyToPEM(ctx, publicKey)
 	if err != nil {
 		return "", err
 	}
 
-	verifier, err := sigs.LoadPublicKeyRaw([]byte(pubKeyPEM), crypto.SHA256)
-	if err != nil {
-		return "", fmt.Errorf("failed to load public key: %w", err)
-	}
-
 	// Decode base64 signature
 	signatureBytes, err := base64.StdEncoding.DecodeString(sig)
 	if err != nil {
@@ -190,6 +369,31 @@ func verifySignatureWithKey(ctx context.Context, publicKey string, sig string, e
 		signatureBytes = []byte(sig)
 	}
 
+	pubKey, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(pubKeyPEM))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	if ed25519Key, ok := pubKey.(ed25519.PublicKey); ok {
+		digest := sha512.Sum512(expectedPayload)
+
+		if err := ed25519.VerifyWithOptions(ed25519Key, digest[:], signatureBytes, &ed25519.Options{Hash: crypto.SHA512}); err != nil {
+			return "", fmt.Errorf("signature verification failed: %w", err)
+		}
+
+		return pubKeyPEM, nil
+	}
+
+	hashAlgo := crypto.SHA256
+	if opts != nil && opts.HashAlgorithm != 0 {
+		hashAlgo = opts.HashAlgorithm
+	}
+
+	verifier, err := sigs.LoadPublicKeyRaw([]byte(pubKeyPEM), hashAlgo)
+	if err != nil {
+		return "", fmt.Errorf("failed to load public key: %w", err)
+	}
+
 	// Verify signature against the expected payload
 	err = verifier.VerifySignature(bytes.NewReader(signatureBytes), bytes.NewReader(expectedPayload))
 	if err != nil {
@@ -228,35 +432,89 @@ func getOIDCVerifierOptions(opts *signv1.VerifyOptionsOIDC) []verify.VerifierOpt
 
 // getOIDCTrustedMaterial returns trusted material for OIDC-based verification.
 func getOIDCTrustedMaterial(opts *signv1.VerifyOptionsOIDC) (root.TrustedMaterial, error) {
+	var (
+		trustedMaterial root.TrustedMaterial
+		err             error
+	)
+
 	switch {
 	case opts.GetTrustedRootPath() != "":
 		// Option 1: Load trusted root from file path (offline mode)
-		trustedRoot, err := root.NewTrustedRootFromPath(opts.GetTrustedRootPath())
+		trustedMaterial, err = root.NewTrustedRootFromPath(opts.GetTrustedRootPath())
 		if err != nil {
 			return nil, fmt.Errorf("failed to load trusted root from path %s: %w", opts.GetTrustedRootPath(), err)
 		}
 
-		return trustedRoot, nil
-
 	case opts.GetTufMirrorUrl() != "":
-		// Option 2: Fetch from TUF (online mode)
-		tufOpts := tuf.DefaultOptions()
-		tufOpts.RepositoryBaseURL = opts.GetTufMirrorUrl()
+		// Option 2: Fetch from TUF (online mode), via the package-level cache
+		// so repeated verifications against the same mirror reuse already
+		// fetched trusted root material instead of a full TUF round-trip.
+		staging := strings.Contains(opts.GetTufMirrorUrl(), "sigstage")
 
-		// If using staging environment, use staging TUF root
-		if strings.Contains(opts.GetTufMirrorUrl(), "sigstage") {
-			tufOpts.Root = tuf.StagingRoot()
+		trustedMaterial, err = DefaultTrustedRootCache.Get(context.Background(), opts.GetTufMirrorUrl(), staging)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch trusted root from TUF: %w", err)
 		}
 
-		trustedMaterial, err := root.FetchTrustedRootWithOptions(tufOpts)
+	default:
+		return nil, fmt.Errorf("no trusted root source specified")
+	}
+
+	// A local TSA certificate chain layers timestamp-authority trust on top
+	// of whichever Fulcio/CT material was resolved above, for air-gapped
+	// setups where the trusted root above doesn't itself carry the
+	// in-house TSA the signature was timestamped against.
+	if opts.GetTsaCertChainPath() != "" {
+		tsaAuthority, err := tsaAuthorityFromPath(opts.GetTsaCertChainPath())
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch trusted root from TUF: %w", err)
+			return nil, fmt.Errorf("failed to load TSA certificate chain from %s: %w", opts.GetTsaCertChainPath(), err)
 		}
 
-		return trustedMaterial, nil
+		return &tsaOverrideTrustedMaterial{TrustedMaterial: trustedMaterial, tsa: tsaAuthority}, nil
 	}
 
-	return nil, fmt.Errorf("no trusted root source specified")
+	return trustedMaterial, nil
+}
+
+// tsaOverrideTrustedMaterial layers a single TSA trust chain on top of a
+// base root.TrustedMaterial, so the Fulcio/CT roots it already carries can
+// be paired with timestamp authorities read from a local PEM file.
+type tsaOverrideTrustedMaterial struct {
+	root.TrustedMaterial
+	tsa root.TimestampingAuthority
+}
+
+func (t *tsaOverrideTrustedMaterial) TimestampingAuthorities() []root.TimestampingAuthority {
+	return []root.TimestampingAuthority{t.tsa}
+}
+
+// tsaAuthorityFromPath parses a PEM certificate chain (leaf first, root
+// last) from path into a root.TimestampingAuthority, for pairing with a
+// Fulcio/CT trusted root via getOIDCTrustedMaterial.
+func tsaAuthorityFromPath(path string) (root.TimestampingAuthority, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TSA certificate chain: %w", err)
+	}
+
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(pemBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TSA certificate chain: %w", err)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found in TSA certificate chain")
+	}
+
+	leaf := certs[0]
+	rootCert := certs[len(certs)-1]
+	intermediates := certs[1 : len(certs)-1]
+
+	return &root.SigstoreTimestampingAuthority{
+		Leaf:          leaf,
+		Intermediates: intermediates,
+		Root:          rootCert,
+	}, nil
 }
 
 // getValueMatcher returns a tuple of (exact, regex) matchers based on the input value.
@@ -276,10 +534,13 @@ func getValueMatcher(value string) (string, string) {
 }
 
 // detectKeyAlgorithm detects the algorithm from a PEM-encoded public key.
-// Returns algorithm name like "ECDSA-P256", "Ed25519", "RSA-2048", etc.
+// Returns algorithm name like "ECDSA-P-256", "ECDSA-P-256-SHA384", "Ed25519ph",
+// "RSA-2048", etc. opts.HashAlgorithm, when set, is appended to RSA/ECDSA
+// names; Ed25519 keys always report "Ed25519ph" since they are verified
+// pre-hashed regardless of opts.
 //
 //nolint:goconst
-func detectKeyAlgorithm(publicKeyPEM string) string {
+func detectKeyAlgorithm(publicKeyPEM string, opts *LoadOptions) string {
 	block, _ := pem.Decode([]byte(publicKeyPEM))
 	if block == nil {
 		return "unknown"
@@ -292,17 +553,29 @@ func detectKeyAlgorithm(publicKeyPEM string) string {
 
 	switch key := pubKey.(type) {
 	case *ecdsa.PublicKey:
+		name := "ECDSA"
 		if key.Curve != nil {
-			return fmt.Sprintf("ECDSA-%s", strings.ToUpper(key.Curve.Params().Name))
+			name = fmt.Sprintf("ECDSA-%s", strings.ToUpper(key.Curve.Params().Name))
 		}
 
-		return "ECDSA"
+		return appendHashSuffix(name, opts)
 	case ed25519.PublicKey:
-		return "Ed25519"
+		return "Ed25519ph"
 	case *rsa.PublicKey:
 		//nolint:mnd
-		return fmt.Sprintf("RSA-%d", key.Size()*8)
+		return appendHashSuffix(fmt.Sprintf("RSA-%d", key.Size()*8), opts)
 	default:
 		return "unknown"
 	}
 }
+
+// appendHashSuffix appends opts.HashAlgorithm's name to name (e.g.
+// "ECDSA-P-256" + SHA384 -> "ECDSA-P-256-SHA384"). Returns name unchanged when
+// opts is nil or uses the default hash.
+func appendHashSuffix(name string, opts *LoadOptions) string {
+	if opts == nil || opts.HashAlgorithm == 0 || opts.HashAlgorithm == crypto.SHA256 {
+		return name
+	}
+
+	return name + "-" + strings.ReplaceAll(strings.ToUpper(opts.HashAlgorithm.String()), "-", "")
+}
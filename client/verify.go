@@ -82,13 +82,16 @@ func (c *Client) Verify(ctx context.Context, req *signv1.VerifyRequest) (*signv1
 			signerInfo, verifyErr = cosign.VerifyWithOIDC(payload, provider.Oidc, sig)
 
 		case *signv1.VerifyRequestProvider_Key:
-			signerInfo, verifyErr = cosign.VerifyWithKeys(ctx, payload, publicKeys, sig)
+			signerInfo, verifyErr = cosign.VerifyWithKeys(ctx, payload, publicKeys, sig, nil)
+
+		case *signv1.VerifyRequestProvider_CaBundle:
+			signerInfo, verifyErr = cosign.VerifyWithCABundle(payload, provider.CaBundle, sig)
 
 		case *signv1.VerifyRequestProvider_Any:
 			// VerifyWithAny accepts any valid signature.
 			// If a signature has no bundle, it must be verified with a key.
 			if len(sig.GetContentBundle()) == 0 {
-				signerInfo, verifyErr = cosign.VerifyWithKeys(ctx, payload, publicKeys, sig)
+				signerInfo, verifyErr = cosign.VerifyWithKeys(ctx, payload, publicKeys, sig, nil)
 			} else {
 				signerInfo, verifyErr = cosign.VerifyWithOIDC(payload, &signv1.VerifyWithOIDC{
 					Options: provider.Any.GetOidcOptions().GetDefaultOptions(),
@@ -139,6 +142,8 @@ func getSignerKey(signer *signv1.SignerInfo) string {
 		return "key:" + s.Key.String()
 	case *signv1.SignerInfo_Oidc:
 		return "oidc:" + s.Oidc.String()
+	case *signv1.SignerInfo_CaBundle:
+		return "ca_bundle:" + s.CaBundle.String()
 	default:
 		return ""
 	}
@@ -4,6 +4,7 @@
 package local
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,15 +18,19 @@ import (
 // Metrics E2E Tests
 //
 // Testing Strategy:
-// - Verify Prometheus /metrics endpoint is accessible
+// - Verify Prometheus /metrics endpoint is accessible on the internal admin server
 // - Validate gRPC metrics are being collected
 // - Check that metrics contain data from previous tests (01-06)
+// - Verify the internal admin server's pprof, health, and buildinfo endpoints
 //
 // Note: This test runs AFTER other tests (numbered 07), so metrics should
 // already contain non-zero values from previous test operations.
 
 var _ = ginkgo.Describe("Prometheus Metrics", ginkgo.Serial, ginkgo.Label("metrics"), func() {
-	const metricsURL = "http://localhost:9090/metrics"
+	const (
+		adminAddress = "http://localhost:8889"
+		metricsURL   = adminAddress + "/metrics"
+	)
 
 	ginkgo.BeforeEach(func() {
 		if cfg.DeploymentMode != config.DeploymentModeLocal {
@@ -460,4 +465,56 @@ var _ = ginkgo.Describe("Prometheus Metrics", ginkgo.Serial, ginkgo.Label("metri
 				"Found metrics with negative values: %v", negativeLines)
 		})
 	})
+
+	ginkgo.Context("internal admin server", func() {
+		ginkgo.It("should expose the pprof index", func() {
+			resp, err := http.Get(adminAddress + "/debug/pprof/")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+			body, err := io.ReadAll(resp.Body)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(string(body)).To(gomega.ContainSubstring("/debug/pprof/"))
+		})
+
+		ginkgo.It("should return 200 from /healthz when SERVING", func() {
+			resp, err := http.Get(adminAddress + "/healthz")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+		})
+
+		ginkgo.It("should return 200 from /readyz when SERVING", func() {
+			resp, err := http.Get(adminAddress + "/readyz")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+		})
+
+		ginkgo.It("should return a well-formed /buildinfo JSON document", func() {
+			resp, err := http.Get(adminAddress + "/buildinfo")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			defer resp.Body.Close()
+
+			gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(resp.Header.Get("Content-Type")).To(gomega.ContainSubstring("application/json"))
+
+			var info struct {
+				Version         string   `json:"version"`
+				Commit          string   `json:"commit,omitempty"`
+				GoVersion       string   `json:"go_version"`
+				EnabledFeatures []string `json:"enabled_features"`
+			}
+
+			gomega.Expect(json.NewDecoder(resp.Body).Decode(&info)).To(gomega.Succeed())
+
+			gomega.Expect(info.Version).NotTo(gomega.BeEmpty())
+			gomega.Expect(info.GoVersion).To(gomega.ContainSubstring("go"))
+			gomega.Expect(info.EnabledFeatures).NotTo(gomega.BeNil())
+		})
+	})
 })
@@ -0,0 +1,220 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/util/teststorage"
+)
+
+// Observability E2E Tests
+//
+// Testing Strategy:
+//   - Load the shipped deploy/observability/rules.yaml with rulefmt, the same
+//     parser Prometheus itself uses, so a syntactically broken rules file
+//     fails CI rather than production.
+//   - Scrape the live /metrics endpoint (on the internal admin server) twice,
+//     a few seconds apart, and load both scrapes into an in-memory TSDB so the
+//     recording rules have two points to compute a rate() over.
+//   - Evaluate every recording rule's PromQL expression with promql.Engine
+//     and assert it produces at least one sample.
+//   - Evaluate every alert's PromQL expression and assert it is empty on a
+//     healthy, freshly-exercised cluster (the dashboards/rules shipped in
+//     deploy/observability are first-class, tested artifacts, not just
+//     decoration).
+var _ = ginkgo.Describe("Observability rules", ginkgo.Serial, ginkgo.Ordered, ginkgo.Label("metrics"), func() {
+	const scrapeInterval = 3 * time.Second
+
+	var (
+		rgs     *rulefmt.RuleGroups
+		engine  *promql.Engine
+		store   *teststorage.TestStorage
+		queryTS time.Time
+	)
+
+	ginkgo.BeforeEach(func() {
+		if cfg.DeploymentMode != config.DeploymentModeLocal {
+			ginkgo.Skip("Skipping test, not in local mode")
+		}
+	})
+
+	ginkgo.BeforeAll(func() {
+		rulesPath := rulesFilePath()
+
+		var errs []error
+		rgs, errs = rulefmt.ParseFile(rulesPath)
+		gomega.Expect(errs).To(gomega.BeEmpty(), "rules.yaml must parse cleanly: %v", errs)
+		gomega.Expect(rgs.Groups).NotTo(gomega.BeEmpty())
+
+		engine = promql.NewEngine(promql.EngineOpts{
+			Logger:     nil,
+			MaxSamples: 50_000_000,
+			Timeout:    10 * time.Second,
+		})
+
+		store = teststorage.New(ginkgo.GinkgoT())
+		ginkgo.DeferCleanup(store.Close)
+
+		// Seed the TSDB from two scrapes of the live /metrics endpoint so
+		// rate()/deriv() based expressions have a delta to compute.
+		scrapeInto(store, time.Now())
+		time.Sleep(scrapeInterval)
+		queryTS = time.Now()
+		scrapeInto(store, queryTS)
+	})
+
+	ginkgo.It("should parse deploy/observability/rules.yaml without error", func() {
+		gomega.Expect(rgs).NotTo(gomega.BeNil())
+	})
+
+	ginkgo.It("should produce samples for every recording rule", func() {
+		for _, group := range rgs.Groups {
+			for _, rule := range group.Rules {
+				if rule.Record.Value == "" {
+					continue // alert, not a recording rule
+				}
+
+				result := instantQuery(engine, store, rule.Expr.Value, queryTS)
+				gomega.Expect(result).NotTo(gomega.BeEmpty(),
+					"recording rule %q produced no samples", rule.Record.Value)
+			}
+		}
+	})
+
+	ginkgo.It("should not fire any alert on a healthy cluster", func() {
+		for _, group := range rgs.Groups {
+			for _, rule := range group.Rules {
+				if rule.Alert.Value == "" {
+					continue // recording rule, not an alert
+				}
+
+				result := instantQuery(engine, store, rule.Expr.Value, queryTS)
+				gomega.Expect(result).To(gomega.BeEmpty(),
+					"alert %q unexpectedly fired on a healthy cluster: %v", rule.Alert.Value, result)
+			}
+		}
+	})
+})
+
+// rulesFilePath locates deploy/observability/rules.yaml relative to this
+// test file, so the test works regardless of the invoking directory.
+func rulesFilePath() string {
+	_, thisFile, _, _ := runtime.Caller(0)
+
+	return filepath.Join(filepath.Dir(thisFile), "..", "..", "deploy", "observability", "rules.yaml")
+}
+
+// scrapeInto fetches the live /metrics endpoint and appends every sample to
+// store, stamped at ts.
+func scrapeInto(store *teststorage.TestStorage, ts time.Time) {
+	resp, err := http.Get("http://localhost:8889/metrics")
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	defer resp.Body.Close()
+
+	var parser expfmt.TextParser
+
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	app := store.Appender(context.Background())
+
+	for name, mf := range families {
+		for _, m := range mf.GetMetric() {
+			for _, sample := range expandMetric(name, mf.GetType(), m) {
+				_, err := app.Append(0, sample.lset, ts.UnixMilli(), sample.value)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+		}
+	}
+
+	gomega.Expect(app.Commit()).To(gomega.Succeed())
+}
+
+type labeledSample struct {
+	lset  labels.Labels
+	value float64
+}
+
+// expandMetric flattens a single Prometheus metric family sample into one
+// or more (labels, value) pairs, following the text-format conventions for
+// counters/gauges (direct value) and histograms (the _sum/_count/_bucket
+// series Prometheus itself records).
+func expandMetric(name string, mtype dto.MetricType, m *dto.Metric) []labeledSample {
+	base := labels.NewBuilder(labels.EmptyLabels())
+	for _, lp := range m.GetLabel() {
+		base.Set(lp.GetName(), lp.GetValue())
+	}
+
+	switch mtype {
+	case dto.MetricType_HISTOGRAM:
+		h := m.GetHistogram()
+		samples := []labeledSample{
+			{lset: base.Set(labels.MetricName, name+"_sum").Labels(), value: h.GetSampleSum()},
+			{lset: base.Set(labels.MetricName, name+"_count").Labels(), value: float64(h.GetSampleCount())},
+		}
+
+		for _, bucket := range h.GetBucket() {
+			bucketBuilder := base.Set(labels.MetricName, name+"_bucket")
+			bucketBuilder.Set("le", formatFloat(bucket.GetUpperBound()))
+			samples = append(samples, labeledSample{lset: bucketBuilder.Labels(), value: float64(bucket.GetCumulativeCount())})
+		}
+
+		return samples
+	default:
+		return []labeledSample{{lset: base.Set(labels.MetricName, name).Labels(), value: metricValue(mtype, m)}}
+	}
+}
+
+func metricValue(mtype dto.MetricType, m *dto.Metric) float64 {
+	switch mtype {
+	case dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue()
+	case dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue()
+	case dto.MetricType_UNTYPED:
+		return m.GetUntyped().GetValue()
+	default:
+		return 0
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// instantQuery runs expr against store at ts and returns the resulting
+// samples as a flat slice, regardless of whether the query returned a
+// vector or scalar.
+func instantQuery(engine *promql.Engine, queryable storage.Queryable, expr string, ts time.Time) promql.Vector {
+	q, err := engine.NewInstantQuery(context.Background(), queryable, nil, expr, ts)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	defer q.Close()
+
+	res := q.Exec(context.Background())
+	gomega.Expect(res.Err).NotTo(gomega.HaveOccurred())
+
+	vec, ok := res.Value.(promql.Vector)
+	if !ok {
+		return nil
+	}
+
+	return vec
+}
@@ -0,0 +1,143 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/e2e/shared/config"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	collectormetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+)
+
+// OTLP Exporter E2E Tests
+//
+// Testing Strategy:
+//   - Stand up a minimal in-process OTLP/gRPC collector stub implementing
+//     collectormetricspb.MetricsServiceServer.
+//   - Wait for the server's OTLP pipeline (server/telemetry) to push at
+//     least one export to it.
+//   - Scrape the same instant from the Prometheus /metrics endpoint on the
+//     internal admin server and assert the same metric families (by name)
+//     appear on both paths, proving the Prometheus exporter and the OTLP
+//     reader are backed by the same MeterProvider rather than diverging
+//     instrumentation.
+//
+// This test assumes the local e2e deployment's server.config.yml enables
+// the OTLP pipeline (telemetry.otlp.enabled: true) pointed at
+// 127.0.0.1:4317, which is exactly the address this test's fake collector
+// listens on.
+var _ = ginkgo.Describe("OTLP metrics exporter", ginkgo.Serial, ginkgo.Label("metrics", "otlp"), func() {
+	ginkgo.BeforeEach(func() {
+		if cfg.DeploymentMode != config.DeploymentModeLocal {
+			ginkgo.Skip("Skipping test, not in local mode")
+		}
+	})
+
+	ginkgo.It("should push the same metric families via OTLP that are scraped via Prometheus", func() {
+		collector := newFakeOTLPCollector()
+		defer collector.Stop()
+
+		gomega.Eventually(collector.ReceivedMetricNames, 30*time.Second, time.Second).ShouldNot(gomega.BeEmpty())
+
+		resp, err := http.Get("http://localhost:8889/metrics")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		defer resp.Body.Close()
+
+		gomega.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+		pushedNames := collector.ReceivedMetricNames()
+		gomega.Expect(pushedNames).NotTo(gomega.BeEmpty(), "expected at least one metric family pushed via OTLP")
+
+		body, err := io.ReadAll(resp.Body)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// The Prometheus exporter replaces "." with "_" and may append unit
+		// or "_total" suffixes, so each pushed name must appear as a prefix
+		// of a scraped family name.
+		scraped := string(body)
+		for _, name := range pushedNames {
+			gomega.Expect(scraped).To(gomega.ContainSubstring(strings.ReplaceAll(name, ".", "_")),
+				"metric %q pushed via OTLP but not scraped via Prometheus", name)
+		}
+	})
+})
+
+// fakeOTLPCollector is a minimal in-process stand-in for an OTLP/gRPC
+// collector: it accepts ExportMetricsServiceRequest calls and records the
+// metric names it has seen so the test can assert on them, without
+// depending on a real collector binary.
+type fakeOTLPCollector struct {
+	collectormetricspb.UnimplementedMetricsServiceServer
+
+	listener net.Listener
+	server   *grpc.Server
+
+	mu    sync.Mutex
+	names map[string]struct{}
+}
+
+func newFakeOTLPCollector() *fakeOTLPCollector {
+	listener, err := net.Listen("tcp", "127.0.0.1:4317")
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	c := &fakeOTLPCollector{
+		listener: listener,
+		server:   grpc.NewServer(),
+		names:    map[string]struct{}{},
+	}
+
+	collectormetricspb.RegisterMetricsServiceServer(c.server, c)
+
+	go func() {
+		_ = c.server.Serve(listener)
+	}()
+
+	return c
+}
+
+func (c *fakeOTLPCollector) Export(
+	_ context.Context,
+	req *collectormetricspb.ExportMetricsServiceRequest,
+) (*collectormetricspb.ExportMetricsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, rm := range req.GetResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				c.names[m.GetName()] = struct{}{}
+			}
+		}
+	}
+
+	return &collectormetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+// ReceivedMetricNames returns the distinct metric names observed so far.
+func (c *fakeOTLPCollector) ReceivedMetricNames() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.names))
+	for name := range c.names {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func (c *fakeOTLPCollector) Stop() {
+	c.server.GracefulStop()
+	_ = c.listener.Close()
+}
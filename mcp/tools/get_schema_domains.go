@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/agntcy/oasf-sdk/pkg/validator"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -38,13 +37,15 @@ type GetSchemaDomainsOutput struct {
 // GetSchemaDomains retrieves domains from the OASF schema for the specified version.
 // If parent_domain is provided, returns only sub-domains under that parent.
 // Otherwise, returns all top-level domains.
-func GetSchemaDomains(_ context.Context, _ *mcp.CallToolRequest, input GetSchemaDomainsInput) (
+func GetSchemaDomains(ctx context.Context, _ *mcp.CallToolRequest, input GetSchemaDomainsInput) (
 	*mcp.CallToolResult,
 	GetSchemaDomainsOutput,
 	error,
 ) {
-	// Get available schema versions from the OASF SDK
-	availableVersions, err := validator.GetAvailableSchemaVersions()
+	source := getSchemaSource()
+
+	// Get available schema versions from the configured schema source
+	availableVersions, err := source.AvailableVersions(ctx)
 	if err != nil {
 		return nil, GetSchemaDomainsOutput{
 			ErrorMessage: fmt.Sprintf("Failed to get available schema versions: %v", err),
@@ -75,8 +76,8 @@ func GetSchemaDomains(_ context.Context, _ *mcp.CallToolRequest, input GetSchema
 		}, nil
 	}
 
-	// Get domains content using the OASF SDK
-	domainsJSON, err := validator.GetSchemaDomains(input.Version)
+	// Get domains content from the configured schema source
+	domainsJSON, err := source.Schema(ctx, input.Version, SchemaKindDomains)
 	if err != nil {
 		return nil, GetSchemaDomainsOutput{
 			Version:           input.Version,
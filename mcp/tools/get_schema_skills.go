@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/agntcy/oasf-sdk/pkg/validator"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -38,13 +37,15 @@ type GetSchemaSkillsOutput struct {
 // GetSchemaSkills retrieves skills from the OASF schema for the specified version.
 // If parent_skill is provided, returns only sub-skills under that parent.
 // Otherwise, returns all top-level skills.
-func GetSchemaSkills(_ context.Context, _ *mcp.CallToolRequest, input GetSchemaSkillsInput) (
+func GetSchemaSkills(ctx context.Context, _ *mcp.CallToolRequest, input GetSchemaSkillsInput) (
 	*mcp.CallToolResult,
 	GetSchemaSkillsOutput,
 	error,
 ) {
-	// Get available schema versions from the OASF SDK
-	availableVersions, err := validator.GetAvailableSchemaVersions()
+	source := getSchemaSource()
+
+	// Get available schema versions from the configured schema source
+	availableVersions, err := source.AvailableVersions(ctx)
 	if err != nil {
 		return nil, GetSchemaSkillsOutput{
 			ErrorMessage: fmt.Sprintf("Failed to get available schema versions: %v", err),
@@ -75,8 +76,8 @@ func GetSchemaSkills(_ context.Context, _ *mcp.CallToolRequest, input GetSchemaS
 		}, nil
 	}
 
-	// Get skills content using the OASF SDK
-	skillsJSON, err := validator.GetSchemaSkills(input.Version)
+	// Get skills content from the configured schema source
+	skillsJSON, err := source.Schema(ctx, input.Version, SchemaKindSkills)
 	if err != nil {
 		return nil, GetSchemaSkillsOutput{
 			Version:           input.Version,
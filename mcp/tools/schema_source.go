@@ -0,0 +1,86 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+)
+
+// SchemaKind identifies which part of an OASF schema version to retrieve.
+type SchemaKind string
+
+const (
+	SchemaKindDomains SchemaKind = "domains"
+	SchemaKindSkills  SchemaKind = "skills"
+)
+
+// SchemaSource resolves OASF schema content for GetSchemaDomains and
+// GetSchemaSkills, decoupling those tools from any one way of obtaining
+// schemas. EmbeddedSchemaSource serves what ships with the oasf-sdk;
+// HTTPSchemaSource and OCISchemaSource let an operator point the MCP server
+// at newer OASF releases without rebuilding the binary.
+type SchemaSource interface {
+	// AvailableVersions lists the OASF schema versions this source can serve.
+	AvailableVersions(ctx context.Context) ([]string, error)
+
+	// Schema returns the raw JSON content of kind for version.
+	Schema(ctx context.Context, version string, kind SchemaKind) ([]byte, error)
+}
+
+// EmbeddedSchemaSource serves schemas bundled with the oasf-sdk at build
+// time. This is the default source and the pre-existing behavior of
+// GetSchemaDomains/GetSchemaSkills.
+type EmbeddedSchemaSource struct{}
+
+// AvailableVersions implements SchemaSource.
+func (EmbeddedSchemaSource) AvailableVersions(_ context.Context) ([]string, error) {
+	//nolint:wrapcheck
+	return validator.GetAvailableSchemaVersions()
+}
+
+// Schema implements SchemaSource.
+func (EmbeddedSchemaSource) Schema(_ context.Context, version string, kind SchemaKind) ([]byte, error) {
+	switch kind {
+	case SchemaKindDomains:
+		//nolint:wrapcheck
+		return validator.GetSchemaDomains(version)
+	case SchemaKindSkills:
+		//nolint:wrapcheck
+		return validator.GetSchemaSkills(version)
+	default:
+		return nil, fmt.Errorf("unknown schema kind %q", kind)
+	}
+}
+
+var (
+	schemaSourceMu sync.RWMutex
+	schemaSource   SchemaSource = EmbeddedSchemaSource{}
+)
+
+// SetSchemaSource replaces the package-level SchemaSource used by
+// GetSchemaDomains and GetSchemaSkills. Passing nil restores the default
+// EmbeddedSchemaSource. Operators wrap the desired source in
+// NewCachingSchemaSource to avoid re-fetching/re-verifying on every call.
+func SetSchemaSource(source SchemaSource) {
+	schemaSourceMu.Lock()
+	defer schemaSourceMu.Unlock()
+
+	if source == nil {
+		source = EmbeddedSchemaSource{}
+	}
+
+	schemaSource = source
+}
+
+// getSchemaSource returns the currently configured SchemaSource.
+func getSchemaSource() SchemaSource {
+	schemaSourceMu.RLock()
+	defer schemaSourceMu.RUnlock()
+
+	return schemaSource
+}
@@ -0,0 +1,107 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// DefaultSchemaCacheTTL is used by NewCachingSchemaSource when no TTL is
+// given. Remote schema releases change rarely, so a few minutes is enough to
+// absorb the request bursts a single MCP session tends to produce.
+const DefaultSchemaCacheTTL = 5 * time.Minute
+
+// cachedSchema holds one cached (version, kind) schema entry.
+type cachedSchema struct {
+	data    []byte
+	sha     string
+	expires time.Time
+}
+
+// cachingSchemaSource wraps a SchemaSource with a TTL cache keyed by
+// version+kind, caching each entry's content alongside its SHA-256 so a
+// fetch that returns identical bytes is recognizable as unchanged. Available
+// versions are cached as a single entry under their own key.
+type cachingSchemaSource struct {
+	source SchemaSource
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	schemas  map[string]cachedSchema
+	versions cachedVersions
+}
+
+type cachedVersions struct {
+	versions []string
+	expires  time.Time
+}
+
+// NewCachingSchemaSource wraps source with an in-memory TTL cache, so
+// repeated GetSchemaDomains/GetSchemaSkills calls for the same version don't
+// re-fetch (and, for HTTPSchemaSource, re-verify) on every call. A ttl of
+// zero uses DefaultSchemaCacheTTL.
+func NewCachingSchemaSource(source SchemaSource, ttl time.Duration) SchemaSource {
+	if ttl <= 0 {
+		ttl = DefaultSchemaCacheTTL
+	}
+
+	return &cachingSchemaSource{
+		source:  source,
+		ttl:     ttl,
+		schemas: make(map[string]cachedSchema),
+	}
+}
+
+// AvailableVersions implements SchemaSource.
+func (c *cachingSchemaSource) AvailableVersions(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.versions.versions != nil && time.Now().Before(c.versions.expires) {
+		return c.versions.versions, nil
+	}
+
+	versions, err := c.source.AvailableVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.versions = cachedVersions{versions: versions, expires: time.Now().Add(c.ttl)}
+
+	return versions, nil
+}
+
+// Schema implements SchemaSource.
+func (c *cachingSchemaSource) Schema(ctx context.Context, version string, kind SchemaKind) ([]byte, error) {
+	key := version + ":" + string(kind)
+
+	c.mu.Lock()
+	entry, ok := c.schemas[key]
+	c.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.data, nil
+	}
+
+	data, err := c.source.Schema(ctx, version, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+
+	c.mu.Lock()
+	c.schemas[key] = cachedSchema{
+		data:    data,
+		sha:     hex.EncodeToString(sum[:]),
+		expires: time.Now().Add(c.ttl),
+	}
+	c.mu.Unlock()
+
+	return data, nil
+}
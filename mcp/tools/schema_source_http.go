@@ -0,0 +1,111 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/agntcy/dir/utils/cosign"
+)
+
+// HTTPSchemaSource fetches versioned OASF schemas from a remote HTTP
+// endpoint, verifying each one against a Sigstore bundle served alongside
+// it before returning its content - so pointing the MCP server at a newer
+// OASF release doesn't mean trusting an arbitrary HTTP response.
+//
+// For a requested version and kind, HTTPSchemaSource expects:
+//   - BaseURL + "/" + version + "/" + kind + ".json"        (schema content)
+//   - BaseURL + "/" + version + "/" + kind + ".json.sigstore" (bundle JSON)
+//
+// and BaseURL + "/versions.json" (a JSON array of available version
+// strings) for AvailableVersions.
+type HTTPSchemaSource struct {
+	// BaseURL is the root the schema and bundle files above are resolved
+	// against, e.g. "https://schema.oasf.outshift.com".
+	BaseURL string
+
+	// VerifyOptions is used as a template for verifying each fetched
+	// schema's Sigstore bundle: BundleJSON and ExpectedPayload are
+	// overwritten per fetch, every other field (TrustPolicyStore,
+	// ExpectedIssuer/Identity, TrustRoot, ...) is taken from here as-is.
+	VerifyOptions cosign.VerifyOIDCOptions
+
+	// HTTPClient overrides the HTTP client used to reach BaseURL. Defaults
+	// to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// AvailableVersions implements SchemaSource.
+func (s *HTTPSchemaSource) AvailableVersions(ctx context.Context) ([]string, error) {
+	body, err := s.get(ctx, s.BaseURL+"/versions.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch available schema versions: %w", err)
+	}
+
+	var versions []string
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, fmt.Errorf("failed to parse available schema versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// Schema implements SchemaSource.
+func (s *HTTPSchemaSource) Schema(ctx context.Context, version string, kind SchemaKind) ([]byte, error) {
+	base := fmt.Sprintf("%s/%s/%s.json", s.BaseURL, version, kind)
+
+	data, err := s.get(ctx, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s schema for version %s: %w", kind, version, err)
+	}
+
+	bundleJSON, err := s.get(ctx, base+".sigstore")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signature bundle for %s schema version %s: %w", kind, version, err)
+	}
+
+	verifyOpts := s.VerifyOptions
+	verifyOpts.BundleJSON = string(bundleJSON)
+	verifyOpts.ExpectedPayload = data
+
+	if _, err := cosign.VerifySignatureWithOIDC(ctx, &verifyOpts); err != nil {
+		return nil, fmt.Errorf("signature verification failed for %s schema version %s: %w", kind, version, err)
+	}
+
+	return data, nil
+}
+
+// get performs an HTTP GET against url and returns the response body.
+func (s *HTTPSchemaSource) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,160 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+// Media types used for the two layers an OASF schema version's OCI artifact
+// carries - one layer per SchemaKind.
+const (
+	mediaTypeSchemaDomains = "application/vnd.oasf.schema.domains+json"
+	mediaTypeSchemaSkills  = "application/vnd.oasf.schema.skills+json"
+)
+
+func mediaTypeForKind(kind SchemaKind) (string, error) {
+	switch kind {
+	case SchemaKindDomains:
+		return mediaTypeSchemaDomains, nil
+	case SchemaKindSkills:
+		return mediaTypeSchemaSkills, nil
+	default:
+		return "", fmt.Errorf("unknown schema kind %q", kind)
+	}
+}
+
+// OCISchemaSource pulls OASF schemas as OCI artifacts, one version per tag,
+// from any OCI-compliant registry (GHCR, ECR, ACR, GCR, a private Zot/
+// Distribution instance, ...) - the same cloud-agnostic registry access
+// pattern server/store/oci uses for records, reused here so schemas can be
+// distributed and pulled the same way.
+type OCISchemaSource struct {
+	// RegistryAddress is the registry host, e.g. "ghcr.io".
+	RegistryAddress string
+
+	// RepositoryName is the repository within the registry that holds
+	// tagged schema artifacts, e.g. "agntcy/oasf-schemas".
+	RepositoryName string
+
+	// Username, Password, RefreshToken, and AccessToken configure registry
+	// authentication. Leave all empty for anonymous pulls.
+	Username     string
+	Password     string
+	RefreshToken string
+	AccessToken  string
+
+	// Insecure allows plain HTTP, for local/test registries.
+	Insecure bool
+}
+
+func (s *OCISchemaSource) repository() (*remote.Repository, error) {
+	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", s.RegistryAddress, s.RepositoryName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote repo: %w", err)
+	}
+
+	repo.PlainHTTP = s.Insecure
+	repo.Client = &auth.Client{
+		Client: retry.DefaultClient,
+		Header: http.Header{
+			"User-Agent": {"dir-mcp"},
+		},
+		Cache: auth.DefaultCache,
+		Credential: auth.StaticCredential(s.RegistryAddress, auth.Credential{
+			Username:     s.Username,
+			Password:     s.Password,
+			RefreshToken: s.RefreshToken,
+			AccessToken:  s.AccessToken,
+		}),
+	}
+
+	return repo, nil
+}
+
+// AvailableVersions implements SchemaSource by listing the repository's tags
+// - each tag is a schema version.
+func (s *OCISchemaSource) AvailableVersions(ctx context.Context) ([]string, error) {
+	repo, err := s.repository()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+
+	if err := repo.Tags(ctx, "", func(tags []string) error {
+		versions = append(versions, tags...)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list schema versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// Schema implements SchemaSource by resolving the manifest tagged version,
+// then fetching the layer whose media type matches kind.
+func (s *OCISchemaSource) Schema(ctx context.Context, version string, kind SchemaKind) ([]byte, error) {
+	mediaType, err := mediaTypeForKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := s.repository()
+	if err != nil {
+		return nil, err
+	}
+
+	manifestDesc, err := repo.Resolve(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve schema version %s: %w", version, err)
+	}
+
+	manifestReader, err := repo.Fetch(ctx, manifestDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest for schema version %s: %w", version, err)
+	}
+	defer manifestReader.Close()
+
+	manifestBytes, err := io.ReadAll(manifestReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest for schema version %s: %w", version, err)
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest for schema version %s: %w", version, err)
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != mediaType {
+			continue
+		}
+
+		layerReader, err := repo.Fetch(ctx, layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s layer for schema version %s: %w", kind, version, err)
+		}
+		defer layerReader.Close()
+
+		data, err := io.ReadAll(layerReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s layer for schema version %s: %w", kind, version, err)
+		}
+
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("schema version %s has no %s layer", version, kind)
+}
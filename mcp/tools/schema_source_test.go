@@ -0,0 +1,135 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSchemaSource counts calls so cachingSchemaSource's TTL behavior can be
+// asserted without a real backend.
+type fakeSchemaSource struct {
+	versionCalls int
+	schemaCalls  int
+	err          error
+}
+
+func (f *fakeSchemaSource) AvailableVersions(context.Context) ([]string, error) {
+	f.versionCalls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return []string{"0.7.0"}, nil
+}
+
+func (f *fakeSchemaSource) Schema(_ context.Context, version string, kind SchemaKind) ([]byte, error) {
+	f.schemaCalls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return []byte(version + ":" + string(kind)), nil
+}
+
+func TestCachingSchemaSource_CachesWithinTTL(t *testing.T) {
+	fake := &fakeSchemaSource{}
+	cached := NewCachingSchemaSource(fake, time.Minute)
+	ctx := context.Background()
+
+	if _, err := cached.AvailableVersions(ctx); err != nil {
+		t.Fatalf("AvailableVersions failed: %v", err)
+	}
+
+	if _, err := cached.AvailableVersions(ctx); err != nil {
+		t.Fatalf("AvailableVersions failed: %v", err)
+	}
+
+	if fake.versionCalls != 1 {
+		t.Errorf("expected 1 underlying call, got %d", fake.versionCalls)
+	}
+
+	if _, err := cached.Schema(ctx, "0.7.0", SchemaKindDomains); err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	if _, err := cached.Schema(ctx, "0.7.0", SchemaKindDomains); err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	if fake.schemaCalls != 1 {
+		t.Errorf("expected 1 underlying call, got %d", fake.schemaCalls)
+	}
+
+	// A different kind is a different cache key.
+	if _, err := cached.Schema(ctx, "0.7.0", SchemaKindSkills); err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	if fake.schemaCalls != 2 {
+		t.Errorf("expected 2 underlying calls after a different kind, got %d", fake.schemaCalls)
+	}
+}
+
+func TestCachingSchemaSource_RefetchesAfterExpiry(t *testing.T) {
+	fake := &fakeSchemaSource{}
+	cached := NewCachingSchemaSource(fake, time.Nanosecond)
+	ctx := context.Background()
+
+	if _, err := cached.Schema(ctx, "0.7.0", SchemaKindDomains); err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+
+	if _, err := cached.Schema(ctx, "0.7.0", SchemaKindDomains); err != nil {
+		t.Fatalf("Schema failed: %v", err)
+	}
+
+	if fake.schemaCalls != 2 {
+		t.Errorf("expected the expired entry to be refetched, got %d calls", fake.schemaCalls)
+	}
+}
+
+func TestCachingSchemaSource_PropagatesErrors(t *testing.T) {
+	fake := &fakeSchemaSource{err: errors.New("boom")}
+	cached := NewCachingSchemaSource(fake, time.Minute)
+
+	if _, err := cached.Schema(context.Background(), "0.7.0", SchemaKindDomains); err == nil {
+		t.Fatal("expected error to propagate")
+	}
+}
+
+func TestSetSchemaSource_NilRestoresEmbedded(t *testing.T) {
+	t.Cleanup(func() { SetSchemaSource(nil) })
+
+	SetSchemaSource(&fakeSchemaSource{})
+
+	if _, ok := getSchemaSource().(*fakeSchemaSource); !ok {
+		t.Fatal("expected the fake source to be active")
+	}
+
+	SetSchemaSource(nil)
+
+	if _, ok := getSchemaSource().(EmbeddedSchemaSource); !ok {
+		t.Fatal("expected SetSchemaSource(nil) to restore EmbeddedSchemaSource")
+	}
+}
+
+func TestMediaTypeForKind(t *testing.T) {
+	if mt, err := mediaTypeForKind(SchemaKindDomains); err != nil || mt != mediaTypeSchemaDomains {
+		t.Errorf("SchemaKindDomains: got (%q, %v)", mt, err)
+	}
+
+	if mt, err := mediaTypeForKind(SchemaKindSkills); err != nil || mt != mediaTypeSchemaSkills {
+		t.Errorf("SchemaKindSkills: got (%q, %v)", mt, err)
+	}
+
+	if _, err := mediaTypeForKind(SchemaKind("bogus")); err == nil {
+		t.Error("expected an error for an unknown schema kind")
+	}
+}
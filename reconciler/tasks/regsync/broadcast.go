@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package regsync
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// DefaultEventBufferSize is the per-watcher buffer used by EventBroadcaster.
+// A slow watcher drops events past this depth rather than blocking the worker.
+const DefaultEventBufferSize = 32
+
+// eventSubscription is a single watcher attached to one sync ID.
+type eventSubscription struct {
+	id string
+	ch chan SyncEvent
+}
+
+// EventBroadcaster fans SyncEvents for a sync out to any number of watchers,
+// keyed by sync ID, without letting a slow or absent watcher block the
+// worker that is publishing progress. It mirrors server/events.EventBus's
+// pub/sub shape, scoped to a single sync ID instead of event-type filters.
+type EventBroadcaster struct {
+	mu   sync.RWMutex
+	subs map[string]map[string]*eventSubscription
+}
+
+// NewEventBroadcaster creates an empty EventBroadcaster.
+func NewEventBroadcaster() *EventBroadcaster {
+	return &EventBroadcaster{
+		subs: make(map[string]map[string]*eventSubscription),
+	}
+}
+
+// DefaultBroadcaster is the process-wide broadcaster shared between regsync
+// workers and the SyncService RPC handler that serves watchers.
+var DefaultBroadcaster = NewEventBroadcaster() //nolint:gochecknoglobals
+
+// Subscribe attaches a new watcher to syncID and returns its subscription ID
+// (for Unsubscribe) and the channel it will receive events on.
+func (b *EventBroadcaster) Subscribe(syncID string) (string, <-chan SyncEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := uuid.New().String()
+	sub := &eventSubscription{id: id, ch: make(chan SyncEvent, DefaultEventBufferSize)}
+
+	if b.subs[syncID] == nil {
+		b.subs[syncID] = make(map[string]*eventSubscription)
+	}
+
+	b.subs[syncID][id] = sub
+
+	return id, sub.ch
+}
+
+// Unsubscribe detaches and closes a watcher's channel. Safe to call more
+// than once, or with an ID that no longer exists.
+func (b *EventBroadcaster) Unsubscribe(syncID, id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs, ok := b.subs[syncID]
+	if !ok {
+		return
+	}
+
+	if sub, ok := subs[id]; ok {
+		close(sub.ch)
+		delete(subs, id)
+	}
+
+	if len(subs) == 0 {
+		delete(b.subs, syncID)
+	}
+}
+
+// Publish delivers event to every watcher currently attached to its SyncID.
+// Publish never blocks: a watcher whose buffer is full drops the event.
+// A nil *EventBroadcaster is a valid no-op publisher, so workers running
+// without a broadcaster don't need to guard every call site.
+func (b *EventBroadcaster) Publish(event SyncEvent) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subs[event.SyncID] {
+		select {
+		case sub.ch <- event:
+		default:
+			logger.Warn("Dropped sync progress event due to slow watcher", "sync_id", event.SyncID, "kind", event.Kind)
+		}
+	}
+}
@@ -46,6 +46,46 @@ type Config struct {
 
 	// Authn holds authentication configuration for connecting to remote Directory nodes.
 	Authn authn.Config `json:"authn" mapstructure:"authn"`
+
+	// SyncPolicy controls the signature verification required before a
+	// synced record is published into the local registry.
+	SyncPolicy SyncPolicy `json:"sync_policy" mapstructure:"sync_policy"`
+}
+
+// SyncPolicy mirrors the verification options accepted by `dirctl verify`
+// (cli/cmd/verify), applied to every record mirrored in by regsync.
+type SyncPolicy struct {
+	// Enabled gates whether synced records must pass signature verification
+	// before they are kept in the local registry. Records are mirrored
+	// unverified when this is false.
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Key is a path to a public key to verify against. If set, key-based
+	// verification is used instead of OIDC.
+	Key string `json:"key,omitempty" mapstructure:"key"`
+
+	// OIDCIssuer is the expected OIDC issuer to verify against.
+	OIDCIssuer string `json:"oidc_issuer,omitempty" mapstructure:"oidc_issuer"`
+
+	// OIDCSubject is the expected OIDC subject/identity to verify against.
+	OIDCSubject string `json:"oidc_subject,omitempty" mapstructure:"oidc_subject"`
+
+	// TrustedRootPath is a path to a Sigstore TrustedRoot JSON file for
+	// offline/air-gapped verification. Reserved for parity with
+	// `dirctl verify --trusted-root-path`; not yet consumed.
+	TrustedRootPath string `json:"trusted_root_path,omitempty" mapstructure:"trusted_root_path"`
+
+	// IgnoreTlog mirrors `dirctl verify --ignore-tlog`. It is not read by
+	// verifyAgainstPolicy; transparency log checks run as for any OIDC bundle.
+	IgnoreTlog bool `json:"ignore_tlog,omitempty" mapstructure:"ignore_tlog"`
+
+	// IgnoreTsa mirrors `dirctl verify --ignore-tsa`. It is not read by
+	// verifyAgainstPolicy; timestamp authority checks run unchanged.
+	IgnoreTsa bool `json:"ignore_tsa,omitempty" mapstructure:"ignore_tsa"`
+
+	// IgnoreSct mirrors `dirctl verify --ignore-sct`. It is not read by
+	// verifyAgainstPolicy; SCT checks run unchanged.
+	IgnoreSct bool `json:"ignore_sct,omitempty" mapstructure:"ignore_sct"`
 }
 
 // GetInterval returns the interval with default fallback.
@@ -0,0 +1,48 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package regsync
+
+// SyncEventKind identifies the stage a SyncEvent reports on.
+type SyncEventKind string
+
+const (
+	// SyncEventStarted is emitted once a worker begins processing a sync.
+	SyncEventStarted SyncEventKind = "started"
+
+	// SyncEventBlobProgress reports copy progress for a single CID.
+	SyncEventBlobProgress SyncEventKind = "blob_progress"
+
+	// SyncEventVerified is emitted once a CID passes the sync policy's
+	// signature verification.
+	SyncEventVerified SyncEventKind = "verified"
+
+	// SyncEventCompleted is emitted once a CID, or the whole sync, finishes
+	// successfully.
+	SyncEventCompleted SyncEventKind = "completed"
+
+	// SyncEventFailed is emitted when a CID, or the whole sync, fails.
+	SyncEventFailed SyncEventKind = "failed"
+)
+
+// SyncEvent reports progress for a single sync operation so watchers (e.g.
+// `dirctl sync watch`) can render it without polling GetSync.
+type SyncEvent struct {
+	// SyncID identifies the sync this event belongs to.
+	SyncID string
+
+	// CID is the record this event concerns. Empty for sync-wide events
+	// (e.g. the final SyncEventCompleted/SyncEventFailed for the whole run).
+	CID string
+
+	// Kind identifies the stage being reported.
+	Kind SyncEventKind
+
+	// BytesDone and BytesTotal describe copy progress for
+	// SyncEventBlobProgress. Both are zero for other kinds.
+	BytesDone  int64
+	BytesTotal int64
+
+	// Reason carries the failure message for SyncEventFailed.
+	Reason string
+}
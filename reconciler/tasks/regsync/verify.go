@@ -0,0 +1,150 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package regsync
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	"github.com/agntcy/dir/server/store/oci"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/cosign"
+)
+
+// RejectedUnverifiedError indicates a synced record failed signature
+// verification under the configured SyncPolicy. It is a distinct error
+// class from transient sync failures: the task layer should mark the sync
+// as rejected rather than retry it, and the record has already been rolled
+// back out of the local registry.
+type RejectedUnverifiedError struct {
+	CID    string
+	Reason string
+}
+
+func (e *RejectedUnverifiedError) Error() string {
+	return fmt.Sprintf("record %s rejected by sync policy: %s", e.CID, e.Reason)
+}
+
+// IsRejectedUnverified reports whether err is (or wraps) a
+// RejectedUnverifiedError.
+func IsRejectedUnverified(err error) bool {
+	var rejected *RejectedUnverifiedError
+
+	return errors.As(err, &rejected)
+}
+
+// verifyRecord checks cid, which has already been copied into the local
+// registry, against w.config.SyncPolicy. It reuses the same cosign
+// primitives as the server's signing package and `dirctl verify`.
+func (w *Worker) verifyRecord(ctx context.Context, cid string) error {
+	policy := w.config.SyncPolicy
+	if !policy.Enabled {
+		return nil
+	}
+
+	store, err := oci.New(w.localRegistry)
+	if err != nil {
+		return fmt.Errorf("failed to open local registry for verification: %w", err)
+	}
+
+	referrerStore, ok := store.(types.ReferrerStoreAPI)
+	if !ok {
+		return &RejectedUnverifiedError{CID: cid, Reason: "local registry does not support referrer lookup"}
+	}
+
+	var signatures []*signv1.Signature
+
+	err = referrerStore.WalkReferrers(ctx, cid, corev1.SignatureReferrerType, func(referrer *corev1.RecordReferrer) error {
+		sig := &signv1.Signature{}
+		if err := sig.UnmarshalReferrer(referrer); err != nil {
+			return nil //nolint:nilerr
+		}
+
+		signatures = append(signatures, sig)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pull signature referrers for %s: %w", cid, err)
+	}
+
+	if len(signatures) == 0 {
+		return &RejectedUnverifiedError{CID: cid, Reason: "no signatures found"}
+	}
+
+	dgst, err := corev1.ConvertCIDToDigest(cid)
+	if err != nil {
+		return fmt.Errorf("invalid CID %s: %w", cid, err)
+	}
+
+	expectedPayload, err := cosign.GenerateExpectedPayload(dgst.String())
+	if err != nil {
+		return fmt.Errorf("failed to generate expected payload for %s: %w", cid, err)
+	}
+
+	for _, sig := range signatures {
+		verified, err := verifyAgainstPolicy(ctx, policy, sig, expectedPayload)
+		if err != nil {
+			logger.Debug("Signature verification attempt failed", "sync_id", w.syncID, "cid", cid, "error", err)
+
+			continue
+		}
+
+		if verified {
+			return nil
+		}
+	}
+
+	return &RejectedUnverifiedError{CID: cid, Reason: "no signature matched the configured sync policy"}
+}
+
+// verifyAgainstPolicy verifies a single signature against policy: key-based
+// when policy.Key is set, otherwise OIDC verification of the signature's
+// bundle.
+func verifyAgainstPolicy(ctx context.Context, policy SyncPolicy, sig *signv1.Signature, expectedPayload []byte) (bool, error) {
+	if sig.GetContentBundle() != "" && policy.Key == "" {
+		bundleBytes, err := base64.StdEncoding.DecodeString(sig.GetContentBundle())
+		if err != nil {
+			return false, fmt.Errorf("failed to decode bundle: %w", err)
+		}
+
+		result, err := cosign.VerifySignatureWithOIDC(ctx, &cosign.VerifyOIDCOptions{
+			BundleJSON:       string(bundleBytes),
+			ExpectedPayload:  expectedPayload,
+			ExpectedIssuer:   policy.OIDCIssuer,
+			ExpectedIdentity: policy.OIDCSubject,
+		})
+		if err != nil {
+			return false, fmt.Errorf("OIDC verification failed: %w", err)
+		}
+
+		return result.Verified, nil
+	}
+
+	if policy.Key == "" {
+		return false, nil
+	}
+
+	publicKey, err := os.ReadFile(filepath.Clean(policy.Key))
+	if err != nil {
+		return false, fmt.Errorf("failed to read verification key: %w", err)
+	}
+
+	verified, err := cosign.VerifySignatures(&cosign.VerifySignaturesOptions{
+		ExpectedPayload: expectedPayload,
+		Signatures:      []string{sig.GetSignature()},
+		PublicKeys:      []string{string(publicKey)},
+	})
+	if err != nil {
+		return false, fmt.Errorf("key verification failed: %w", err)
+	}
+
+	return verified, nil
+}
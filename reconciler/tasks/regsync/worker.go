@@ -4,15 +4,22 @@
 package regsync
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
+	"net"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/store/oci"
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	serversync "github.com/agntcy/dir/server/sync"
 	"github.com/agntcy/dir/server/types"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/errcode"
 )
 
 // Worker processes a single sync request atomically.
@@ -20,20 +27,28 @@ import (
 // sync operations don't interfere with each other.
 // Worker does not have direct database access - it reports results
 // back to the task which handles database updates.
+//
+// NOTE: the production sync task (reconciler/tasks/regsync/task.go) still
+// runs its own, separate exec-based sync loop and does not yet construct
+// workers through NewWorker, so events published here only reach watchers
+// once the task is switched over to this worker.
 type Worker struct {
 	config        Config
 	localRegistry ociconfig.Config
 	syncID        string
 	syncObj       types.SyncObject
+	events        *EventBroadcaster
 }
 
-// NewWorker creates a new worker to process a single sync request.
-func NewWorker(config Config, localRegistry ociconfig.Config, syncObj types.SyncObject) *Worker {
+// NewWorker creates a new worker to process a single sync request. events
+// may be nil, in which case progress is not published anywhere.
+func NewWorker(config Config, localRegistry ociconfig.Config, syncObj types.SyncObject, events *EventBroadcaster) *Worker {
 	return &Worker{
 		config:        config,
 		localRegistry: localRegistry,
 		syncID:        syncObj.GetID(),
 		syncObj:       syncObj,
+		events:        events,
 	}
 }
 
@@ -45,8 +60,10 @@ func (w *Worker) Run(ctx context.Context) error {
 
 	logger.Info("Executing sync", "sync_id", w.syncID, "remote_directory", remoteDirectoryURL)
 
+	w.events.Publish(SyncEvent{SyncID: w.syncID, Kind: SyncEventStarted})
+
 	// Negotiate credentials with the remote Directory node
-	credentials, err := NegotiateCredentials(ctx, remoteDirectoryURL, w.config.Authn)
+	credentials, err := serversync.NegotiateCredentials(ctx, remoteDirectoryURL, w.config.Authn)
 	if err != nil {
 		return fmt.Errorf("failed to negotiate credentials: %w", err)
 	}
@@ -57,101 +74,120 @@ func (w *Worker) Run(ctx context.Context) error {
 		"repository", credentials.RepositoryName,
 	)
 
-	// Create isolated regsync config for this worker
-	regsyncConfig := NewRegsyncConfig()
+	// Connect to the remote Directory node's OCI registry as the copy source.
+	srcRepo, err := oci.NewORASRepository(ociconfig.Config{
+		RegistryAddress: credentials.RegistryAddress,
+		RepositoryName:  credentials.RepositoryName,
+		Insecure:        credentials.Credentials.Insecure,
+		Username:        credentials.Credentials.Username,
+		Password:        credentials.Credentials.Password,
+	})
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to connect to remote registry %s: %v", credentials.RegistryAddress, err)
+	}
 
-	// Add local registry credential
-	regsyncConfig.AddCredential(
-		w.localRegistry.RegistryAddress,
-		w.localRegistry.Username,
-		w.localRegistry.Password,
-		w.localRegistry.Insecure,
-	)
+	// Connect to the local registry as the copy destination.
+	dstRepo, err := oci.NewORASRepository(w.localRegistry)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to connect to local registry %s: %v", w.localRegistry.RegistryAddress, err)
+	}
 
-	// Add credentials for the remote registry
-	regsyncConfig.AddCredential(
-		credentials.RegistryAddress,
-		credentials.Credentials.Username,
-		credentials.Credentials.Password,
-		credentials.Credentials.Insecure,
-	)
+	timeout := w.config.GetTimeout()
 
-	// Configure the sync entry
-	regsyncConfig.AddSync(
-		credentials.FullRepositoryURL(),
-		w.localRegistry.GetRepositoryURL(),
-		w.syncObj.GetCIDs(),
-	)
+	syncCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// Create config file
-	configPath, err := regsyncConfig.WriteToFile(w.syncID)
-	if err != nil {
-		return fmt.Errorf("failed to create temp config file: %w", err)
-	}
-	defer os.Remove(configPath) // Ensure config file is cleaned up after execution
+	cids := w.syncObj.GetCIDs()
 
-	// Run the regsync command with the generated config
-	logger.Info("Running regsync command",
+	logger.Info("Copying synced objects",
 		"sync_id", w.syncID,
 		"source", credentials.FullRepositoryURL(),
 		"target", w.localRegistry.GetRepositoryURL(),
-		"config_path", configPath,
+		"count", len(cids),
 	)
 
-	// Run the regsync binary
-	if err := w.runRegsync(ctx, configPath); err != nil {
-		return fmt.Errorf("regsync command failed: %w", err)
-	}
+	for _, cid := range cids {
+		if err := syncCtx.Err(); err != nil {
+			return fmt.Errorf("sync canceled: %w", err)
+		}
 
-	return nil
-}
+		desc, err := w.copyObject(syncCtx, srcRepo, dstRepo, cid)
+		if err != nil {
+			w.events.Publish(SyncEvent{SyncID: w.syncID, CID: cid, Kind: SyncEventFailed, Reason: err.Error()})
 
-// runRegsync executes the regsync binary with the worker's configuration.
-func (w *Worker) runRegsync(ctx context.Context, configPath string) error {
-	// Create a context with timeout
-	timeout := w.config.GetTimeout()
+			return fmt.Errorf("failed to sync object %s: %w", cid, err)
+		}
 
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+		w.events.Publish(SyncEvent{SyncID: w.syncID, CID: cid, Kind: SyncEventBlobProgress, BytesDone: desc.Size, BytesTotal: desc.Size})
 
-	// Build the command: regsync once -c <config_path>
-	binaryPath := w.config.GetBinaryPath()
+		if err := w.verifyRecord(syncCtx, cid); err != nil {
+			if delErr := dstRepo.Delete(syncCtx, desc); delErr != nil {
+				logger.Error("Failed to roll back unverified record", "sync_id", w.syncID, "cid", cid, "error", delErr)
+			}
 
-	//nolint:gosec // Binary path is from trusted configuration
-	cmd := exec.CommandContext(execCtx, binaryPath, "once", "-c", configPath)
+			w.events.Publish(SyncEvent{SyncID: w.syncID, CID: cid, Kind: SyncEventFailed, Reason: err.Error()})
 
-	// Capture stdout and stderr
-	var stdout, stderr bytes.Buffer
+			return fmt.Errorf("failed to verify synced object %s: %w", cid, err)
+		}
 
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+		if w.config.SyncPolicy.Enabled {
+			w.events.Publish(SyncEvent{SyncID: w.syncID, CID: cid, Kind: SyncEventVerified})
+		}
 
-	logger.Debug("Executing regsync command",
-		"sync_id", w.syncID,
-		"command", cmd.String(),
-		"timeout", timeout,
-	)
+		w.events.Publish(SyncEvent{SyncID: w.syncID, CID: cid, Kind: SyncEventCompleted})
+	}
 
-	// Run the command
-	err := cmd.Run()
+	logger.Info("Sync completed", "sync_id", w.syncID, "count", len(cids))
 
-	// Log output regardless of success/failure
-	if stdout.Len() > 0 {
-		logger.Debug("regsync stdout", "sync_id", w.syncID, "output", stdout.String())
-	}
+	w.events.Publish(SyncEvent{SyncID: w.syncID, Kind: SyncEventCompleted})
 
-	if stderr.Len() > 0 {
-		logger.Debug("regsync stderr", "sync_id", w.syncID, "output", stderr.String())
+	return nil
+}
+
+// copyObject copies a single CID's manifest, everything it references, and
+// everything that references it (signatures, public keys, verification
+// bundles) from src to dst. Using ExtendedCopy instead of plain Copy is what
+// makes a synced mirror self-contained: a record synced into an air-gapped
+// registry keeps its referrers, so it stays verifiable without reaching back
+// to the source registry.
+func (w *Worker) copyObject(ctx context.Context, src, dst oras.Target, cid string) (ocispec.Descriptor, error) {
+	dgst, err := corev1.ConvertCIDToDigest(cid)
+	if err != nil {
+		return ocispec.Descriptor{}, status.Errorf(codes.InvalidArgument, "invalid CID %s: %v", cid, err)
 	}
 
+	desc, err := oras.ExtendedCopy(ctx, src, dgst.String(), dst, dgst.String(), oras.DefaultExtendedCopyOptions)
 	if err != nil {
-		// Check if it was a timeout
-		if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
-			return fmt.Errorf("regsync command timed out after %v", timeout)
+		return ocispec.Descriptor{}, classifyCopyError(dgst.String(), err)
+	}
+
+	return desc, nil
+}
+
+// classifyCopyError maps an oras.Copy failure to a structured gRPC status
+// so callers can distinguish auth, not-found, and network failures instead
+// of pattern-matching a regsync binary's stderr.
+func classifyCopyError(ref string, err error) error {
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) {
+		switch errResp.StatusCode {
+		case 401, 403: //nolint:mnd
+			return status.Errorf(codes.Unauthenticated, "not authorized to copy %s: %v", ref, err)
+		case 404: //nolint:mnd
+			return status.Errorf(codes.NotFound, "object %s not found on source registry: %v", ref, err)
 		}
 
-		return fmt.Errorf("regsync command failed: %w, stderr: %s", err, stderr.String())
+		return status.Errorf(codes.Unavailable, "registry returned an error copying %s: %v", ref, err)
 	}
 
-	return nil
+	if errors.Is(err, errdef.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "object %s not found on source registry: %v", ref, err)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return status.Errorf(codes.Unavailable, "network error copying %s: %v", ref, err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to copy %s: %v", ref, err)
 }
@@ -225,6 +225,7 @@ func (t *Task) verifySignature(ctx context.Context, recordCID string, item sigWi
 			payload,
 			publicKeys,
 			item.sig,
+			nil,
 		)
 	} else {
 		info, err = cosign.VerifyWithOIDC(
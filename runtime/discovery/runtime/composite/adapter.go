@@ -0,0 +1,221 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package composite provides a RuntimeAdapter that fans out discovery to
+// multiple underlying adapters (e.g. Docker, Kubernetes, Nomad) and merges
+// their results, so a hybrid deployment can be represented as a single
+// runtime in config.Config.
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	runtimev1 "github.com/agntcy/dir/runtime/api/runtime/v1"
+	"github.com/agntcy/dir/runtime/discovery/types"
+)
+
+const RuntimeType types.RuntimeType = "composite"
+
+// adapter fans discovery out to multiple underlying adapters and merges
+// their workload streams, deduplicating by workload ID.
+type adapter struct {
+	adapters []types.RuntimeAdapter
+}
+
+// NewAdapter creates a composite adapter over the given underlying adapters.
+// Building the underlying adapters from config.Config is the caller's
+// responsibility (see runtime.NewAdapter), so this package has no
+// dependency on the config package.
+func NewAdapter(adapters []types.RuntimeAdapter) (types.RuntimeAdapter, error) {
+	if len(adapters) == 0 {
+		return nil, errors.New("composite runtime requires at least one underlying runtime")
+	}
+
+	return &adapter{adapters: adapters}, nil
+}
+
+// Type returns the composite runtime type.
+func (a *adapter) Type() types.RuntimeType {
+	return RuntimeType
+}
+
+// Close closes all underlying adapters, joining any errors.
+func (a *adapter) Close() error {
+	var errs []error
+
+	for _, sub := range a.adapters {
+		if err := sub.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.Type(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ListWorkloads queries all underlying adapters concurrently and merges the
+// results, keeping the first workload seen for any duplicate ID.
+func (a *adapter) ListWorkloads(ctx context.Context) ([]*runtimev1.Workload, error) {
+	type result struct {
+		workloads []*runtimev1.Workload
+		err       error
+	}
+
+	results := make([]result, len(a.adapters))
+
+	var wg sync.WaitGroup
+
+	for i, sub := range a.adapters {
+		wg.Add(1)
+
+		go func(i int, sub types.RuntimeAdapter) {
+			defer wg.Done()
+
+			workloads, err := sub.ListWorkloads(ctx)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", sub.Type(), err)
+			}
+
+			results[i] = result{workloads: workloads, err: err}
+		}(i, sub)
+	}
+
+	wg.Wait()
+
+	var (
+		merged []*runtimev1.Workload
+		errs   []error
+	)
+
+	seen := make(map[string]struct{})
+
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, res.err)
+
+			continue
+		}
+
+		for _, w := range res.workloads {
+			if _, ok := seen[w.GetId()]; ok {
+				continue
+			}
+
+			seen[w.GetId()] = struct{}{}
+
+			merged = append(merged, w)
+		}
+	}
+
+	if len(errs) == len(a.adapters) {
+		return nil, errors.Join(errs...)
+	}
+
+	return merged, nil
+}
+
+// WatchEvents watches all underlying adapters concurrently, forwarding
+// Added/Modified events for workload IDs not yet seen from another adapter
+// and Deleted/Paused events unless another adapter owns the ID, so
+// duplicates reported by overlapping adapters collapse to a single logical
+// workload.
+func (a *adapter) WatchEvents(ctx context.Context, eventChan chan<- *types.RuntimeEvent) error {
+	var (
+		mu   sync.Mutex
+		seen = make(map[string]types.RuntimeType)
+	)
+
+	var wg sync.WaitGroup
+
+	errCh := make(chan error, len(a.adapters))
+
+	for _, sub := range a.adapters {
+		wg.Add(1)
+
+		go func(sub types.RuntimeAdapter) {
+			defer wg.Done()
+
+			subCh := make(chan *types.RuntimeEvent)
+
+			go func() {
+				if err := sub.WatchEvents(ctx, subCh); err != nil && ctx.Err() == nil {
+					errCh <- fmt.Errorf("%s: %w", sub.Type(), err)
+				}
+			}()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-subCh:
+					if !ok {
+						return
+					}
+
+					if a.shouldForward(&mu, seen, sub.Type(), event) {
+						eventChan <- event
+					}
+				}
+			}
+		}(sub)
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	var errs []error
+	for err := range errCh {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return ctx.Err() //nolint:wrapcheck
+}
+
+// shouldForward decides whether event should be forwarded, deduplicating
+// Added/Modified events across adapters by workload ID: the first adapter
+// to report a given ID owns it, and later adapters' events for the same ID
+// are suppressed. Deleted/Paused events are forwarded unless another adapter
+// owns the ID; a forwarded one clears ownership, so a subsequent Added from
+// another adapter is recognized.
+func (a *adapter) shouldForward(
+	mu *sync.Mutex,
+	seen map[string]types.RuntimeType,
+	source types.RuntimeType,
+	event *types.RuntimeEvent,
+) bool {
+	if event.Workload == nil {
+		return true
+	}
+
+	id := event.Workload.GetId()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	switch event.Type {
+	case types.RuntimeEventTypeDeleted, types.RuntimeEventTypePaused:
+		owner, ok := seen[id]
+		if ok && owner != source {
+			return false
+		}
+
+		delete(seen, id)
+
+		return true
+	default:
+		owner, ok := seen[id]
+		if ok && owner != source {
+			return false
+		}
+
+		seen[id] = source
+
+		return true
+	}
+}
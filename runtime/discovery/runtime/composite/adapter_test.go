@@ -0,0 +1,124 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package composite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	runtimev1 "github.com/agntcy/dir/runtime/api/runtime/v1"
+	"github.com/agntcy/dir/runtime/discovery/types"
+)
+
+// fakeAdapter is a minimal types.RuntimeAdapter for testing composite
+// without standing up Docker/Kubernetes/Nomad.
+type fakeAdapter struct {
+	runtimeType types.RuntimeType
+	workloads   []*runtimev1.Workload
+	events      []*types.RuntimeEvent
+}
+
+func (f *fakeAdapter) Type() types.RuntimeType { return f.runtimeType }
+
+func (f *fakeAdapter) Close() error { return nil }
+
+func (f *fakeAdapter) ListWorkloads(context.Context) ([]*runtimev1.Workload, error) {
+	return f.workloads, nil
+}
+
+func (f *fakeAdapter) WatchEvents(ctx context.Context, eventChan chan<- *types.RuntimeEvent) error {
+	for _, e := range f.events {
+		eventChan <- e
+	}
+
+	<-ctx.Done()
+
+	return ctx.Err() //nolint:wrapcheck
+}
+
+func TestNewAdapter_RequiresAtLeastOneRuntime(t *testing.T) {
+	if _, err := NewAdapter(nil); err == nil {
+		t.Fatal("expected error when no underlying adapters are given")
+	}
+}
+
+func TestListWorkloads_DeduplicatesByID(t *testing.T) {
+	a := &fakeAdapter{
+		runtimeType: "a",
+		workloads:   []*runtimev1.Workload{{Id: "shared", Name: "from-a"}, {Id: "only-a", Name: "a"}},
+	}
+	b := &fakeAdapter{
+		runtimeType: "b",
+		workloads:   []*runtimev1.Workload{{Id: "shared", Name: "from-b"}, {Id: "only-b", Name: "b"}},
+	}
+
+	c, err := NewAdapter([]types.RuntimeAdapter{a, b})
+	if err != nil {
+		t.Fatalf("NewAdapter failed: %v", err)
+	}
+
+	workloads, err := c.ListWorkloads(context.Background())
+	if err != nil {
+		t.Fatalf("ListWorkloads failed: %v", err)
+	}
+
+	if len(workloads) != 3 {
+		t.Fatalf("expected 3 deduplicated workloads, got %d", len(workloads))
+	}
+
+	seen := make(map[string]int)
+	for _, w := range workloads {
+		seen[w.GetId()]++
+	}
+
+	for id, count := range seen {
+		if count != 1 {
+			t.Errorf("workload %q appeared %d times, want 1", id, count)
+		}
+	}
+}
+
+func TestWatchEvents_SuppressesDuplicateAdds(t *testing.T) {
+	shared := &runtimev1.Workload{Id: "shared"}
+
+	a := &fakeAdapter{
+		runtimeType: "a",
+		events:      []*types.RuntimeEvent{{Type: types.RuntimeEventTypeAdded, Workload: shared}},
+	}
+	b := &fakeAdapter{
+		runtimeType: "b",
+		events:      []*types.RuntimeEvent{{Type: types.RuntimeEventTypeAdded, Workload: shared}},
+	}
+
+	c, err := NewAdapter([]types.RuntimeAdapter{a, b})
+	if err != nil {
+		t.Fatalf("NewAdapter failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventChan := make(chan *types.RuntimeEvent, 4)
+
+	done := make(chan struct{})
+
+	go func() {
+		_ = c.WatchEvents(ctx, eventChan)
+		close(done)
+	}()
+
+	received := <-eventChan
+
+	if received.Workload.GetId() != "shared" {
+		t.Fatalf("unexpected workload: %v", received.Workload)
+	}
+
+	select {
+	case extra := <-eventChan:
+		t.Fatalf("expected duplicate Added event to be suppressed, got %v", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	<-done
+}
@@ -6,6 +6,7 @@ package config
 import (
 	"github.com/agntcy/dir/runtime/discovery/runtime/docker"
 	"github.com/agntcy/dir/runtime/discovery/runtime/k8s"
+	"github.com/agntcy/dir/runtime/discovery/runtime/nomad"
 	"github.com/agntcy/dir/runtime/discovery/types"
 )
 
@@ -19,4 +20,20 @@ type Config struct {
 
 	// Kubernetes runtime configuration.
 	Kubernetes k8s.Config `json:"kubernetes" mapstructure:"kubernetes"`
+
+	// Nomad runtime configuration.
+	Nomad nomad.Config `json:"nomad" mapstructure:"nomad"`
+
+	// Composite runtime configuration, used when Type is "composite" to fan
+	// discovery out across multiple underlying runtimes.
+	Composite CompositeConfig `json:"composite" mapstructure:"composite"`
+}
+
+// CompositeConfig holds the underlying runtimes a composite runtime fans
+// discovery out to.
+type CompositeConfig struct {
+	// Runtimes are the underlying runtime configurations to combine. Each
+	// entry is a full Config, so any registered runtime type - including
+	// another composite - can be nested.
+	Runtimes []Config `json:"runtimes" mapstructure:"runtimes"`
 }
@@ -0,0 +1,210 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	runtimev1 "github.com/agntcy/dir/runtime/api/runtime/v1"
+	"github.com/agntcy/dir/runtime/discovery/types"
+	"github.com/agntcy/dir/runtime/utils"
+	"github.com/hashicorp/nomad/api"
+)
+
+const RuntimeType types.RuntimeType = "nomad"
+
+// defaultWaitTime bounds how long a single blocking query waits for a
+// change before WatchEvents loops and issues the next one.
+const defaultWaitTime = 30 * time.Second
+
+var logger = utils.NewLogger("runtime", "nomad")
+
+// adapter implements the RuntimeAdapter interface for Nomad.
+type adapter struct {
+	client   *api.Client
+	tagKey   string
+	tagValue string
+}
+
+// NewAdapter creates a new Nomad adapter.
+func NewAdapter(cfg Config) (types.RuntimeAdapter, error) {
+	apiCfg := api.DefaultConfig()
+
+	if cfg.Address != "" {
+		apiCfg.Address = cfg.Address
+	}
+
+	if cfg.Region != "" {
+		apiCfg.Region = cfg.Region
+	}
+
+	if cfg.Namespace != "" {
+		apiCfg.Namespace = cfg.Namespace
+	}
+
+	if cfg.Token != "" {
+		apiCfg.SecretID = cfg.Token
+	}
+
+	client, err := api.NewClient(apiCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Nomad client: %w", err)
+	}
+
+	tagKey := cfg.TagKey
+	if tagKey == "" {
+		tagKey = DefaultTagKey
+	}
+
+	tagValue := cfg.TagValue
+	if tagValue == "" {
+		tagValue = DefaultTagValue
+	}
+
+	return &adapter{client: client, tagKey: tagKey, tagValue: tagValue}, nil
+}
+
+// Type returns the Nomad runtime type.
+func (a *adapter) Type() types.RuntimeType {
+	return RuntimeType
+}
+
+// Close is a no-op; the Nomad API client holds no long-lived connection.
+func (a *adapter) Close() error {
+	return nil
+}
+
+// ListWorkloads returns all running allocations belonging to jobs tagged
+// with the discover meta key.
+func (a *adapter) ListWorkloads(ctx context.Context) ([]*runtimev1.Workload, error) {
+	jobStubs, _, err := a.client.Jobs().List(&api.QueryOptions{Ctx: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Nomad jobs: %w", err)
+	}
+
+	var workloads []*runtimev1.Workload
+
+	for _, jobStub := range jobStubs {
+		job, _, err := a.client.Jobs().Info(jobStub.ID, &api.QueryOptions{Ctx: ctx})
+		if err != nil {
+			logger.Error("failed to get job", "job", jobStub.ID, "error", err)
+
+			continue
+		}
+
+		if job.Meta[a.tagKey] != a.tagValue {
+			continue
+		}
+
+		allocStubs, _, err := a.client.Jobs().Allocations(jobStub.ID, false, &api.QueryOptions{Ctx: ctx})
+		if err != nil {
+			logger.Error("failed to list allocations", "job", jobStub.ID, "error", err)
+
+			continue
+		}
+
+		for _, allocStub := range allocStubs {
+			if allocStub.ClientStatus != api.AllocClientStatusRunning {
+				continue
+			}
+
+			workloads = append(workloads, a.allocStubToWorkload(job, allocStub))
+		}
+	}
+
+	return workloads, nil
+}
+
+// WatchEvents watches Nomad allocations via blocking queries and sends
+// workload events to the channel.
+func (a *adapter) WatchEvents(ctx context.Context, eventChan chan<- *types.RuntimeEvent) error {
+	var lastIndex uint64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck
+		default:
+		}
+
+		allocStubs, meta, err := a.client.Allocations().List(&api.QueryOptions{
+			WaitIndex: lastIndex,
+			WaitTime:  defaultWaitTime,
+			Ctx:       ctx,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err() //nolint:wrapcheck
+			}
+
+			logger.Error("error watching Nomad allocations", "error", err)
+
+			continue
+		}
+
+		lastIndex = meta.LastIndex
+
+		for _, allocStub := range allocStubs {
+			a.handleAllocStub(ctx, allocStub, eventChan)
+		}
+	}
+}
+
+// handleAllocStub looks up the allocation's job, checks whether it's tagged
+// for discovery, and sends a workload event if so.
+func (a *adapter) handleAllocStub(ctx context.Context, allocStub *api.AllocationListStub, eventChan chan<- *types.RuntimeEvent) {
+	job, _, err := a.client.Jobs().Info(allocStub.JobID, &api.QueryOptions{Ctx: ctx})
+	if err != nil {
+		logger.Error("failed to get job", "job", allocStub.JobID, "error", err)
+
+		return
+	}
+
+	if job.Meta[a.tagKey] != a.tagValue {
+		return
+	}
+
+	var eventType types.RuntimeEventType
+
+	switch allocStub.ClientStatus {
+	case api.AllocClientStatusRunning:
+		eventType = types.RuntimeEventTypeAdded
+	case api.AllocClientStatusComplete, api.AllocClientStatusFailed, api.AllocClientStatusLost:
+		eventType = types.RuntimeEventTypeDeleted
+	default:
+		return
+	}
+
+	eventChan <- &types.RuntimeEvent{
+		Type:     eventType,
+		Workload: a.allocStubToWorkload(job, allocStub),
+	}
+}
+
+// allocStubToWorkload converts a Nomad allocation to a workload.
+func (a *adapter) allocStubToWorkload(job *api.Job, allocStub *api.AllocationListStub) *runtimev1.Workload {
+	labels := make(map[string]string, len(job.Meta))
+	for k, v := range job.Meta {
+		labels[k] = v
+	}
+
+	isolationGroups := []string{allocStub.Namespace}
+	if allocStub.TaskGroup != "" {
+		isolationGroups = append(isolationGroups, allocStub.TaskGroup)
+	}
+
+	return &runtimev1.Workload{
+		Id:              allocStub.ID,
+		Name:            allocStub.Name,
+		Hostname:        allocStub.NodeName,
+		Runtime:         string(RuntimeType),
+		WorkloadType:    "allocation",
+		Addresses:       []string{allocStub.NodeName},
+		IsolationGroups: isolationGroups,
+		Labels:          labels,
+		Annotations:     make(map[string]string),
+	}
+}
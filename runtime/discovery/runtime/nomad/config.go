@@ -0,0 +1,36 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package nomad
+
+const (
+	// DefaultAddress is the default Nomad HTTP API address.
+	DefaultAddress = "http://127.0.0.1:4646"
+
+	// DefaultTagKey is the default job meta key to filter discoverable jobs.
+	DefaultTagKey = "org.agntcy/discover"
+
+	// DefaultTagValue is the default job meta value to filter discoverable jobs.
+	DefaultTagValue = "true"
+)
+
+// Config holds Nomad runtime configuration.
+type Config struct {
+	// Address is the Nomad HTTP API address.
+	Address string `json:"address,omitempty" mapstructure:"address"`
+
+	// Region is the Nomad region to query. Empty uses the client's default.
+	Region string `json:"region,omitempty" mapstructure:"region"`
+
+	// Namespace is the Nomad namespace to query. Empty uses the client's default.
+	Namespace string `json:"namespace,omitempty" mapstructure:"namespace"`
+
+	// Token is the Nomad ACL token used to authenticate requests.
+	Token string `json:"token,omitempty" mapstructure:"token"` //nolint:gosec // G117: intentional field for ACL token
+
+	// TagKey is the job meta key to filter discoverable jobs.
+	TagKey string `json:"tag_key,omitempty" mapstructure:"tag_key"`
+
+	// TagValue is the job meta value to filter discoverable jobs.
+	TagValue string `json:"tag_value,omitempty" mapstructure:"tag_value"`
+}
@@ -5,21 +5,84 @@ package runtime
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/agntcy/dir/runtime/discovery/runtime/composite"
 	"github.com/agntcy/dir/runtime/discovery/runtime/config"
 	"github.com/agntcy/dir/runtime/discovery/runtime/docker"
 	"github.com/agntcy/dir/runtime/discovery/runtime/k8s"
+	"github.com/agntcy/dir/runtime/discovery/runtime/nomad"
 	"github.com/agntcy/dir/runtime/discovery/types"
 )
 
-//nolint:wrapcheck
+// AdapterFactory builds a runtime adapter from the full discovery config.
+// Implementations pick out their own sub-config (cfg.Docker, cfg.Nomad, ...).
+type AdapterFactory func(cfg config.Config) (types.RuntimeAdapter, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[types.RuntimeType]AdapterFactory)
+)
+
+// Register adds factory to the set of known runtime types, so NewAdapter can
+// build it from config.Config.Type. External packages can call Register
+// from their own init() to contribute an adapter without this package
+// needing to import them.
+func Register(name types.RuntimeType, factory AdapterFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[name] = factory
+}
+
+// NewAdapter builds the runtime adapter registered for cfg.Type.
 func NewAdapter(cfg config.Config) (types.RuntimeAdapter, error) {
-	switch cfg.Type {
-	case docker.RuntimeType:
+	registryMu.RLock()
+	factory, ok := registry[cfg.Type]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported runtime: %s", cfg.Type)
+	}
+
+	return factory(cfg)
+}
+
+//nolint:gochecknoinits
+func init() {
+	Register(docker.RuntimeType, func(cfg config.Config) (types.RuntimeAdapter, error) {
+		//nolint:wrapcheck
 		return docker.NewAdapter(cfg.Docker)
-	case k8s.RuntimeType:
+	})
+
+	Register(k8s.RuntimeType, func(cfg config.Config) (types.RuntimeAdapter, error) {
+		//nolint:wrapcheck
 		return k8s.NewAdapter(cfg.Kubernetes)
-	default:
-		return nil, fmt.Errorf("unsupported runtime: %s", cfg.Type)
+	})
+
+	Register(nomad.RuntimeType, func(cfg config.Config) (types.RuntimeAdapter, error) {
+		//nolint:wrapcheck
+		return nomad.NewAdapter(cfg.Nomad)
+	})
+
+	Register(composite.RuntimeType, newCompositeAdapter)
+}
+
+// newCompositeAdapter builds each underlying runtime listed in
+// cfg.Composite.Runtimes (recursing through NewAdapter, so a composite can
+// itself nest another composite) and combines them into one adapter.
+func newCompositeAdapter(cfg config.Config) (types.RuntimeAdapter, error) {
+	adapters := make([]types.RuntimeAdapter, 0, len(cfg.Composite.Runtimes))
+
+	for i, subCfg := range cfg.Composite.Runtimes {
+		adapter, err := NewAdapter(subCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build composite runtime %d (%s): %w", i, subCfg.Type, err)
+		}
+
+		adapters = append(adapters, adapter)
 	}
+
+	//nolint:wrapcheck
+	return composite.NewAdapter(adapters)
 }
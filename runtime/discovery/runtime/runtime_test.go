@@ -0,0 +1,37 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/runtime/discovery/runtime/config"
+	"github.com/agntcy/dir/runtime/discovery/types"
+)
+
+func TestNewAdapter_UnsupportedRuntime(t *testing.T) {
+	_, err := NewAdapter(config.Config{Type: "unknown"})
+	if err == nil {
+		t.Fatal("expected error for unsupported runtime type")
+	}
+}
+
+func TestRegister_AddsFactory(t *testing.T) {
+	const name types.RuntimeType = "test-runtime"
+
+	called := false
+	Register(name, func(config.Config) (types.RuntimeAdapter, error) {
+		called = true
+
+		return nil, nil //nolint:nilnil
+	})
+
+	if _, err := NewAdapter(config.Config{Type: name}); err != nil {
+		t.Fatalf("NewAdapter failed: %v", err)
+	}
+
+	if !called {
+		t.Fatal("expected registered factory to be invoked")
+	}
+}
@@ -12,13 +12,18 @@ import (
 	authn "github.com/agntcy/dir/server/authn/config"
 	authz "github.com/agntcy/dir/server/authz/config"
 	dbconfig "github.com/agntcy/dir/server/database/config"
+	deviceauthconfig "github.com/agntcy/dir/server/deviceauth/config"
 	events "github.com/agntcy/dir/server/events/config"
+	adminconfig "github.com/agntcy/dir/server/internal/admin/config"
+	concurrencyconfig "github.com/agntcy/dir/server/middleware/concurrency/config"
 	ratelimitconfig "github.com/agntcy/dir/server/middleware/ratelimit/config"
 	naming "github.com/agntcy/dir/server/naming/config"
 	publication "github.com/agntcy/dir/server/publication/config"
 	routing "github.com/agntcy/dir/server/routing/config"
+	signingconfig "github.com/agntcy/dir/server/signing/config"
 	store "github.com/agntcy/dir/server/store/config"
 	oci "github.com/agntcy/dir/server/store/oci/config"
+	telemetryconfig "github.com/agntcy/dir/server/telemetry/config"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -103,6 +108,13 @@ const (
 
 	// DefaultMetricsAddress is the default listen address for the metrics HTTP server.
 	DefaultMetricsAddress = ":9090"
+
+	// Expiry configuration.
+
+	// DefaultDeviceRequestExpiry bounds how long an unapproved device
+	// authorization grant request (device_code/user_code pair) remains
+	// valid, per RFC 8628 §3.2's expires_in.
+	DefaultDeviceRequestExpiry = 15 * time.Minute
 )
 
 var logger = logging.Logger("config")
@@ -123,6 +135,9 @@ type Config struct {
 	// Rate limiting configuration
 	RateLimit ratelimitconfig.Config `json:"ratelimit" mapstructure:"ratelimit"`
 
+	// Concurrency limiting configuration
+	Concurrency concurrencyconfig.Config `json:"concurrency" mapstructure:"concurrency"`
+
 	// Authn configuration (JWT or X.509 authentication)
 	Authn authn.Config `json:"authn" mapstructure:"authn"`
 
@@ -150,8 +165,42 @@ type Config struct {
 	// Metrics configuration
 	Metrics MetricsConfig `json:"metrics" mapstructure:"metrics"`
 
+	// Admin holds configuration for the internal admin server, which hosts
+	// /metrics, /debug/pprof/*, /healthz, /readyz, and /buildinfo on a port
+	// separate from the user-facing gRPC listener.
+	Admin adminconfig.Config `json:"admin" mapstructure:"admin"`
+
+	// Telemetry holds configuration for the OpenTelemetry OTLP metrics
+	// pipeline, which runs alongside the Prometheus pull endpoint.
+	Telemetry TelemetryConfig `json:"telemetry" mapstructure:"telemetry"`
+
 	// Naming holds name verification cache config (TTL for naming API; reconciler name task performs re-verification).
 	Naming naming.Config `json:"naming,omitzero" mapstructure:"naming"`
+
+	// DeviceAuth holds configuration for the RFC 8628 device authorization
+	// grant server (POST /device/code, POST /token, GET /device).
+	DeviceAuth deviceauthconfig.Config `json:"device_auth" mapstructure:"device_auth"`
+
+	// Expiry holds TTLs for time-bounded server-side records.
+	Expiry ExpiryConfig `json:"expiry" mapstructure:"expiry"`
+
+	// Signing holds configuration for the signing service, including the
+	// optional trust policy file used by OIDC/keyless signature verification.
+	Signing signingconfig.Config `json:"signing" mapstructure:"signing"`
+}
+
+// ExpiryConfig holds TTLs for time-bounded server-side records.
+type ExpiryConfig struct {
+	// DeviceRequests bounds how long an unapproved device authorization
+	// grant request remains valid before /token returns expired_token.
+	// Default: 15m
+	DeviceRequests time.Duration `json:"device_requests,omitempty" mapstructure:"device_requests"`
+}
+
+// TelemetryConfig holds configuration for the OTLP metrics pipeline.
+type TelemetryConfig struct {
+	// OTLP holds the OTLP metrics exporter configuration.
+	OTLP telemetryconfig.Config `json:"otlp" mapstructure:"otlp"`
 }
 
 type SyncConfig struct {
@@ -242,14 +291,17 @@ type KeepaliveConfig struct {
 	PermitWithoutStream bool `json:"permit_without_stream,omitempty" mapstructure:"permit_without_stream"`
 }
 
-// MetricsConfig holds Prometheus metrics configuration.
+// MetricsConfig holds Prometheus metrics collection configuration.
+// The metrics themselves are now served from the internal admin server
+// (see Config.Admin); Address is kept only for tooling that still expects
+// a dedicated metrics port to exist in config.
 type MetricsConfig struct {
 	// Enabled enables Prometheus metrics collection.
 	// Default: true
 	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
 
-	// Address is the HTTP listen address for the metrics endpoint.
-	// The metrics server runs on a separate port from the gRPC server.
+	// Address is the legacy HTTP listen address for the metrics endpoint.
+	// Deprecated: metrics are served from Admin.Address at /metrics.
 	// Default: ":9090"
 	Address string `json:"address,omitempty" mapstructure:"address"`
 }
@@ -338,6 +390,17 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("ratelimit.enabled")
 	v.SetDefault("ratelimit.enabled", false)
 
+	_ = v.BindEnv("ratelimit.backend")
+	v.SetDefault("ratelimit.backend", ratelimitconfig.DefaultBackend)
+
+	_ = v.BindEnv("ratelimit.redis.addr")
+	_ = v.BindEnv("ratelimit.redis.password")
+	_ = v.BindEnv("ratelimit.redis.db")
+
+	_ = v.BindEnv("ratelimit.etcd.endpoints")
+	_ = v.BindEnv("ratelimit.etcd.username")
+	_ = v.BindEnv("ratelimit.etcd.password")
+
 	_ = v.BindEnv("ratelimit.global_rps")
 	v.SetDefault("ratelimit.global_rps", 0.0)
 
@@ -360,6 +423,24 @@ func LoadConfig() (*Config, error) {
 	//         rps: 50
 	//         burst: 100
 
+	//
+	// Concurrency limiting configuration
+	//
+	_ = v.BindEnv("concurrency.enabled")
+	v.SetDefault("concurrency.enabled", false)
+
+	_ = v.BindEnv("concurrency.global_max_inflight")
+	v.SetDefault("concurrency.global_max_inflight", 0)
+
+	_ = v.BindEnv("concurrency.per_client_max_inflight")
+	v.SetDefault("concurrency.per_client_max_inflight", 0)
+
+	_ = v.BindEnv("concurrency.max_queue_wait")
+	v.SetDefault("concurrency.max_queue_wait", 0)
+
+	// Note: method_limits (per-method concurrency overrides) can only be configured
+	// via YAML/JSON config file, same as ratelimit.method_limits above.
+
 	//
 	// Authn configuration (authentication: JWT or X.509)
 	//
@@ -396,6 +477,9 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("store.oci.cache_dir")
 	v.SetDefault("store.oci.cache_dir", "")
 
+	_ = v.BindEnv("store.oci.leases_enabled")
+	v.SetDefault("store.oci.leases_enabled", false)
+
 	_ = v.BindEnv("store.oci.registry_address")
 	v.SetDefault("store.oci.registry_address", oci.DefaultRegistryAddress)
 
@@ -505,12 +589,67 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("metrics.address")
 	v.SetDefault("metrics.address", DefaultMetricsAddress)
 
+	//
+	// Admin server configuration (metrics, pprof, health, buildinfo)
+	//
+	_ = v.BindEnv("admin.enabled")
+	v.SetDefault("admin.enabled", adminconfig.DefaultEnabled)
+
+	_ = v.BindEnv("admin.address")
+	v.SetDefault("admin.address", adminconfig.DefaultAddress)
+
+	_ = v.BindEnv("admin.tls.cert_file")
+	_ = v.BindEnv("admin.tls.key_file")
+
+	_ = v.BindEnv("admin.basic_auth.username")
+	_ = v.BindEnv("admin.basic_auth.password")
+
+	//
+	// Telemetry configuration (OTLP metrics pipeline)
+	//
+	_ = v.BindEnv("telemetry.otlp.enabled")
+	v.SetDefault("telemetry.otlp.enabled", telemetryconfig.DefaultEnabled)
+
+	_ = v.BindEnv("telemetry.otlp.endpoint")
+
+	_ = v.BindEnv("telemetry.otlp.protocol")
+	v.SetDefault("telemetry.otlp.protocol", telemetryconfig.DefaultProtocol)
+
+	_ = v.BindEnv("telemetry.otlp.insecure")
+
+	_ = v.BindEnv("telemetry.otlp.interval")
+	v.SetDefault("telemetry.otlp.interval", telemetryconfig.DefaultInterval)
+
+	// Note: headers and resource_attributes (map[string]string) can only be
+	// configured via YAML/JSON config file, matching ratelimit.method_limits.
+
 	//
 	// Naming (name verification cache TTL for API responses; re-verification is done by the reconciler name task)
 	//
 	_ = v.BindEnv("naming.ttl")
 	v.SetDefault("naming.ttl", naming.DefaultTTL)
 
+	//
+	// Device authorization grant server configuration
+	//
+	_ = v.BindEnv("device_auth.enabled")
+	v.SetDefault("device_auth.enabled", deviceauthconfig.DefaultEnabled)
+
+	_ = v.BindEnv("device_auth.address")
+	v.SetDefault("device_auth.address", deviceauthconfig.DefaultAddress)
+
+	_ = v.BindEnv("device_auth.verification_uri")
+	v.SetDefault("device_auth.verification_uri", deviceauthconfig.DefaultVerificationURI)
+
+	_ = v.BindEnv("device_auth.interval")
+	v.SetDefault("device_auth.interval", deviceauthconfig.DefaultInterval)
+
+	//
+	// Expiry configuration
+	//
+	_ = v.BindEnv("expiry.device_requests")
+	v.SetDefault("expiry.device_requests", DefaultDeviceRequestExpiry)
+
 	//
 	// Connection management configuration
 	//
@@ -36,6 +36,15 @@ func (s *signCtrl) Sign(_ context.Context, _ *signv1.SignRequest) (*signv1.SignR
 	return nil, status.Error(codes.Unimplemented, "server-side signing not implemented - use client SDK")
 }
 
+// BuildBundle wraps a legacy detached signature into a Sigstore bundle.
+// Like Sign, this is handled client-side (see utils/cosign.BuildBundle)
+// so the raw signature bytes and any private key material never leave the caller.
+func (s *signCtrl) BuildBundle(_ context.Context, _ *signv1.BuildBundleRequest) (*signv1.BuildBundleResponse, error) {
+	signLogger.Debug("BuildBundle request received - redirecting to client-side")
+
+	return nil, status.Error(codes.Unimplemented, "server-side bundle construction not implemented - use client SDK")
+}
+
 func (s *signCtrl) Verify(ctx context.Context, req *signv1.VerifyRequest) (*signv1.VerifyResponse, error) {
 	if req.GetRecordRef() == nil || req.GetRecordRef().GetCid() == "" {
 		return nil, status.Error(codes.InvalidArgument, "record CID is required")
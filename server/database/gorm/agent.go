@@ -6,13 +6,17 @@ package gorm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"path"
+	"strings"
 
 	coretypes "github.com/agntcy/dir/api/core/v1alpha1"
 	"github.com/agntcy/dir/server/database/types"
 	ds "github.com/dep2p/libp2p/datastore"
 	"github.com/dep2p/libp2p/datastore/query"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -41,25 +45,152 @@ func NewAgentTable(db *gorm.DB) ds.Datastore {
 }
 
 func (s *agentTable) Get(ctx context.Context, key ds.Key) (value []byte, err error) {
-	//TODO implement me
-	panic("implement me")
+	digest, err := AgentCID(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract digest from key: %w", err)
+	}
+
+	var agent types.Agent
+	if err := s.db.WithContext(ctx).Table(agentTableName).
+		Where("digest = ?", digest.Encode()).
+		First(&agent).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ds.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	return objectMetaBytes(digest, agent.Name, agent.Version)
 }
 
 func (s *agentTable) Has(ctx context.Context, key ds.Key) (exists bool, err error) {
-	//TODO implement me
-	panic("implement me")
+	digest, err := AgentCID(key)
+	if err != nil {
+		return false, fmt.Errorf("failed to extract digest from key: %w", err)
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Table(agentTableName).
+		Where("digest = ?", digest.Encode()).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check agent existence: %w", err)
+	}
+
+	return count > 0, nil
 }
 
 // GetSize we can fake return back here
 func (s *agentTable) GetSize(ctx context.Context, key ds.Key) (size int, err error) {
-	//TODO implement me
-	panic("implement me")
+	value, err := s.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(value), nil
 }
 
-// Query dont implement now
+// Query translates q into GORM Where/Order/Limit/Offset clauses on the agents
+// table where possible (FilterKeyPrefix, FilterKeyCompare, q.Prefix,
+// OrderByKey, OrderByKeyDescending). Any other filter/order type is applied
+// in-memory over the fetched rows, same as a naive datastore query would.
 func (s *agentTable) Query(ctx context.Context, q query.Query) (query.Results, error) {
-	//TODO implement me
-	panic("implement me")
+	db := s.db.WithContext(ctx).Table(agentTableName).Model(&types.Agent{})
+
+	residualFilters := make([]query.Filter, 0, len(q.Filters))
+
+	for _, filter := range q.Filters {
+		switch f := filter.(type) {
+		case query.FilterKeyPrefix:
+			db = db.Where("digest LIKE ?", keyPrefixPattern(f.Prefix))
+		case query.FilterKeyCompare:
+			sqlOp, ok := compareOpToSQL(f.Op)
+			if !ok {
+				residualFilters = append(residualFilters, filter)
+
+				continue
+			}
+
+			db = db.Where(fmt.Sprintf("digest %s ?", sqlOp), digestFromKey(f.Key))
+		default:
+			residualFilters = append(residualFilters, filter)
+		}
+	}
+
+	if q.Prefix != "" {
+		db = db.Where("digest LIKE ?", keyPrefixPattern(q.Prefix))
+	}
+
+	residualOrders := make([]query.Order, 0, len(q.Orders))
+
+	for _, order := range q.Orders {
+		switch order.(type) {
+		case query.OrderByKey:
+			db = db.Order("digest ASC")
+		case query.OrderByKeyDescending:
+			db = db.Order("digest DESC")
+		default:
+			residualOrders = append(residualOrders, order)
+		}
+	}
+
+	// Limit/offset can only be pushed down when every filter/order was pushed
+	// down too - otherwise the residual in-memory filtering/sorting below must
+	// run over the full matching set first.
+	pushedDown := len(residualFilters) == 0 && len(residualOrders) == 0
+	if pushedDown {
+		if q.Limit > 0 {
+			db = db.Limit(q.Limit)
+		}
+
+		if q.Offset > 0 {
+			db = db.Offset(q.Offset)
+		}
+	}
+
+	var agents []types.Agent
+	if err := db.Find(&agents).Error; err != nil {
+		return nil, fmt.Errorf("failed to query agents: %w", err)
+	}
+
+	entries := make([]query.Entry, 0, len(agents))
+
+	for _, agent := range agents {
+		entry, err := agentEntry(agent, q.KeysOnly)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	for _, filter := range residualFilters {
+		entries = filterEntries(entries, filter)
+	}
+
+	for _, order := range residualOrders {
+		order.Sort(entries)
+	}
+
+	if !pushedDown {
+		entries = sliceOffsetLimit(entries, q.Offset, q.Limit)
+	}
+
+	builder := query.NewResultBuilder(q)
+
+	go func() {
+		defer close(builder.Output)
+
+		for _, entry := range entries {
+			select {
+			case builder.Output <- query.Result{Entry: entry}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return builder.Results(), nil
 }
 
 func (s *agentTable) Put(ctx context.Context, key ds.Key, value []byte) error {
@@ -74,24 +205,40 @@ func (s *agentTable) Put(ctx context.Context, key ds.Key, value []byte) error {
 		Digest:  objectMeta.Digest.Encode(),
 	}
 
-	return s.db.WithContext(ctx).Table(agentTableName).Save(&agent).Error
+	// digest is content-addressed, so a re-publish/retry of the same digest is
+	// expected: upsert on the digest unique index instead of Save, which would
+	// always INSERT here since agent.ID is only ever assigned by BeforeCreate
+	// and so never matches an existing row.
+	return s.db.WithContext(ctx).Table(agentTableName).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "digest"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "version"}),
+		}).
+		Create(&agent).Error
 }
 
 func (s *agentTable) Delete(ctx context.Context, key ds.Key) error {
-	//TODO implement me
-	panic("implement me")
+	digest, err := AgentCID(key)
+	if err != nil {
+		return fmt.Errorf("failed to extract digest from key: %w", err)
+	}
+
+	return s.db.WithContext(ctx).Table(agentTableName).
+		Where("digest = ?", digest.Encode()).
+		Delete(&types.Agent{}).Error
 }
 
-// Sync dont implement now
+// Sync is a no-op: every Put/Delete on this table is its own auto-committed
+// GORM statement, so there is no pending transaction left to flush.
 func (s *agentTable) Sync(ctx context.Context, prefix ds.Key) error {
-	//TODO implement me
-	panic("implement me")
+	return nil
 }
 
-// Close should only close the actual table, not the database itself
+// Close should only close the actual table, not the database itself. The
+// *gorm.DB connection is shared across tables and is owned (and closed) by
+// whoever constructed it, so there's nothing for the table wrapper to do here.
 func (s *agentTable) Close() error {
-	//TODO implement me
-	panic("implement me")
+	return nil
 }
 
 // AgentCID extracts the agent digest (UID) from the key.
@@ -103,3 +250,107 @@ func AgentCID(key ds.Key) (*coretypes.Digest, error) {
 	}
 	return &digest, nil
 }
+
+// objectMetaBytes marshals a coretypes.ObjectMeta built from the given digest,
+// name, and version, as persisted by Put and returned by Get.
+func objectMetaBytes(digest *coretypes.Digest, name, version string) ([]byte, error) {
+	value, err := json.Marshal(coretypes.ObjectMeta{
+		Name:    name,
+		Version: version,
+		Digest:  digest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object meta: %w", err)
+	}
+
+	return value, nil
+}
+
+// agentEntry builds the query.Entry for a row, reconstructing its key as
+// /<digest> per the schema documented on agentTable. When keysOnly is set,
+// the value is omitted entirely, matching the query.Query.KeysOnly contract.
+func agentEntry(agent types.Agent, keysOnly bool) (query.Entry, error) {
+	key := ds.NewKey(agent.Digest)
+
+	if keysOnly {
+		return query.Entry{Key: key.String()}, nil
+	}
+
+	var digest coretypes.Digest
+	if err := digest.Decode(agent.Digest); err != nil {
+		return query.Entry{}, fmt.Errorf("failed to decode digest %q: %w", agent.Digest, err)
+	}
+
+	value, err := objectMetaBytes(&digest, agent.Name, agent.Version)
+	if err != nil {
+		return query.Entry{}, err
+	}
+
+	return query.Entry{Key: key.String(), Value: value, Size: len(value)}, nil
+}
+
+// keyPrefixPattern turns a query key-prefix (e.g. "/namespace") into a SQL
+// LIKE pattern matched against the digest column, the only part of the
+// original key this table persists.
+func keyPrefixPattern(prefix string) string {
+	return strings.TrimPrefix(prefix, "/") + "%"
+}
+
+// digestFromKey extracts the digest component from a full datastore key
+// (/<namespace>/<digest>), same as AgentCID but operating on a raw string.
+func digestFromKey(key string) string {
+	return path.Base(key)
+}
+
+// compareOpToSQL maps a query.FilterKeyCompare operator onto the SQL
+// operator to use against the digest column. ok is false for operators this
+// table doesn't know how to push down, leaving the filter to run in-memory.
+func compareOpToSQL(op query.Op) (string, bool) {
+	switch op {
+	case query.Equal:
+		return "=", true
+	case query.NotEqual:
+		return "!=", true
+	case query.GreaterThan:
+		return ">", true
+	case query.GreaterThanOrEqual:
+		return ">=", true
+	case query.LessThan:
+		return "<", true
+	case query.LessThanOrEqual:
+		return "<=", true
+	default:
+		return "", false
+	}
+}
+
+// filterEntries keeps only the entries for which filter.Filter returns true.
+func filterEntries(entries []query.Entry, filter query.Filter) []query.Entry {
+	kept := entries[:0]
+
+	for _, entry := range entries {
+		if filter.Filter(entry) {
+			kept = append(kept, entry)
+		}
+	}
+
+	return kept
+}
+
+// sliceOffsetLimit applies offset/limit to an in-memory entry slice, for the
+// case where filters/orders couldn't be pushed down to SQL.
+func sliceOffsetLimit(entries []query.Entry, offset, limit int) []query.Entry {
+	if offset > 0 {
+		if offset >= len(entries) {
+			return nil
+		}
+
+		entries = entries[offset:]
+	}
+
+	if limit > 0 && limit < len(entries) {
+		entries = entries[:limit]
+	}
+
+	return entries
+}
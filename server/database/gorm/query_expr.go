@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package gorm
+
+import (
+	"fmt"
+
+	searchv1alpha2 "github.com/agntcy/dir/api/search/v1alpha2"
+	"github.com/agntcy/dir/server/types"
+)
+
+// GetRecordsByExpr retrieves records matching a searchv1alpha2.QueryExpr
+// boolean query tree, as produced by searchv1alpha2.ParseQuery. Unlike
+// GetRecords, which only ANDs flat equality/wildcard filters together, this
+// supports arbitrary AND/OR/NOT composition and the richer match modes
+// (prefix, glob, regex, semver range).
+//
+// There is no SQL pushdown yet: every record is fetched and evaluated
+// against expr in Go, same trade-off as other optimizations deferred
+// elsewhere in this package. Only fields backed by columns on the records
+// and locators tables are evaluated - skill/extension/domain fields always
+// report no match, since their association types aren't wired up yet.
+func (d *DB) GetRecordsByExpr(expr *searchv1alpha2.QueryExpr, limit, offset int) ([]types.Record, error) {
+	var records []Record
+	if err := d.gormDB.Model(&Record{}).
+		Preload("Locators").
+		Order("records.created_at DESC").
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to query records: %w", err)
+	}
+
+	matched := make([]types.Record, 0, len(records))
+
+	for i := range records {
+		ok, err := expr.Evaluate(recordQueryFields(&records[i]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate query against record %q: %w", records[i].RecordCID, err)
+		}
+
+		if ok {
+			matched = append(matched, &records[i])
+		}
+	}
+
+	return sliceRecordsOffsetLimit(matched, offset, limit), nil
+}
+
+// recordQueryFields builds the RecordQueryType-name -> values map that
+// QueryExpr.Evaluate matches a record against.
+func recordQueryFields(record *Record) map[string][]string {
+	fields := map[string][]string{
+		"name":    {record.Name},
+		"version": {record.Version},
+	}
+
+	for _, locator := range record.Locators {
+		fields["locator-type"] = append(fields["locator-type"], locator.Type)
+		fields["locator-url"] = append(fields["locator-url"], locator.URL)
+	}
+
+	return fields
+}
+
+// sliceRecordsOffsetLimit applies offset/limit to an in-memory record slice,
+// same pattern as agentTable's sliceOffsetLimit for its in-memory entries.
+func sliceRecordsOffsetLimit(records []types.Record, offset, limit int) []types.Record {
+	if offset > 0 {
+		if offset >= len(records) {
+			return nil
+		}
+
+		records = records[offset:]
+	}
+
+	if limit > 0 && limit < len(records) {
+		records = records[:limit]
+	}
+
+	return records
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Cisco and/or its affiliates.
+// SPDX-License-Identifier: Apache-2.0
+
+package gorm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/database/types"
+	ds "github.com/dep2p/libp2p/datastore"
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Dialector opens the GORM dialector for a driver-specific DSN.
+type Dialector func(dsn string) gorm.Dialector
+
+// PoolConfig tunes the underlying *sql.DB connection pool for a backend.
+// Zero values leave database/sql's own defaults in place.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// Backend is a named SQL backend that Registry.Open can connect to.
+type Backend struct {
+	// Dialector builds the GORM dialector for this backend's DSN.
+	Dialector Dialector
+
+	// Pool tunes this backend's connection pool. Optional.
+	Pool PoolConfig
+}
+
+// Registry holds the set of named SQL backends available to Registry.Open.
+// It replaces a package init() registering backends as a side effect - call
+// Init explicitly (e.g. from server startup) so tests and embedders can
+// choose exactly which drivers get linked and registered.
+type Registry struct {
+	mu       sync.RWMutex
+	backends map[string]Backend
+}
+
+// NewRegistry returns an empty Registry. Use Init to populate it with the
+// built-in sqlite/postgres/mysql backends, or Register custom ones.
+func NewRegistry() *Registry {
+	return &Registry{
+		backends: make(map[string]Backend),
+	}
+}
+
+// Register adds or replaces the named backend.
+func (r *Registry) Register(name string, backend Backend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.backends[name] = backend
+}
+
+// Open connects to the named backend using dsn, runs schema migrations for
+// every table this package knows about, and returns the agent table as a
+// ds.Datastore. Future tables (records/peers/labels) should be migrated and
+// composed in here alongside NewAgentTable once they exist.
+func (r *Registry) Open(name, dsn string) (ds.Datastore, error) {
+	r.mu.RLock()
+	backend, ok := r.backends[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown database backend %q", name)
+	}
+
+	db, err := gorm.Open(backend.Dialector(dsn), &gorm.Config{
+		Logger: newCustomLogger(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", name, err)
+	}
+
+	if _, err := New(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate %s database: %w", name, err)
+	}
+
+	if err := db.AutoMigrate(&types.Agent{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate %s agent schema: %w", name, err)
+	}
+
+	if err := applyPool(db, backend.Pool); err != nil {
+		return nil, fmt.Errorf("failed to configure %s connection pool: %w", name, err)
+	}
+
+	return NewAgentTable(db), nil
+}
+
+// applyPool configures db's underlying *sql.DB connection pool. A zero-value
+// PoolConfig leaves database/sql's own defaults untouched.
+func applyPool(db *gorm.DB, pool PoolConfig) error {
+	if pool == (PoolConfig{}) {
+		return nil
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	if pool.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+
+	if pool.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+
+	if pool.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+
+	return nil
+}
+
+// Init registers the built-in sqlite, postgres, and mysql backends on r.
+// Call it explicitly (e.g. from server startup) instead of relying on a
+// package init(), so embedders can build a Registry with only the drivers
+// they actually want linked and registered.
+func Init(r *Registry) {
+	r.Register("sqlite", Backend{
+		Dialector: func(dsn string) gorm.Dialector { return sqlite.Open(dsn) },
+	})
+
+	r.Register("postgres", Backend{
+		Dialector: func(dsn string) gorm.Dialector { return postgres.Open(dsn) },
+	})
+
+	r.Register("mysql", Backend{
+		Dialector: func(dsn string) gorm.Dialector { return mysql.Open(dsn) },
+	})
+}
@@ -6,7 +6,7 @@ package types
 type Agent struct {
 	Model
 
-	Name    string `json:"name,omitempty" mapstructure:"name"`
+	Name    string `json:"name,omitempty"    mapstructure:"name"`
 	Version string `json:"version,omitempty" mapstructure:"version"`
-	Digest  string `json:"digest,omitempty" mapstructure:"digest"`
+	Digest  string `json:"digest,omitempty"  mapstructure:"digest" gorm:"uniqueIndex"` // one row per content-addressed digest
 }
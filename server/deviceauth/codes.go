@@ -0,0 +1,70 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package deviceauth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// userCodeAlphabet excludes visually-similar characters (0/O, 1/I/L) per
+// RFC 8628 §6.1's recommendation, so a human reading the code aloud or
+// typing it from a phone screen is unlikely to transpose a character.
+const userCodeAlphabet = "BCDFGHJKMNPQRSTVWXYZ"
+
+// userCodeGroupLen and userCodeGroups produce codes like "WDJB-MJHT",
+// matching the shape of GitHub's own device flow user codes.
+const (
+	userCodeGroupLen = 4
+	userCodeGroups   = 2
+
+	// deviceCodeBytes is the amount of entropy behind a device_code. It is
+	// never shown to a human, so it can be long and use a dense encoding.
+	deviceCodeBytes = 32
+
+	// tokenBytes is the amount of entropy behind an issued access token.
+	tokenBytes = 32
+)
+
+// generateUserCode returns a short, human-typeable code like "WDJB-MJHT".
+func generateUserCode() (string, error) {
+	total := userCodeGroupLen * userCodeGroups
+
+	raw := make([]byte, total)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate user code: %w", err)
+	}
+
+	code := make([]byte, 0, total+userCodeGroups-1)
+
+	for i, b := range raw {
+		if i > 0 && i%userCodeGroupLen == 0 {
+			code = append(code, '-')
+		}
+
+		code = append(code, userCodeAlphabet[int(b)%len(userCodeAlphabet)])
+	}
+
+	return string(code), nil
+}
+
+// generateDeviceCode returns a long, hex-encoded random device_code.
+func generateDeviceCode() (string, error) {
+	return randomHex(deviceCodeBytes)
+}
+
+// generateToken returns a long, hex-encoded random access token.
+func generateToken() (string, error) {
+	return randomHex(tokenBytes)
+}
+
+func randomHex(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+
+	return hex.EncodeToString(raw), nil
+}
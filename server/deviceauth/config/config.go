@@ -0,0 +1,48 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config provides configuration for the device authorization grant server.
+package config
+
+const (
+	// DefaultEnabled disables the device authorization grant server by default,
+	// since most deployments authenticate RPCs via mTLS/JWT/GitHub tokens
+	// minted elsewhere rather than an on-box OAuth2 device flow.
+	DefaultEnabled = false
+
+	// DefaultAddress is the default listen address for the device authorization
+	// HTTP endpoints (/device/code, /token, /device).
+	DefaultAddress = ":8890"
+
+	// DefaultVerificationURI is the default user-facing approval page, served
+	// by this same server. Operators behind a reverse proxy should override
+	// this to the externally-reachable URL.
+	DefaultVerificationURI = "http://localhost:8890/device"
+
+	// DefaultInterval is the default minimum polling interval, in seconds, a
+	// client must wait between /token requests for the same device_code,
+	// per RFC 8628 §3.2.
+	DefaultInterval = 5
+)
+
+// Config holds configuration for the device authorization grant server.
+type Config struct {
+	// Enabled enables the device authorization grant HTTP server.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Address is the HTTP listen address for /device/code, /token, and /device.
+	// Default: ":8890"
+	Address string `json:"address,omitempty" mapstructure:"address"`
+
+	// VerificationURI is the user-facing approval page URL returned as
+	// verification_uri (and, combined with the user code, as
+	// verification_uri_complete) in /device/code responses.
+	// Default: "http://localhost:8890/device"
+	VerificationURI string `json:"verification_uri,omitempty" mapstructure:"verification_uri"`
+
+	// Interval is the minimum number of seconds a client must wait between
+	// polls of the same device_code before the server returns slow_down.
+	// Default: 5
+	Interval int `json:"interval,omitempty" mapstructure:"interval"`
+}
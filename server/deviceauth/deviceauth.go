@@ -0,0 +1,126 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deviceauth implements an RFC 8628 OAuth 2.0 Device Authorization
+// Grant server: POST /device/code, POST /token, and GET /device (the
+// user-facing approval page). It lets headless agents (CLI tools, CI jobs,
+// anything without a browser of its own) obtain a bearer token for the dir
+// server by having a human approve a short user code on a second device.
+//
+// DeviceRequest and DeviceToken records are persisted in the same
+// types.Datastore used by the routing layer, keyed by device code and user
+// code so both /token polling and /device approval can look requests
+// up by whichever code they hold. Issued tokens are opaque (random,
+// server-generated) rather than JWTs; the /token/introspect endpoint lets
+// the Casbin-backed auth/authzserver (a separate process, fronting Store/
+// Routing RPCs as an Envoy ext_authz sidecar) resolve a bearer token back
+// into a username so its existing role-based policies apply uniformly,
+// regardless of which provider issued the token.
+package deviceauth
+
+import (
+	"errors"
+	"time"
+)
+
+// Status is the lifecycle state of a DeviceRequest, per RFC 8628 §3.5.
+type Status string
+
+const (
+	// StatusPending means the user has not yet approved or denied the request.
+	StatusPending Status = "pending"
+
+	// StatusApproved means a user approved the request; /token may now issue
+	// an access token for it.
+	StatusApproved Status = "approved"
+
+	// StatusDenied means a user explicitly denied the request.
+	StatusDenied Status = "denied"
+)
+
+// DefaultRequestTTL is used when the server is constructed with a zero
+// requestTTL (i.e. config.Expiry.DeviceRequests is unset).
+const DefaultRequestTTL = 15 * time.Minute
+
+// RFC 8628 §3.5 error codes, returned as the "error" field of a /token
+// error response.
+const (
+	errAuthorizationPending = "authorization_pending"
+	errSlowDown             = "slow_down"
+	errExpiredToken         = "expired_token"
+	errAccessDenied         = "access_denied"
+)
+
+// Sentinel errors returned by Store/Handler methods, mapped to the RFC 8628
+// error codes above at the HTTP layer.
+var (
+	// ErrNotFound means no DeviceRequest or DeviceToken exists for the given code.
+	ErrNotFound = errors.New("deviceauth: not found")
+
+	// ErrAuthorizationPending means the user has not yet approved or denied
+	// the request.
+	ErrAuthorizationPending = errors.New("deviceauth: authorization pending")
+
+	// ErrSlowDown means the client polled /token again before Interval
+	// seconds elapsed since its last poll for this device code.
+	ErrSlowDown = errors.New("deviceauth: slow down")
+
+	// ErrExpired means the device code or user code has passed its expiry.
+	ErrExpired = errors.New("deviceauth: request expired")
+
+	// ErrAccessDenied means a user explicitly denied the request.
+	ErrAccessDenied = errors.New("deviceauth: access denied")
+)
+
+// DeviceRequest tracks one in-flight device authorization grant, from the
+// initial POST /device/code through to user approval/denial or expiry.
+type DeviceRequest struct {
+	DeviceCode string `json:"device_code"`
+	UserCode   string `json:"user_code"`
+	ClientID   string `json:"client_id"`
+	Scope      string `json:"scope,omitempty"`
+
+	Status Status `json:"status"`
+
+	// Username is set once a user approves the request, identifying who the
+	// resulting DeviceToken authenticates as.
+	Username string `json:"username,omitempty"`
+
+	// Interval is the minimum number of seconds between /token polls for
+	// this device code, returned as interval in the /device/code response.
+	// slow_down responses do not carry it.
+	Interval int `json:"interval"`
+
+	// LastPolledAt records the last /token poll that passed the Interval
+	// check, used to enforce Interval. Polls answered slow_down leave it
+	// unchanged.
+	LastPolledAt time.Time `json:"last_polled_at,omitzero"`
+
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// expired reports whether the request has passed its ExpiresAt.
+func (r *DeviceRequest) expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && now.After(r.ExpiresAt)
+}
+
+// DeviceToken is an access token issued after a DeviceRequest is approved.
+// Tokens are opaque (random, server-generated) rather than JWTs; the
+// /token/introspect endpoint is how other components (the Casbin-backed
+// auth/authzserver in particular) resolve one back into a username.
+type DeviceToken struct {
+	Token    string `json:"token"`
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope,omitempty"`
+	Username string `json:"username"`
+
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// valid reports whether the token has not passed its ExpiresAt (a zero
+// ExpiresAt means the token does not expire).
+func (t *DeviceToken) valid(now time.Time) bool {
+	return t.ExpiresAt.IsZero() || now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,137 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package deviceauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	deviceauthconfig "github.com/agntcy/dir/server/deviceauth/config"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() deviceauthconfig.Config {
+	return deviceauthconfig.Config{
+		Address:         ":0",
+		VerificationURI: "http://localhost/device",
+		Interval:        5,
+	}
+}
+
+func TestStore_RequestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(datastore.NewMapDatastore())
+
+	req := &DeviceRequest{
+		DeviceCode: "device-123",
+		UserCode:   "WDJB-MJHT",
+		ClientID:   "test-client",
+		Status:     StatusPending,
+		Interval:   5,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(time.Minute),
+	}
+
+	require.NoError(t, s.saveRequest(ctx, req))
+
+	byDeviceCode, err := s.getRequestByDeviceCode(ctx, "device-123")
+	require.NoError(t, err)
+	assert.Equal(t, req.UserCode, byDeviceCode.UserCode)
+
+	byUserCode, err := s.getRequestByUserCode(ctx, "WDJB-MJHT")
+	require.NoError(t, err)
+	assert.Equal(t, req.DeviceCode, byUserCode.DeviceCode)
+
+	require.NoError(t, s.deleteRequest(ctx, req))
+
+	_, err = s.getRequestByDeviceCode(ctx, "device-123")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestExchangeDeviceCode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pending request returns authorization_pending", func(t *testing.T) {
+		srv := New(datastore.NewMapDatastore(), testConfig(), time.Minute)
+		req := newTestRequest(StatusPending)
+		require.NoError(t, srv.store.saveRequest(ctx, req))
+
+		_, err := srv.exchangeDeviceCode(ctx, req.DeviceCode)
+		assert.ErrorIs(t, err, ErrAuthorizationPending)
+	})
+
+	t.Run("denied request returns access_denied and is removed", func(t *testing.T) {
+		srv := New(datastore.NewMapDatastore(), testConfig(), time.Minute)
+		req := newTestRequest(StatusDenied)
+		require.NoError(t, srv.store.saveRequest(ctx, req))
+
+		_, err := srv.exchangeDeviceCode(ctx, req.DeviceCode)
+		assert.ErrorIs(t, err, ErrAccessDenied)
+
+		_, err = srv.store.getRequestByDeviceCode(ctx, req.DeviceCode)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("approved request issues a token", func(t *testing.T) {
+		srv := New(datastore.NewMapDatastore(), testConfig(), time.Minute)
+		req := newTestRequest(StatusApproved)
+		req.Username = "octocat"
+		require.NoError(t, srv.store.saveRequest(ctx, req))
+
+		token, err := srv.exchangeDeviceCode(ctx, req.DeviceCode)
+		require.NoError(t, err)
+		assert.Equal(t, "octocat", token.Username)
+		assert.NotEmpty(t, token.Token)
+	})
+
+	t.Run("unknown device code returns expired_token", func(t *testing.T) {
+		srv := New(datastore.NewMapDatastore(), testConfig(), time.Minute)
+
+		_, err := srv.exchangeDeviceCode(ctx, "does-not-exist")
+		assert.ErrorIs(t, err, ErrExpired)
+	})
+
+	t.Run("expired request returns expired_token", func(t *testing.T) {
+		srv := New(datastore.NewMapDatastore(), testConfig(), time.Minute)
+		req := newTestRequest(StatusPending)
+		req.ExpiresAt = time.Now().Add(-time.Minute)
+		require.NoError(t, srv.store.saveRequest(ctx, req))
+
+		_, err := srv.exchangeDeviceCode(ctx, req.DeviceCode)
+		assert.ErrorIs(t, err, ErrExpired)
+	})
+
+	t.Run("polling faster than interval returns slow_down", func(t *testing.T) {
+		srv := New(datastore.NewMapDatastore(), testConfig(), time.Minute)
+		req := newTestRequest(StatusPending)
+		req.LastPolledAt = time.Now()
+		req.Interval = 60
+		require.NoError(t, srv.store.saveRequest(ctx, req))
+
+		_, err := srv.exchangeDeviceCode(ctx, req.DeviceCode)
+		assert.ErrorIs(t, err, ErrSlowDown)
+	})
+}
+
+func TestGenerateUserCode(t *testing.T) {
+	code, err := generateUserCode()
+	require.NoError(t, err)
+	assert.Len(t, code, userCodeGroupLen*userCodeGroups+(userCodeGroups-1))
+	assert.Contains(t, code, "-")
+}
+
+func newTestRequest(status Status) *DeviceRequest {
+	return &DeviceRequest{
+		DeviceCode: "device-" + string(status),
+		UserCode:   "USER-" + string(status),
+		ClientID:   "test-client",
+		Status:     status,
+		Interval:   5,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+}
@@ -0,0 +1,480 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package deviceauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"time"
+
+	deviceauthconfig "github.com/agntcy/dir/server/deviceauth/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+)
+
+var logger = logging.Logger("deviceauth")
+
+// httpReadHeaderTimeout bounds how long the server waits for request
+// headers, matching the internal admin server's hardening.
+const httpReadHeaderTimeout = 5 * time.Second
+
+// usernameHeader is set by the Envoy ext_authz sidecar (auth/authzserver's
+// allowResponse) once a request's Authorization header has been validated
+// against an upstream identity provider. The /device approval page trusts
+// it as the identity of whoever is approving a pending request, so the
+// same authentication this server already requires for Store/Routing RPCs
+// also gates who may approve a device code.
+const usernameHeader = "x-username"
+
+// Server hosts the device authorization grant HTTP endpoints: POST
+// /device/code, POST /token, GET and POST /device, and POST
+// /token/introspect.
+type Server struct {
+	cfg        deviceauthconfig.Config
+	store      *store
+	requestTTL time.Duration
+	httpServer *http.Server
+}
+
+// New creates a device authorization grant server backed by dstore, the
+// same types.Datastore the routing layer persists label metadata in.
+// requestTTL bounds how long a device_code/user_code pair remains valid
+// before /token returns expired_token (config.Expiry.DeviceRequests).
+func New(dstore types.Datastore, cfg deviceauthconfig.Config, requestTTL time.Duration) *Server {
+	if cfg.Address == "" {
+		cfg.Address = deviceauthconfig.DefaultAddress
+	}
+
+	if cfg.VerificationURI == "" {
+		cfg.VerificationURI = deviceauthconfig.DefaultVerificationURI
+	}
+
+	if cfg.Interval == 0 {
+		cfg.Interval = deviceauthconfig.DefaultInterval
+	}
+
+	if requestTTL == 0 {
+		requestTTL = DefaultRequestTTL
+	}
+
+	s := &Server{
+		cfg:        cfg,
+		store:      newStore(dstore),
+		requestTTL: requestTTL,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device/code", s.handleDeviceCode)
+	mux.HandleFunc("/token", s.handleToken)
+	mux.HandleFunc("/token/introspect", s.handleIntrospect)
+	mux.HandleFunc("/device", s.handleDevicePage)
+
+	s.httpServer = &http.Server{
+		Addr:              cfg.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+	}
+
+	return s
+}
+
+// Start starts the HTTP server in the background.
+func (s *Server) Start() error {
+	go func() {
+		logger.Info("Device authorization server starting", "address", s.cfg.Address)
+
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Device authorization server error", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully shuts down the HTTP server.
+func (s *Server) Stop(ctx context.Context) error {
+	logger.Info("Stopping device authorization server", "address", s.cfg.Address)
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown device authorization server: %w", err)
+	}
+
+	return nil
+}
+
+// deviceCodeResponse is the RFC 8628 §3.2 device authorization response.
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+func (s *Server) handleDeviceCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, http.StatusBadRequest, "invalid_request", "failed to parse request")
+
+		return
+	}
+
+	clientID := r.FormValue("client_id")
+	if clientID == "" {
+		writeTokenError(w, http.StatusBadRequest, "invalid_request", "client_id is required")
+
+		return
+	}
+
+	deviceCode, err := generateDeviceCode()
+	if err != nil {
+		http.Error(w, "failed to generate device code", http.StatusInternalServerError)
+
+		return
+	}
+
+	userCode, err := generateUserCode()
+	if err != nil {
+		http.Error(w, "failed to generate user code", http.StatusInternalServerError)
+
+		return
+	}
+
+	now := time.Now()
+
+	req := &DeviceRequest{
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		ClientID:   clientID,
+		Scope:      r.FormValue("scope"),
+		Status:     StatusPending,
+		Interval:   s.cfg.Interval,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(s.requestTTL),
+	}
+
+	if err := s.store.saveRequest(r.Context(), req); err != nil {
+		logger.Error("failed to save device request", "error", err)
+		http.Error(w, "failed to save device request", http.StatusInternalServerError)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deviceCodeResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         s.cfg.VerificationURI,
+		VerificationURIComplete: s.cfg.VerificationURI + "?user_code=" + userCode,
+		ExpiresIn:               int(s.requestTTL.Seconds()),
+		Interval:                s.cfg.Interval,
+	})
+}
+
+// tokenResponse is the RFC 6749 §5.1 access token response.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// deviceGrantType is the grant_type value RFC 8628 §3.4 defines for
+// exchanging a device_code for an access token.
+const deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, http.StatusBadRequest, "invalid_request", "failed to parse request")
+
+		return
+	}
+
+	if r.FormValue("grant_type") != deviceGrantType {
+		writeTokenError(w, http.StatusBadRequest, "unsupported_grant_type", "grant_type must be "+deviceGrantType)
+
+		return
+	}
+
+	deviceCode := r.FormValue("device_code")
+	if deviceCode == "" {
+		writeTokenError(w, http.StatusBadRequest, "invalid_request", "device_code is required")
+
+		return
+	}
+
+	token, err := s.exchangeDeviceCode(r.Context(), deviceCode)
+	if err != nil {
+		code, desc := tokenErrorFor(err)
+		writeTokenError(w, http.StatusBadRequest, code, desc)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken: token.Token,
+		TokenType:   "bearer",
+		Scope:       token.Scope,
+	})
+}
+
+// exchangeDeviceCode implements the RFC 8628 §3.5 polling state machine: it
+// enforces the minimum poll interval, rejects expired or denied requests,
+// and issues a DeviceToken once the request has been approved.
+func (s *Server) exchangeDeviceCode(ctx context.Context, deviceCode string) (*DeviceToken, error) {
+	req, err := s.store.getRequestByDeviceCode(ctx, deviceCode)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrExpired
+		}
+
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if req.expired(now) {
+		_ = s.store.deleteRequest(ctx, req)
+
+		return nil, ErrExpired
+	}
+
+	minInterval := time.Duration(req.Interval) * time.Second
+	if !req.LastPolledAt.IsZero() && now.Sub(req.LastPolledAt) < minInterval {
+		return nil, ErrSlowDown
+	}
+
+	req.LastPolledAt = now
+
+	switch req.Status {
+	case StatusDenied:
+		_ = s.store.deleteRequest(ctx, req)
+
+		return nil, ErrAccessDenied
+	case StatusPending:
+		if err := s.store.saveRequest(ctx, req); err != nil {
+			return nil, err
+		}
+
+		return nil, ErrAuthorizationPending
+	case StatusApproved:
+		// fall through to token issuance below
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	deviceToken := &DeviceToken{
+		Token:     token,
+		ClientID:  req.ClientID,
+		Scope:     req.Scope,
+		Username:  req.Username,
+		CreatedAt: now,
+	}
+
+	if err := s.store.saveToken(ctx, deviceToken); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.deleteRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return deviceToken, nil
+}
+
+// tokenErrorFor maps a state-machine error to the RFC 8628 §3.5 "error" code
+// and a human-readable description for the /token response body.
+func tokenErrorFor(err error) (code, description string) {
+	switch {
+	case errors.Is(err, ErrAuthorizationPending):
+		return errAuthorizationPending, "the user has not yet approved or denied this request"
+	case errors.Is(err, ErrSlowDown):
+		return errSlowDown, "polling too frequently, increase your interval"
+	case errors.Is(err, ErrExpired):
+		return errExpiredToken, "the device_code has expired, restart the device flow"
+	case errors.Is(err, ErrAccessDenied):
+		return errAccessDenied, "the user denied this request"
+	default:
+		return "server_error", "an internal error occurred"
+	}
+}
+
+// introspectResponse is a minimal RFC 7662-style token introspection
+// response, used by the Casbin-backed auth/authzserver to resolve an opaque
+// device-flow access token back into a username.
+type introspectResponse struct {
+	Active   bool   `json:"active"`
+	Username string `json:"username,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+}
+
+func (s *Server) handleIntrospect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "failed to parse request", http.StatusBadRequest)
+
+		return
+	}
+
+	token, err := s.store.getToken(r.Context(), r.FormValue("token"))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			writeJSON(w, http.StatusOK, introspectResponse{Active: false})
+
+			return
+		}
+
+		http.Error(w, "failed to look up token", http.StatusInternalServerError)
+
+		return
+	}
+
+	if !token.valid(time.Now()) {
+		writeJSON(w, http.StatusOK, introspectResponse{Active: false})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, introspectResponse{
+		Active:   true,
+		Username: token.Username,
+		ClientID: token.ClientID,
+		Scope:    token.Scope,
+	})
+}
+
+// handleDevicePage serves the user-facing approval page: GET renders a form
+// pre-filled with user_code (if provided as a query parameter), and POST
+// approves or denies the corresponding DeviceRequest on behalf of the
+// caller identified by usernameHeader.
+func (s *Server) handleDevicePage(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.renderDevicePage(w, r.URL.Query().Get("user_code"), "")
+	case http.MethodPost:
+		s.handleDeviceApproval(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleDeviceApproval(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "failed to parse request", http.StatusBadRequest)
+
+		return
+	}
+
+	userCode := r.FormValue("user_code")
+
+	// username must come from the trusted header set by the Envoy ext_authz
+	// sidecar - never from a form field on the request being authenticated,
+	// or any caller could approve a code as an arbitrary username.
+	username := r.Header.Get(usernameHeader)
+	if username == "" {
+		s.renderDevicePage(w, userCode, "You must be signed in to approve a device.")
+
+		return
+	}
+
+	req, err := s.store.getRequestByUserCode(r.Context(), userCode)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			s.renderDevicePage(w, userCode, "Unknown or expired code.")
+
+			return
+		}
+
+		http.Error(w, "failed to look up device request", http.StatusInternalServerError)
+
+		return
+	}
+
+	if req.expired(time.Now()) {
+		_ = s.store.deleteRequest(r.Context(), req)
+		s.renderDevicePage(w, userCode, "That code has expired.")
+
+		return
+	}
+
+	if r.FormValue("action") == "deny" {
+		req.Status = StatusDenied
+	} else {
+		req.Status = StatusApproved
+		req.Username = username
+	}
+
+	if err := s.store.saveRequest(r.Context(), req); err != nil {
+		http.Error(w, "failed to save device request", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	if req.Status == StatusApproved {
+		_, _ = fmt.Fprint(w, "<html><body><p>Device approved. You may close this page.</p></body></html>")
+	} else {
+		_, _ = fmt.Fprint(w, "<html><body><p>Device denied.</p></body></html>")
+	}
+}
+
+func (s *Server) renderDevicePage(w http.ResponseWriter, userCode, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	if message != "" {
+		message = "<p>" + html.EscapeString(message) + "</p>"
+	}
+
+	fmt.Fprintf(w, `<html><body>
+%s
+<form method="post" action="/device">
+  <input type="text" name="user_code" value="%s" placeholder="XXXX-XXXX" />
+  <button type="submit" name="action" value="approve">Approve</button>
+  <button type="submit" name="action" value="deny">Deny</button>
+</form>
+</body></html>`, message, html.EscapeString(userCode))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func writeTokenError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
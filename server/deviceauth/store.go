@@ -0,0 +1,121 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package deviceauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// Datastore key layout. A request is indexed twice - once by device code
+// (used by the polling client) and once by user code (used by the /device
+// approval page) - both pointing at independently-serialized copies of the
+// same DeviceRequest, kept in sync by store methods.
+const (
+	deviceRequestsByDeviceCodePrefix = "/deviceauth/requests/by-device-code/"
+	deviceRequestsByUserCodePrefix   = "/deviceauth/requests/by-user-code/"
+	deviceTokensPrefix               = "/deviceauth/tokens/"
+)
+
+// store persists DeviceRequest and DeviceToken records in the server's
+// shared types.Datastore, the same store the routing layer uses for label
+// metadata.
+type store struct {
+	dstore types.Datastore
+}
+
+func newStore(dstore types.Datastore) *store {
+	return &store{dstore: dstore}
+}
+
+func (s *store) saveRequest(ctx context.Context, req *DeviceRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal device request: %w", err)
+	}
+
+	if err := s.dstore.Put(ctx, datastore.NewKey(deviceRequestsByDeviceCodePrefix+req.DeviceCode), data); err != nil {
+		return fmt.Errorf("failed to save device request by device code: %w", err)
+	}
+
+	if err := s.dstore.Put(ctx, datastore.NewKey(deviceRequestsByUserCodePrefix+req.UserCode), data); err != nil {
+		return fmt.Errorf("failed to save device request by user code: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) getRequestByDeviceCode(ctx context.Context, deviceCode string) (*DeviceRequest, error) {
+	return s.getRequest(ctx, deviceRequestsByDeviceCodePrefix+deviceCode)
+}
+
+func (s *store) getRequestByUserCode(ctx context.Context, userCode string) (*DeviceRequest, error) {
+	return s.getRequest(ctx, deviceRequestsByUserCodePrefix+userCode)
+}
+
+func (s *store) getRequest(ctx context.Context, key string) (*DeviceRequest, error) {
+	data, err := s.dstore.Get(ctx, datastore.NewKey(key))
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get device request: %w", err)
+	}
+
+	var req DeviceRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal device request: %w", err)
+	}
+
+	return &req, nil
+}
+
+func (s *store) deleteRequest(ctx context.Context, req *DeviceRequest) error {
+	if err := s.dstore.Delete(ctx, datastore.NewKey(deviceRequestsByDeviceCodePrefix+req.DeviceCode)); err != nil {
+		return fmt.Errorf("failed to delete device request by device code: %w", err)
+	}
+
+	if err := s.dstore.Delete(ctx, datastore.NewKey(deviceRequestsByUserCodePrefix+req.UserCode)); err != nil {
+		return fmt.Errorf("failed to delete device request by user code: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) saveToken(ctx context.Context, token *DeviceToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal device token: %w", err)
+	}
+
+	if err := s.dstore.Put(ctx, datastore.NewKey(deviceTokensPrefix+token.Token), data); err != nil {
+		return fmt.Errorf("failed to save device token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) getToken(ctx context.Context, token string) (*DeviceToken, error) {
+	data, err := s.dstore.Get(ctx, datastore.NewKey(deviceTokensPrefix+token))
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get device token: %w", err)
+	}
+
+	var tok DeviceToken
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal device token: %w", err)
+	}
+
+	return &tok, nil
+}
@@ -6,6 +6,7 @@ package healthcheck
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -53,6 +54,20 @@ func (c *Checker) Register(grpcServer *grpc.Server) {
 	logger.Info("Registered gRPC health service")
 }
 
+// Check returns the current overall serving status, as reported to
+// grpc.health.v1.Health for the default (whole-server) service name.
+// This lets HTTP-facing callers (e.g. the internal admin server's
+// /healthz and /readyz endpoints) bridge into the same health state
+// without depending on the gRPC health client.
+func (c *Checker) Check(ctx context.Context) (grpc_health_v1.HealthCheckResponse_ServingStatus, error) {
+	resp, err := c.healthServer.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return grpc_health_v1.HealthCheckResponse_UNKNOWN, fmt.Errorf("failed to check health: %w", err)
+	}
+
+	return resp.GetStatus(), nil
+}
+
 // Start starts the health check monitoring.
 // It periodically checks all registered readiness checks and updates the health status.
 func (c *Checker) Start(ctx context.Context) error {
@@ -0,0 +1,183 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package admin provides a dedicated internal HTTP server for operational
+// endpoints (metrics, profiling, health, build info) that must never be
+// exposed on the same port as the user-facing gRPC API.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	adminconfig "github.com/agntcy/dir/server/internal/admin/config"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var logger = logging.Logger("admin")
+
+const (
+	// metricsCollectionTimeout is the timeout for collecting metrics.
+	metricsCollectionTimeout = 10 * time.Second
+
+	// httpReadHeaderTimeout is the maximum duration for reading request headers.
+	httpReadHeaderTimeout = 5 * time.Second
+
+	// serverStartupDelay is used to detect immediate listen errors after Start.
+	serverStartupDelay = 100 * time.Millisecond
+)
+
+// HealthChecker reports the current serving status, bridging into the
+// server's grpc.health.v1.Health implementation.
+type HealthChecker interface {
+	Check(ctx context.Context) (grpc_health_v1.HealthCheckResponse_ServingStatus, error)
+}
+
+// Server is the internal admin HTTP server. It hosts /metrics,
+// /debug/pprof/*, /healthz, /readyz, and /buildinfo on a port separate
+// from the user-facing gRPC listener.
+type Server struct {
+	cfg        adminconfig.Config
+	httpServer *http.Server
+}
+
+// New creates a new internal admin server.
+//
+// registry is the Prometheus registry to expose on /metrics (typically the
+// same registry gRPC interceptors report to). health bridges /healthz and
+// /readyz into the server's grpc.health.v1.Health status. buildInfo is
+// served verbatim as JSON on /buildinfo.
+func New(cfg adminconfig.Config, registry *prometheus.Registry, health HealthChecker, buildInfo BuildInfo) *Server {
+	mux := http.NewServeMux()
+
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+		Timeout:           metricsCollectionTimeout,
+	}))
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	mux.HandleFunc("/healthz", newHealthzHandler(health, false))
+	mux.HandleFunc("/readyz", newHealthzHandler(health, true))
+
+	mux.HandleFunc("/buildinfo", newBuildInfoHandler(buildInfo))
+
+	var handler http.Handler = mux
+	if cfg.BasicAuth.Enabled() {
+		handler = withBasicAuth(cfg.BasicAuth.Username, cfg.BasicAuth.Password, handler)
+	}
+
+	return &Server{
+		cfg: cfg,
+		httpServer: &http.Server{
+			Addr:              cfg.Address,
+			Handler:           handler,
+			ReadHeaderTimeout: httpReadHeaderTimeout,
+		},
+	}
+}
+
+// Start starts the HTTP server in the background.
+func (s *Server) Start() error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		logger.Info("Admin server starting", "address", s.cfg.Address, "tls", s.cfg.TLS.Enabled())
+
+		var err error
+		if s.cfg.TLS.Enabled() {
+			err = s.httpServer.ListenAndServeTLS(s.cfg.TLS.CertFile, s.cfg.TLS.KeyFile)
+		} else {
+			err = s.httpServer.ListenAndServe()
+		}
+
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error("Admin server error", "error", err)
+			errCh <- err
+		}
+	}()
+
+	// Give the server a moment to start and check for immediate errors
+	// (e.g., port already in use or bad TLS material).
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("admin server failed to start: %w", err)
+	case <-time.After(serverStartupDelay):
+	}
+
+	logger.Info("Admin server started successfully", "address", s.cfg.Address)
+
+	return nil
+}
+
+// Stop gracefully shuts down the HTTP server.
+func (s *Server) Stop(ctx context.Context) error {
+	logger.Info("Stopping admin server", "address", s.cfg.Address)
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown admin server: %w", err)
+	}
+
+	logger.Info("Admin server stopped successfully")
+
+	return nil
+}
+
+// withBasicAuth wraps handler with HTTP basic-auth enforcement, comparing
+// credentials in constant time to avoid leaking timing information.
+func withBasicAuth(username, password string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(user), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="dir admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// newHealthzHandler bridges the HTTP /healthz and /readyz endpoints into
+// grpc.health.v1.Health: 200 when SERVING, 503 otherwise. readiness and
+// liveness currently share the same underlying check.
+func newHealthzHandler(health HealthChecker, _ bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, err := health.Check(r.Context())
+		if err != nil || status != grpc_health_v1.HealthCheckResponse_SERVING {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(status.String()))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(status.String()))
+	}
+}
+
+// newBuildInfoHandler serves the given BuildInfo as JSON.
+func newBuildInfoHandler(info BuildInfo) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(info); err != nil {
+			logger.Error("Failed to encode buildinfo response", "error", err)
+		}
+	}
+}
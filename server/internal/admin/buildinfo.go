@@ -0,0 +1,54 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package admin
+
+import (
+	"runtime/debug"
+	"sort"
+)
+
+// BuildInfo describes the running binary for the /buildinfo endpoint.
+type BuildInfo struct {
+	// Version is the module version, e.g. from a git tag, or "(devel)"
+	// when built outside of a tagged release.
+	Version string `json:"version"`
+
+	// Commit is the VCS revision the binary was built from, if known.
+	Commit string `json:"commit,omitempty"`
+
+	// GoVersion is the Go toolchain version used to build the binary.
+	GoVersion string `json:"go_version"`
+
+	// Features lists the feature flags that are enabled on this server,
+	// e.g. "authn", "authz", "ratelimit". Sorted for stable output.
+	Features []string `json:"enabled_features"`
+}
+
+// NewBuildInfo collects build metadata from the Go runtime and combines it
+// with the set of feature flags enabled by the running configuration.
+func NewBuildInfo(features []string) BuildInfo {
+	info := BuildInfo{
+		Version:   "(unknown)",
+		GoVersion: "(unknown)",
+		Features:  append([]string{}, features...),
+	}
+
+	sort.Strings(info.Features)
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.Version = buildInfo.Main.Version
+	info.GoVersion = buildInfo.GoVersion
+
+	for _, setting := range buildInfo.Settings {
+		if setting.Key == "vcs.revision" {
+			info.Commit = setting.Value
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,66 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config provides configuration for the internal admin server.
+package config
+
+const (
+	// DefaultEnabled enables the internal admin server.
+	DefaultEnabled = true
+
+	// DefaultAddress is the default listen address for the internal admin server.
+	// This is intentionally separate from the user-facing gRPC ListenAddress so
+	// that pprof, health, and build info are never reachable from the same port
+	// agents use to talk to the directory.
+	DefaultAddress = ":8889"
+)
+
+// Config holds configuration for the internal admin server.
+// The admin server hosts operational endpoints (/metrics, /debug/pprof/*,
+// /healthz, /readyz, /buildinfo) on a dedicated port, separate from the
+// user-facing gRPC listener.
+type Config struct {
+	// Enabled enables the internal admin server.
+	// Default: true
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Address is the HTTP listen address for the internal admin server.
+	// Default: ":8889"
+	Address string `json:"address,omitempty" mapstructure:"address"`
+
+	// TLS holds optional TLS configuration for the admin server.
+	// If CertFile and KeyFile are both set, the server is served over HTTPS.
+	TLS TLSConfig `json:"tls" mapstructure:"tls"`
+
+	// BasicAuth holds optional HTTP basic-auth configuration for the admin server.
+	// If Username and Password are both set, all endpoints require basic auth.
+	BasicAuth BasicAuthConfig `json:"basic_auth" mapstructure:"basic_auth"`
+}
+
+// TLSConfig holds TLS certificate configuration for the admin server.
+type TLSConfig struct {
+	// CertFile is the path to the PEM-encoded TLS certificate.
+	CertFile string `json:"cert_file,omitempty" mapstructure:"cert_file"`
+
+	// KeyFile is the path to the PEM-encoded TLS private key.
+	KeyFile string `json:"key_file,omitempty" mapstructure:"key_file"`
+}
+
+// Enabled reports whether TLS is configured for the admin server.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// BasicAuthConfig holds HTTP basic-auth credentials for the admin server.
+type BasicAuthConfig struct {
+	// Username is the expected basic-auth username.
+	Username string `json:"username,omitempty" mapstructure:"username"`
+
+	// Password is the expected basic-auth password.
+	Password string `json:"password,omitempty" mapstructure:"password"`
+}
+
+// Enabled reports whether basic auth is configured for the admin server.
+func (c BasicAuthConfig) Enabled() bool {
+	return c.Username != "" && c.Password != ""
+}
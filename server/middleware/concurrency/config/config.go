@@ -0,0 +1,77 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config provides configuration for the concurrency limiting middleware.
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// Config holds concurrency limiting configuration.
+type Config struct {
+	// Enabled controls whether concurrency limiting is enforced.
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// GlobalMaxInflight is the maximum number of concurrent RPCs allowed for
+	// unauthenticated clients. Zero disables the global limit.
+	GlobalMaxInflight int `json:"global_max_inflight,omitempty" mapstructure:"global_max_inflight"`
+
+	// PerClientMaxInflight is the maximum number of concurrent RPCs allowed
+	// per SPIFFE ID. Zero disables per-client limiting.
+	PerClientMaxInflight int `json:"per_client_max_inflight,omitempty" mapstructure:"per_client_max_inflight"`
+
+	// MaxQueueWait is how long a request blocks waiting for a free slot once
+	// the applicable limit is full, before failing with ResourceExhausted.
+	// Zero fails fast instead of queueing.
+	MaxQueueWait time.Duration `json:"max_queue_wait,omitempty" mapstructure:"max_queue_wait"`
+
+	// MethodLimits overrides the in-flight cap and queue wait for specific
+	// gRPC full method names, for methods (e.g. large Push/Pull streams)
+	// that need a tighter bound than the per-client default.
+	MethodLimits map[string]MethodLimit `json:"method_limits,omitempty" mapstructure:"method_limits"`
+}
+
+// MethodLimit defines a per-method concurrency limit override.
+type MethodLimit struct {
+	// MaxInflight is the maximum number of concurrent RPCs for this method,
+	// combined across client and method (see semaphoreForRequest). Zero
+	// disables the limit for this method.
+	MaxInflight int `json:"max_inflight,omitempty" mapstructure:"max_inflight"`
+
+	// MaxQueueWait is how long a request to this method blocks waiting for a
+	// free slot before failing. Zero fails fast instead of queueing.
+	MaxQueueWait time.Duration `json:"max_queue_wait,omitempty" mapstructure:"max_queue_wait"`
+}
+
+// Validate checks that the configuration is internally consistent.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.GlobalMaxInflight < 0 {
+		return errors.New("global_max_inflight must not be negative")
+	}
+
+	if c.PerClientMaxInflight < 0 {
+		return errors.New("per_client_max_inflight must not be negative")
+	}
+
+	if c.MaxQueueWait < 0 {
+		return errors.New("max_queue_wait must not be negative")
+	}
+
+	for method, limit := range c.MethodLimits {
+		if limit.MaxInflight < 0 {
+			return errors.New("method_limits[" + method + "].max_inflight must not be negative")
+		}
+
+		if limit.MaxQueueWait < 0 {
+			return errors.New("method_limits[" + method + "].max_queue_wait must not be negative")
+		}
+	}
+
+	return nil
+}
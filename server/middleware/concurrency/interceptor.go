@@ -0,0 +1,79 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package concurrency
+
+import (
+	"context"
+
+	"github.com/agntcy/dir/server/authn"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a gRPC unary server interceptor that performs
+// concurrency limiting. It extracts the client identity from the context
+// (SPIFFE ID if authenticated), admits the RPC using the provided
+// ClientLimiter, and returns a ResourceExhausted error if no slot is
+// available within the applicable MaxQueueWait.
+func UnaryServerInterceptor(limiter *ClientLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		clientID := extractClientID(ctx)
+
+		release, ok := limiter.Acquire(ctx, clientID, info.FullMethod)
+		if !ok {
+			logger.Warn("Concurrency limit exceeded",
+				"client_id", clientID,
+				"method", info.FullMethod,
+			)
+
+			return nil, status.Error(codes.ResourceExhausted, "concurrency limit exceeded")
+		}
+		defer release()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC stream server interceptor that
+// performs concurrency limiting. It extracts the client identity from the
+// context (SPIFFE ID if authenticated), admits the stream using the provided
+// ClientLimiter, and returns a ResourceExhausted error if no slot is
+// available within the applicable MaxQueueWait.
+//
+// Unlike rate limiting, the slot is held for the entire lifetime of the
+// stream (released only when handler returns), since the whole point is to
+// bound how many slow, long-lived streams (large Push/Pull transfers) can be
+// in flight at once.
+func StreamServerInterceptor(limiter *ClientLimiter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		clientID := extractClientID(ctx)
+
+		release, ok := limiter.Acquire(ctx, clientID, info.FullMethod)
+		if !ok {
+			logger.Warn("Concurrency limit exceeded",
+				"client_id", clientID,
+				"method", info.FullMethod,
+			)
+
+			return status.Error(codes.ResourceExhausted, "concurrency limit exceeded")
+		}
+		defer release()
+
+		return handler(srv, ss)
+	}
+}
+
+// extractClientID extracts the client identifier from the gRPC context.
+// It returns the SPIFFE ID string if the client is authenticated via authn
+// middleware, or an empty string for unauthenticated clients (which will use
+// the global concurrency limit).
+func extractClientID(ctx context.Context) string {
+	if spiffeID, ok := authn.SpiffeIDFromContext(ctx); ok {
+		return spiffeID.String()
+	}
+
+	return ""
+}
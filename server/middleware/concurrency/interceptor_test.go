@@ -0,0 +1,236 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package concurrency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/middleware/concurrency/config"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const successResponse = "success"
+
+// TestUnaryServerInterceptor_AllowsRequestWhenUnderLimit tests that requests
+// are allowed when under the concurrency limit.
+func TestUnaryServerInterceptor_AllowsRequestWhenUnderLimit(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              true,
+		PerClientMaxInflight: 10,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	interceptor := UnaryServerInterceptor(limiter)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return successResponse, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	resp, err := interceptor(context.Background(), "request", info, handler)
+	if err != nil {
+		t.Errorf("Interceptor should allow request under concurrency limit, got error: %v", err)
+	}
+
+	if resp != successResponse {
+		t.Errorf("Expected response %q, got: %v", successResponse, resp)
+	}
+}
+
+// TestUnaryServerInterceptor_RejectsRequestWhenLimitExceeded tests that
+// requests are rejected with ResourceExhausted once the limit is exhausted
+// and the handler is still running.
+func TestUnaryServerInterceptor_RejectsRequestWhenLimitExceeded(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              true,
+		PerClientMaxInflight: 1,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	interceptor := UnaryServerInterceptor(limiter)
+
+	blockCh := make(chan struct{})
+	handlerEntered := make(chan struct{})
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		close(handlerEntered)
+		<-blockCh
+
+		return successResponse, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := interceptor(context.Background(), "request1", info, handler)
+		done <- err
+	}()
+
+	<-handlerEntered
+
+	// Second request should be rejected while the first is still in flight.
+	_, err = interceptor(context.Background(), "request2", info, handler)
+	if err == nil {
+		t.Error("second request should be rejected while the first is in flight")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got: %v", err)
+	}
+
+	close(blockCh)
+
+	if err := <-done; err != nil {
+		t.Errorf("first request should have succeeded, got error: %v", err)
+	}
+}
+
+// TestUnaryServerInterceptor_DisabledConcurrencyLimiting tests that when
+// concurrency limiting is disabled, all requests are allowed.
+func TestUnaryServerInterceptor_DisabledConcurrencyLimiting(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              false,
+		PerClientMaxInflight: 1,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	interceptor := UnaryServerInterceptor(limiter)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return successResponse, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	for range 10 {
+		if _, err := interceptor(context.Background(), "request", info, handler); err != nil {
+			t.Errorf("request should succeed when concurrency limiting is disabled, got error: %v", err)
+		}
+	}
+}
+
+// TestStreamServerInterceptor_HoldsSlotForStreamLifetime tests that a stream
+// holds its concurrency slot until the handler returns, not just until the
+// interceptor call returns.
+func TestStreamServerInterceptor_HoldsSlotForStreamLifetime(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              true,
+		PerClientMaxInflight: 1,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	interceptor := StreamServerInterceptor(limiter)
+
+	blockCh := make(chan struct{})
+	handlerEntered := make(chan struct{})
+
+	handler := func(srv any, stream grpc.ServerStream) error {
+		close(handlerEntered)
+		<-blockCh
+
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/StreamMethod"}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- interceptor(nil, newMockServerStream(context.Background()), info, handler)
+	}()
+
+	<-handlerEntered
+
+	err = interceptor(nil, newMockServerStream(context.Background()), info, handler)
+	if err == nil {
+		t.Error("second stream should be rejected while the first is still in flight")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got: %v", err)
+	}
+
+	close(blockCh)
+
+	if err := <-done; err != nil {
+		t.Errorf("first stream should have succeeded, got error: %v", err)
+	}
+}
+
+// TestExtractClientID_WithAuthentication tests client ID extraction for
+// authenticated requests with SPIFFE ID in context.
+func TestExtractClientID_WithAuthentication(t *testing.T) {
+	spiffeID, err := spiffeid.FromString("spiffe://example.org/service/client1")
+	if err != nil {
+		t.Fatalf("Failed to create SPIFFE ID: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), authn.SpiffeIDContextKey, spiffeID)
+
+	clientID := extractClientID(ctx)
+
+	expected := "spiffe://example.org/service/client1"
+	if clientID != expected {
+		t.Errorf("Expected client ID %q, got %q", expected, clientID)
+	}
+}
+
+// TestExtractClientID_WithoutAuthentication tests client ID extraction for
+// unauthenticated requests (should return empty string).
+func TestExtractClientID_WithoutAuthentication(t *testing.T) {
+	clientID := extractClientID(context.Background())
+	if clientID != "" {
+		t.Errorf("Expected empty client ID for unauthenticated request, got %q", clientID)
+	}
+}
+
+// mockServerStream is a mock implementation of grpc.ServerStream for testing.
+// It returns a specific context without storing it as a field to avoid containedctx linter issues.
+type mockServerStream struct {
+	grpc.ServerStream
+	contextFunc func() context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	if m.contextFunc != nil {
+		return m.contextFunc()
+	}
+
+	return context.Background()
+}
+
+// newMockServerStream creates a mockServerStream with the given context.
+func newMockServerStream(ctx context.Context) *mockServerStream {
+	return &mockServerStream{
+		contextFunc: func() context.Context {
+			return ctx
+		},
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/middleware/concurrency/config"
+	"github.com/agntcy/dir/utils/logging"
+)
+
+var logger = logging.Logger("concurrency")
+
+// semaphore bounds the number of concurrent holders to capacity using a
+// buffered channel as a counting semaphore: a send acquires a slot, a
+// receive releases it.
+type semaphore struct {
+	slots chan struct{}
+}
+
+// newSemaphore creates a semaphore with the given capacity.
+func newSemaphore(capacity int) *semaphore {
+	return &semaphore{slots: make(chan struct{}, capacity)}
+}
+
+// tryAcquire acquires a slot without blocking. It reports whether a slot was
+// available.
+func (s *semaphore) tryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// acquireWait blocks up to wait (or until ctx is done) for a slot to free up.
+// It reports whether a slot was acquired.
+func (s *semaphore) acquireWait(ctx context.Context, wait time.Duration) bool {
+	if wait <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees the slot acquired by tryAcquire/acquireWait.
+func (s *semaphore) release() {
+	<-s.slots
+}
+
+// ClientLimiter implements per-client, per-method concurrency limiting.
+// Unlike ratelimit.ClientLimiter, which bounds the rate of admission, it
+// bounds how many RPCs for a given client/method may be in flight at once -
+// the control that protects against a small number of very slow requests
+// (large Push/Pull streams) exhausting server resources, which an RPS limit
+// alone cannot catch.
+//
+// Thread Safety:
+// ClientLimiter is safe for concurrent use by multiple goroutines. It uses
+// sync.Map for lock-free reads and atomic operations for semaphore creation.
+type ClientLimiter struct {
+	// semaphores stores per-client and per-client-method semaphores, keyed
+	// the same way ratelimit.ClientLimiter keys its limiters.
+	// Uses sync.Map for efficient concurrent access without locks
+	semaphores sync.Map
+
+	// globalSemaphore is the fallback semaphore for unauthenticated clients
+	globalSemaphore *semaphore
+
+	// config holds the concurrency limiting configuration
+	config *config.Config
+}
+
+// NewClientLimiter creates a new ClientLimiter with the given configuration.
+func NewClientLimiter(cfg *config.Config) (*ClientLimiter, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid concurrency limit config: %w", err)
+	}
+
+	// If concurrency limiting is disabled, return a limiter with nil global
+	// semaphore. Acquire() will always succeed in this case.
+	if !cfg.Enabled {
+		logger.Info("Concurrency limiting is disabled")
+
+		return &ClientLimiter{config: cfg}, nil
+	}
+
+	var globalSemaphore *semaphore
+	if cfg.GlobalMaxInflight > 0 {
+		globalSemaphore = newSemaphore(cfg.GlobalMaxInflight)
+		logger.Info("Global concurrency limiter initialized", "max_inflight", cfg.GlobalMaxInflight)
+	}
+
+	logger.Info("Client concurrency limiter initialized",
+		"per_client_max_inflight", cfg.PerClientMaxInflight,
+		"max_queue_wait", cfg.MaxQueueWait,
+		"method_overrides", len(cfg.MethodLimits),
+	)
+
+	return &ClientLimiter{
+		globalSemaphore: globalSemaphore,
+		config:          cfg,
+	}, nil
+}
+
+// Acquire admits one in-flight RPC for the given client and method, either
+// immediately, after waiting up to the applicable MaxQueueWait, or not at
+// all. On success it returns a release func that the caller must invoke
+// exactly once when the RPC completes. On failure it returns ok=false and a
+// no-op release func.
+func (l *ClientLimiter) Acquire(ctx context.Context, clientID, method string) (release func(), ok bool) {
+	if !l.config.Enabled {
+		return func() {}, true
+	}
+
+	sem, maxQueueWait := l.semaphoreForRequest(clientID, method)
+	if sem == nil {
+		return func() {}, true
+	}
+
+	if sem.tryAcquire() {
+		return sem.release, true
+	}
+
+	if sem.acquireWait(ctx, maxQueueWait) {
+		return sem.release, true
+	}
+
+	return func() {}, false
+}
+
+// semaphoreForRequest returns the semaphore that applies to a request, and
+// the MaxQueueWait to use with it. It checks in order:
+// 1. Method-specific override (if configured)
+// 2. Per-client semaphore (if clientID provided)
+// 3. Global semaphore (fallback)
+//
+// Returns a nil semaphore if no limit is applicable.
+func (l *ClientLimiter) semaphoreForRequest(clientID, method string) (*semaphore, time.Duration) {
+	if method != "" {
+		if methodLimit, exists := l.config.MethodLimits[method]; exists {
+			key := fmt.Sprintf("%s:%s", clientID, method)
+
+			return l.getOrCreateSemaphore(key, methodLimit.MaxInflight), methodLimit.MaxQueueWait
+		}
+	}
+
+	if clientID != "" && l.config.PerClientMaxInflight > 0 {
+		return l.getOrCreateSemaphore(clientID, l.config.PerClientMaxInflight), l.config.MaxQueueWait
+	}
+
+	return l.globalSemaphore, l.config.MaxQueueWait
+}
+
+// getOrCreateSemaphore gets an existing semaphore or creates a new one. This
+// method is thread-safe and uses sync.Map for efficient concurrent access.
+func (l *ClientLimiter) getOrCreateSemaphore(key string, maxInflight int) *semaphore {
+	// Fast path: check if semaphore already exists
+	if value, exists := l.semaphores.Load(key); exists {
+		sem, ok := value.(*semaphore)
+		if !ok {
+			// This should never happen as we control what goes into the map
+			panic(fmt.Sprintf("invalid type in semaphores map: expected *semaphore, got %T", value))
+		}
+
+		return sem
+	}
+
+	// If the limit is zero, don't create a semaphore (unlimited)
+	if maxInflight == 0 {
+		return nil
+	}
+
+	// Slow path: create new semaphore
+	// Use LoadOrStore to handle race conditions (multiple goroutines creating for same key)
+	newSem := newSemaphore(maxInflight)
+	actual, loaded := l.semaphores.LoadOrStore(key, newSem)
+
+	if !loaded {
+		logger.Debug("Created new concurrency semaphore", "key", key, "max_inflight", maxInflight)
+	}
+
+	sem, ok := actual.(*semaphore)
+	if !ok {
+		// This should never happen as we control what goes into the map
+		panic(fmt.Sprintf("invalid type in semaphores map: expected *semaphore, got %T", actual))
+	}
+
+	return sem
+}
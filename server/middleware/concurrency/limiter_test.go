@@ -0,0 +1,265 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/middleware/concurrency/config"
+)
+
+func TestNewClientLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *config.Config
+		wantErr bool
+	}{
+		{
+			name: "valid configuration",
+			config: &config.Config{
+				Enabled:              true,
+				GlobalMaxInflight:    10,
+				PerClientMaxInflight: 5,
+			},
+		},
+		{
+			name:    "nil config",
+			config:  nil,
+			wantErr: true,
+		},
+		{
+			name: "negative per_client_max_inflight",
+			config: &config.Config{
+				Enabled:              true,
+				PerClientMaxInflight: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "disabled config skips validation errors",
+			config: &config.Config{
+				Enabled:              false,
+				PerClientMaxInflight: -1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClientLimiter(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClientLimiter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestClientLimiter_PerClientMaxInflight tests that a client is blocked once
+// it has PerClientMaxInflight RPCs in flight, and admitted again once one
+// completes.
+func TestClientLimiter_PerClientMaxInflight(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              true,
+		PerClientMaxInflight: 2,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	release1, ok := limiter.Acquire(ctx, "client1", "/test/Method")
+	if !ok {
+		t.Fatal("first acquire should succeed")
+	}
+
+	release2, ok := limiter.Acquire(ctx, "client1", "/test/Method")
+	if !ok {
+		t.Fatal("second acquire should succeed (within limit of 2)")
+	}
+
+	if _, ok := limiter.Acquire(ctx, "client1", "/test/Method"); ok {
+		t.Error("third acquire should fail fast (limit of 2 exhausted)")
+	}
+
+	// A different client has its own, unexhausted slots.
+	release3, ok := limiter.Acquire(ctx, "client2", "/test/Method")
+	if !ok {
+		t.Error("a different client should have its own slots")
+	}
+
+	release1()
+
+	if _, ok := limiter.Acquire(ctx, "client1", "/test/Method"); !ok {
+		t.Error("acquire should succeed after a slot is released")
+	}
+
+	release2()
+	release3()
+}
+
+// TestClientLimiter_MaxQueueWait tests that an acquire blocks up to
+// MaxQueueWait waiting for a free slot, then succeeds once one frees up.
+func TestClientLimiter_MaxQueueWait(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              true,
+		PerClientMaxInflight: 1,
+		MaxQueueWait:         500 * time.Millisecond,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	release, ok := limiter.Acquire(ctx, "client1", "/test/Method")
+	if !ok {
+		t.Fatal("first acquire should succeed")
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		release()
+	}()
+
+	if _, ok := limiter.Acquire(ctx, "client1", "/test/Method"); !ok {
+		t.Error("second acquire should succeed once the first slot is released within MaxQueueWait")
+	}
+}
+
+// TestClientLimiter_FailFastWithoutMaxQueueWait tests that an acquire fails
+// immediately when MaxQueueWait is zero, without blocking.
+func TestClientLimiter_FailFastWithoutMaxQueueWait(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              true,
+		PerClientMaxInflight: 1,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	if _, ok := limiter.Acquire(ctx, "client1", "/test/Method"); !ok {
+		t.Fatal("first acquire should succeed")
+	}
+
+	start := time.Now()
+
+	if _, ok := limiter.Acquire(ctx, "client1", "/test/Method"); ok {
+		t.Error("second acquire should fail fast")
+	}
+
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("acquire should fail immediately without MaxQueueWait, took %v", elapsed)
+	}
+}
+
+// TestClientLimiter_MethodSpecificLimits tests that method-specific limits
+// apply independently of the per-client default.
+func TestClientLimiter_MethodSpecificLimits(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              true,
+		PerClientMaxInflight: 10,
+		MethodLimits: map[string]config.MethodLimit{
+			"/test.Service/ExpensiveMethod": {
+				MaxInflight: 1,
+			},
+		},
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	if _, ok := limiter.Acquire(ctx, "client1", "/test.Service/ExpensiveMethod"); !ok {
+		t.Fatal("first acquire to expensive method should succeed")
+	}
+
+	if _, ok := limiter.Acquire(ctx, "client1", "/test.Service/ExpensiveMethod"); ok {
+		t.Error("second acquire to expensive method should be limited")
+	}
+
+	// The regular per-client limit (10) is unaffected by the method override.
+	for i := range 5 {
+		if _, ok := limiter.Acquire(ctx, "client1", "/test.Service/RegularMethod"); !ok {
+			t.Errorf("acquire %d to regular method should succeed", i+1)
+		}
+	}
+}
+
+// TestClientLimiter_DisabledConcurrencyLimiting tests that when concurrency
+// limiting is disabled, all acquires succeed.
+func TestClientLimiter_DisabledConcurrencyLimiting(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              false,
+		PerClientMaxInflight: 1,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	ctx := context.Background()
+
+	for i := range 10 {
+		if _, ok := limiter.Acquire(ctx, "client1", "/test/Method"); !ok {
+			t.Errorf("acquire %d should succeed when concurrency limiting is disabled", i+1)
+		}
+	}
+}
+
+// TestClientLimiter_PanicOnInvalidTypeInMap tests the defensive panic in
+// getOrCreateSemaphore when the semaphores map contains an unexpected type.
+// This should never happen in normal operation but protects against internal bugs.
+func TestClientLimiter_PanicOnInvalidTypeInMap(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:              true,
+		PerClientMaxInflight: 5,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	limiter.semaphores.Store("corrupted", "invalid-type-not-a-semaphore")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Acquire() should panic when semaphores map contains invalid type")
+		} else {
+			panicMsg := fmt.Sprintf("%v", r)
+			if !contains(panicMsg, "invalid type in semaphores map") {
+				t.Errorf("Panic message should mention invalid type, got: %v", panicMsg)
+			}
+		}
+	}()
+
+	_, _ = limiter.Acquire(context.Background(), "corrupted", "/test/Method")
+}
+
+// contains checks if a string contains a substring.
+func contains(s, substr string) bool {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+
+	return len(substr) == 0
+}
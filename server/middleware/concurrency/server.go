@@ -0,0 +1,39 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package concurrency
+
+import (
+	"github.com/agntcy/dir/server/middleware/concurrency/config"
+	"google.golang.org/grpc"
+)
+
+// ServerOptions creates unary and stream concurrency limiting interceptors
+// for a gRPC server. These interceptors bound how many RPCs per client and
+// per method may be in flight at once, which protects against a small
+// number of very slow requests (large Push/Pull streams) exhausting server
+// resources - something an RPS limit alone cannot catch.
+//
+// Unlike ratelimit.ServerOptions, this has no io.Closer to release: the
+// ClientLimiter it builds only holds semaphores in a sync.Map, with no
+// janitor goroutine or other resource that needs releasing on shutdown.
+//
+// IMPORTANT: These interceptors should be chained AFTER the rate limiter so
+// that both admission controls apply: rate limiting bounds how often a
+// client can start a request, concurrency limiting bounds how many of those
+// requests may still be running. server.New wires both in that order.
+func ServerOptions(cfg *config.Config) ([]grpc.ServerOption, error) {
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			UnaryServerInterceptor(limiter),
+		),
+		grpc.ChainStreamInterceptor(
+			StreamServerInterceptor(limiter),
+		),
+	}, nil
+}
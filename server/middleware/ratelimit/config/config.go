@@ -0,0 +1,185 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config provides configuration for the rate limiting middleware.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// BackendMemory keeps token buckets in process-local memory. RPS caps
+	// apply per replica: a deployment scaled to N pods effectively allows
+	// N times the configured rate. This is the default.
+	BackendMemory = "memory"
+
+	// BackendRedis keeps token buckets in Redis, shared across replicas.
+	BackendRedis = "redis"
+
+	// BackendEtcd keeps token buckets in etcd, shared across replicas.
+	BackendEtcd = "etcd"
+
+	// BackendEnvoyRLS delegates rate limiting decisions entirely to an
+	// external Envoy Rate Limit Service (RLS) deployment instead of
+	// enforcing a token bucket locally.
+	BackendEnvoyRLS = "envoy_rls"
+
+	// DefaultBackend is used when Backend is left unset.
+	DefaultBackend = BackendMemory
+)
+
+// Config holds rate limiting configuration.
+type Config struct {
+	// Enabled controls whether rate limiting is enforced.
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Backend selects where token bucket state is stored: "memory" (default,
+	// per-replica), "redis", or "etcd" (cluster-wide), or "envoy_rls" to
+	// delegate decisions to an external Envoy Rate Limit Service.
+	Backend string `json:"backend,omitempty" mapstructure:"backend"`
+
+	// GlobalRPS is the requests-per-second limit applied to unauthenticated
+	// clients, or cluster-wide alongside per-client limits when Backend is
+	// a distributed backend. Zero disables the global limit.
+	GlobalRPS float64 `json:"global_rps,omitempty" mapstructure:"global_rps"`
+
+	// GlobalBurst is the token bucket burst capacity for the global limit.
+	GlobalBurst int `json:"global_burst,omitempty" mapstructure:"global_burst"`
+
+	// PerClientRPS is the requests-per-second limit applied per SPIFFE ID.
+	// Zero disables per-client limiting.
+	PerClientRPS float64 `json:"per_client_rps,omitempty" mapstructure:"per_client_rps"`
+
+	// PerClientBurst is the token bucket burst capacity for per-client limits.
+	PerClientBurst int `json:"per_client_burst,omitempty" mapstructure:"per_client_burst"`
+
+	// MethodLimits overrides the RPS/burst for specific gRPC full method names.
+	MethodLimits map[string]MethodLimit `json:"method_limits,omitempty" mapstructure:"method_limits"`
+
+	// Redis configures the Redis backend. Only used when Backend is "redis".
+	Redis RedisConfig `json:"redis,omitempty" mapstructure:"redis"`
+
+	// Etcd configures the etcd backend. Only used when Backend is "etcd".
+	Etcd EtcdConfig `json:"etcd,omitempty" mapstructure:"etcd"`
+
+	// EnvoyRLS configures the Envoy RLS backend. Only used when Backend is
+	// "envoy_rls".
+	EnvoyRLS EnvoyRLSConfig `json:"envoy_rls,omitempty" mapstructure:"envoy_rls"`
+
+	// CleanupInterval is how often idle per-client limiters are evicted from
+	// memory. Zero disables eviction, which is safe for a bounded or
+	// short-lived set of clients but lets the limiters map grow without bound
+	// for a deployment that sees a large, ever-changing population of SPIFFE
+	// IDs. Only used with the memory backend.
+	CleanupInterval time.Duration `json:"cleanup_interval,omitempty" mapstructure:"cleanup_interval"`
+
+	// MaxIdleAge is how long a per-client limiter may sit at a full token
+	// bucket before it is eligible for eviction. Required when CleanupInterval
+	// is set.
+	MaxIdleAge time.Duration `json:"max_idle_age,omitempty" mapstructure:"max_idle_age"`
+}
+
+// MethodLimit defines a per-method rate limit override.
+type MethodLimit struct {
+	// RPS is the requests-per-second limit for this method.
+	RPS float64 `json:"rps,omitempty" mapstructure:"rps"`
+
+	// Burst is the token bucket burst capacity for this method.
+	Burst int `json:"burst,omitempty" mapstructure:"burst"`
+}
+
+// RedisConfig holds connection settings for the distributed Redis backend.
+type RedisConfig struct {
+	// Addr is the Redis server address ("host:port").
+	Addr string `json:"addr,omitempty" mapstructure:"addr"`
+
+	// Password authenticates to the Redis server, if required.
+	//nolint:gosec // G117: intentional config field for Redis auth
+	Password string `json:"password,omitempty" mapstructure:"password"`
+
+	// DB selects the Redis logical database (default 0).
+	DB int `json:"db,omitempty" mapstructure:"db"`
+}
+
+// EtcdConfig holds connection settings for the distributed etcd backend.
+type EtcdConfig struct {
+	// Endpoints is the list of etcd server addresses.
+	Endpoints []string `json:"endpoints,omitempty" mapstructure:"endpoints"`
+
+	// Username for etcd authentication.
+	Username string `json:"username,omitempty" mapstructure:"username"`
+
+	// Password for etcd authentication.
+	//nolint:gosec // G117: intentional config field for etcd auth
+	Password string `json:"password,omitempty" mapstructure:"password"`
+
+	// DialTimeout is the timeout for connecting to etcd.
+	DialTimeout time.Duration `json:"dial_timeout,omitempty" mapstructure:"dial_timeout"`
+}
+
+// EnvoyRLSConfig holds connection settings for the Envoy RLS backend.
+type EnvoyRLSConfig struct {
+	// Address is the RLS server address ("host:port").
+	Address string `json:"address,omitempty" mapstructure:"address"`
+
+	// Domain is the rate limit domain sent with every request, scoping which
+	// set of rules the RLS server applies (see the RLS configuration docs).
+	Domain string `json:"domain,omitempty" mapstructure:"domain"`
+
+	// Timeout bounds each ShouldRateLimit call to the RLS server.
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+
+	// FailOpen controls what happens when the RLS server is unreachable or
+	// returns an error: true allows the request through, false denies it.
+	FailOpen bool `json:"fail_open,omitempty" mapstructure:"fail_open"`
+}
+
+// Validate checks that the configuration is internally consistent.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.GlobalRPS < 0 {
+		return errors.New("global_rps must not be negative")
+	}
+
+	if c.PerClientRPS < 0 {
+		return errors.New("per_client_rps must not be negative")
+	}
+
+	if c.CleanupInterval < 0 {
+		return errors.New("cleanup_interval must not be negative")
+	}
+
+	if c.CleanupInterval > 0 && c.MaxIdleAge <= 0 {
+		return errors.New("max_idle_age is required when cleanup_interval is set")
+	}
+
+	switch c.Backend {
+	case "", BackendMemory:
+	case BackendRedis:
+		if c.Redis.Addr == "" {
+			return errors.New("redis.addr is required when backend is redis")
+		}
+	case BackendEtcd:
+		if len(c.Etcd.Endpoints) == 0 {
+			return errors.New("etcd.endpoints is required when backend is etcd")
+		}
+	case BackendEnvoyRLS:
+		if c.EnvoyRLS.Address == "" {
+			return errors.New("envoy_rls.address is required when backend is envoy_rls")
+		}
+
+		if c.EnvoyRLS.Domain == "" {
+			return errors.New("envoy_rls.domain is required when backend is envoy_rls")
+		}
+	default:
+		return fmt.Errorf("unsupported backend %q, must be one of: memory, redis, etcd, envoy_rls", c.Backend)
+	}
+
+	return nil
+}
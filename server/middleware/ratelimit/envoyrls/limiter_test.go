@@ -0,0 +1,134 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package envoyrls
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	rlsv3 "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	"google.golang.org/grpc"
+)
+
+// fakeRLSServer is a minimal envoy.service.ratelimit.v3.RateLimitService
+// implementation for tests. It returns overLimitMethods[descriptor method
+// entry] as OVER_LIMIT and everything else as OK, so tests can exercise both
+// outcomes without a real RLS deployment.
+type fakeRLSServer struct {
+	rlsv3.UnimplementedRateLimitServiceServer
+
+	overLimitMethods map[string]bool
+}
+
+func (s *fakeRLSServer) ShouldRateLimit(_ context.Context, req *rlsv3.RateLimitRequest) (*rlsv3.RateLimitResponse, error) {
+	for _, descriptor := range req.GetDescriptors() {
+		for _, entry := range descriptor.GetEntries() {
+			if entry.GetKey() == "method" && s.overLimitMethods[entry.GetValue()] {
+				return &rlsv3.RateLimitResponse{OverallCode: rlsv3.RateLimitResponse_OVER_LIMIT}, nil
+			}
+		}
+	}
+
+	return &rlsv3.RateLimitResponse{OverallCode: rlsv3.RateLimitResponse_OK}, nil
+}
+
+// startFakeRLSServer starts a fakeRLSServer on a random local port and
+// returns its address; the server is stopped via t.Cleanup.
+func startFakeRLSServer(t *testing.T, overLimitMethods map[string]bool) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	rlsv3.RegisterRateLimitServiceServer(grpcServer, &fakeRLSServer{overLimitMethods: overLimitMethods})
+
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestRLSLimiter_Allow(t *testing.T) {
+	addr := startFakeRLSServer(t, map[string]bool{"/test.Service/Expensive": true})
+
+	limiter, err := New(Config{
+		Address: addr,
+		Domain:  "dir",
+		Timeout: 2 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer limiter.Close()
+
+	ctx := context.Background()
+
+	if !limiter.Allow(ctx, "spiffe://example.org/service/client1", "/test.Service/Regular") {
+		t.Error("expected Regular method to be allowed")
+	}
+
+	if limiter.Allow(ctx, "spiffe://example.org/service/client1", "/test.Service/Expensive") {
+		t.Error("expected Expensive method to be denied (OVER_LIMIT)")
+	}
+}
+
+func TestRLSLimiter_Wait(t *testing.T) {
+	addr := startFakeRLSServer(t, map[string]bool{"/test.Service/Expensive": true})
+
+	limiter, err := New(Config{
+		Address: addr,
+		Domain:  "dir",
+		Timeout: 2 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer limiter.Close()
+
+	ctx := context.Background()
+
+	if err := limiter.Wait(ctx, "client1", "/test.Service/Regular"); err != nil {
+		t.Errorf("expected Wait to succeed for an allowed method, got: %v", err)
+	}
+
+	if err := limiter.Wait(ctx, "client1", "/test.Service/Expensive"); err == nil {
+		t.Error("expected Wait to fail for a denied method")
+	}
+}
+
+func TestRLSLimiter_FailOpenOnTransportError(t *testing.T) {
+	// No server listening on this address - calls should fail with a
+	// transport error, exercising the FailOpen/FailClosed branches.
+	unreachableAddr := "127.0.0.1:1"
+
+	ctx := context.Background()
+
+	openLimiter, err := New(Config{Address: unreachableAddr, Domain: "dir", Timeout: 200 * time.Millisecond, FailOpen: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer openLimiter.Close()
+
+	if !openLimiter.Allow(ctx, "client1", "/test.Service/Method") {
+		t.Error("expected FailOpen limiter to allow the request on transport error")
+	}
+
+	closedLimiter, err := New(Config{Address: unreachableAddr, Domain: "dir", Timeout: 200 * time.Millisecond, FailOpen: false})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer closedLimiter.Close()
+
+	if closedLimiter.Allow(ctx, "client1", "/test.Service/Method") {
+		t.Error("expected FailOpen=false limiter to deny the request on transport error")
+	}
+}
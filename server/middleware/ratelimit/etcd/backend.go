@@ -0,0 +1,156 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package etcd implements a cluster-wide token bucket rate limit backend
+// backed by etcd, for deployments that already run etcd (see
+// runtime/store/etcd) but don't want to add Redis as a dependency.
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// maxCASAttempts bounds retries when concurrent replicas race to update the
+// same bucket key, so a hot key can't spin Allow forever under contention.
+const maxCASAttempts = 10
+
+// Config holds connection settings for the etcd backend.
+type Config struct {
+	// Endpoints is the list of etcd server addresses.
+	Endpoints []string
+
+	// Username for etcd authentication.
+	Username string
+
+	// Password for etcd authentication.
+	Password string
+
+	// DialTimeout is the timeout for connecting to etcd.
+	DialTimeout time.Duration
+}
+
+// Backend implements ratelimit.DistributedBackend using etcd.
+type Backend struct {
+	client *clientv3.Client
+}
+
+// bucketState is the JSON value stored per key: tokens available as of
+// LastRefillNanos, refilled lazily on the next Allow call.
+type bucketState struct {
+	Tokens          float64 `json:"tokens"`
+	LastRefillNanos int64   `json:"last_refill_unix_nanos"`
+}
+
+// New creates a Backend connected to the etcd cluster described by cfg.
+func New(cfg Config) (*Backend, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+		DialTimeout: cfg.DialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create etcd client: %w", err)
+	}
+
+	return &Backend{client: client}, nil
+}
+
+// Allow atomically refills and consumes one token from the bucket at key,
+// creating it with the given rps/burst if it doesn't exist yet, via a
+// read-modify-CAS loop scoped to a lease so idle keys expire. It implements
+// ratelimit.DistributedBackend.
+func (b *Backend) Allow(ctx context.Context, key string, rps float64, burst int) (bool, error) {
+	if rps <= 0 {
+		return true, nil
+	}
+
+	ttlSeconds := int64(math.Ceil(float64(burst) / rps))
+	if ttlSeconds < 1 {
+		ttlSeconds = 1
+	}
+
+	for attempt := 0; attempt < maxCASAttempts; attempt++ {
+		allowed, ok, err := b.tryAllow(ctx, key, rps, burst, ttlSeconds)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return allowed, nil
+		}
+		// Lost the CAS race to another replica updating the same key - retry
+		// against the now-current value.
+	}
+
+	return false, fmt.Errorf("failed to update bucket %s after %d attempts", key, maxCASAttempts)
+}
+
+// tryAllow performs a single read-modify-CAS attempt. ok is false if the CAS
+// was lost to a concurrent writer and the caller should retry.
+func (b *Backend) tryAllow(ctx context.Context, key string, rps float64, burst int, ttlSeconds int64) (allowed, ok bool, err error) {
+	resp, err := b.client.Get(ctx, key)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to read bucket %s: %w", key, err)
+	}
+
+	now := time.Now().UnixNano()
+	state := bucketState{Tokens: float64(burst), LastRefillNanos: now}
+
+	var modRevision int64
+	if len(resp.Kvs) > 0 {
+		if err := json.Unmarshal(resp.Kvs[0].Value, &state); err != nil {
+			return false, false, fmt.Errorf("failed to decode bucket %s: %w", key, err)
+		}
+
+		modRevision = resp.Kvs[0].ModRevision
+	}
+
+	elapsed := now - state.LastRefillNanos
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	state.Tokens = math.Min(float64(burst), state.Tokens+float64(elapsed)*rps/1e9)
+	state.LastRefillNanos = now
+
+	allowed = state.Tokens >= 1
+	if allowed {
+		state.Tokens--
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to encode bucket %s: %w", key, err)
+	}
+
+	lease, err := b.client.Grant(ctx, ttlSeconds)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to grant lease for bucket %s: %w", key, err)
+	}
+
+	txnResp, err := b.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)).
+		Then(clientv3.OpPut(key, string(data), clientv3.WithLease(lease.ID))).
+		Commit()
+	if err != nil {
+		return false, false, fmt.Errorf("failed to CAS bucket %s: %w", key, err)
+	}
+
+	return allowed, txnResp.Succeeded, nil
+}
+
+// Close closes the underlying etcd client.
+func (b *Backend) Close() error {
+	if err := b.client.Close(); err != nil {
+		return fmt.Errorf("failed to close etcd client: %w", err)
+	}
+
+	return nil
+}
@@ -5,30 +5,45 @@ package ratelimit
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/agntcy/dir/server/authn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // UnaryServerInterceptor returns a gRPC unary server interceptor that performs rate limiting.
 // It extracts the client identity from the context (SPIFFE ID if authenticated),
 // checks the rate limit using the provided ClientLimiter, and returns ResourceExhausted
 // error if the limit is exceeded.
-func UnaryServerInterceptor(limiter *ClientLimiter) grpc.UnaryServerInterceptor {
+func UnaryServerInterceptor(limiter Limiter) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		// Extract client ID from context (SPIFFE ID if authenticated, empty if not)
 		clientID := extractClientID(ctx)
 
-		// Check rate limit
-		if !limiter.Allow(ctx, clientID, info.FullMethod) {
+		allowed := limiter.Allow(ctx, clientID, info.FullMethod)
+		hint, hasHint := reserveHint(limiter, clientID, info.FullMethod)
+
+		if !allowed {
 			logger.Warn("Rate limit exceeded",
 				"client_id", clientID,
 				"method", info.FullMethod,
 			)
 
-			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+			if hasHint {
+				_ = grpc.SetTrailer(ctx, retryAfterTrailer(hint))
+			}
+
+			return nil, rateLimitExceededError(hint, hasHint)
+		}
+
+		if hasHint {
+			_ = grpc.SetHeader(ctx, quotaHeaders(hint))
 		}
 
 		// Rate limit passed, proceed with the request
@@ -43,21 +58,31 @@ func UnaryServerInterceptor(limiter *ClientLimiter) grpc.UnaryServerInterceptor
 //
 // Note: Rate limiting is applied when the stream is initiated, not per message.
 // This is the standard approach for stream rate limiting to avoid overhead.
-func StreamServerInterceptor(limiter *ClientLimiter) grpc.StreamServerInterceptor {
+func StreamServerInterceptor(limiter Limiter) grpc.StreamServerInterceptor {
 	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		ctx := ss.Context()
 
 		// Extract client ID from context (SPIFFE ID if authenticated, empty if not)
 		clientID := extractClientID(ctx)
 
-		// Check rate limit
-		if !limiter.Allow(ctx, clientID, info.FullMethod) {
+		allowed := limiter.Allow(ctx, clientID, info.FullMethod)
+		hint, hasHint := reserveHint(limiter, clientID, info.FullMethod)
+
+		if !allowed {
 			logger.Warn("Rate limit exceeded",
 				"client_id", clientID,
 				"method", info.FullMethod,
 			)
 
-			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+			if hasHint {
+				ss.SetTrailer(retryAfterTrailer(hint))
+			}
+
+			return rateLimitExceededError(hint, hasHint)
+		}
+
+		if hasHint {
+			_ = ss.SetHeader(quotaHeaders(hint))
 		}
 
 		// Rate limit passed, proceed with the stream
@@ -65,6 +90,58 @@ func StreamServerInterceptor(limiter *ClientLimiter) grpc.StreamServerIntercepto
 	}
 }
 
+// reserveHint fetches retry/quota metadata for clientID/method if limiter
+// supports it. Not every Limiter does (see ReservationHinter), so callers
+// must handle ok == false by skipping quota metadata entirely.
+func reserveHint(limiter Limiter, clientID, method string) (RateLimitHint, bool) {
+	hinter, ok := limiter.(ReservationHinter)
+	if !ok {
+		return RateLimitHint{}, false
+	}
+
+	return hinter.Reserve(clientID, method)
+}
+
+// rateLimitExceededError builds the ResourceExhausted status returned to a
+// rejected caller, attaching a google.rpc.RetryInfo detail with the exact
+// delay until the next token when that's known.
+func rateLimitExceededError(hint RateLimitHint, hasHint bool) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+
+	if !hasHint || hint.RetryAfter <= 0 {
+		return st.Err()
+	}
+
+	withDetails, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(hint.RetryAfter),
+	})
+	if err != nil {
+		// Detail is best-effort; still return the base ResourceExhausted error.
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+// retryAfterTrailer builds the retry-after-ms trailer metadata for a
+// rejected request, mirroring the RetryInfo detail for clients that read
+// trailers rather than status details.
+func retryAfterTrailer(hint RateLimitHint) metadata.MD {
+	return metadata.Pairs("retry-after-ms", strconv.FormatInt(hint.RetryAfter.Milliseconds(), 10))
+}
+
+// quotaHeaders builds the x-ratelimit-* header metadata attached to
+// successful responses so clients can drive local backoff.
+func quotaHeaders(hint RateLimitHint) metadata.MD {
+	resetSeconds := int64(hint.ResetAfter / time.Second)
+
+	return metadata.Pairs(
+		"x-ratelimit-limit", strconv.Itoa(hint.Limit),
+		"x-ratelimit-remaining", strconv.Itoa(hint.Remaining),
+		"x-ratelimit-reset", strconv.FormatInt(resetSeconds, 10),
+	)
+}
+
 // extractClientID extracts the client identifier from the gRPC context.
 // It returns the SPIFFE ID string if the client is authenticated via authn middleware,
 // or an empty string for unauthenticated clients (which will use global rate limit).
@@ -10,8 +10,10 @@ import (
 	"github.com/agntcy/dir/server/authn"
 	"github.com/agntcy/dir/server/middleware/ratelimit/config"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -389,6 +391,9 @@ func TestUnaryServerInterceptor_MethodSpecificLimits(t *testing.T) {
 type mockServerStream struct {
 	grpc.ServerStream
 	contextFunc func() context.Context
+
+	header  metadata.MD
+	trailer metadata.MD
 }
 
 func (m *mockServerStream) Context() context.Context {
@@ -399,6 +404,20 @@ func (m *mockServerStream) Context() context.Context {
 	return context.Background()
 }
 
+// SetHeader captures header metadata instead of forwarding to the (nil)
+// embedded grpc.ServerStream, so interceptors under test can call it safely.
+func (m *mockServerStream) SetHeader(md metadata.MD) error {
+	m.header = md
+
+	return nil
+}
+
+// SetTrailer captures trailer metadata instead of forwarding to the (nil)
+// embedded grpc.ServerStream, so interceptors under test can call it safely.
+func (m *mockServerStream) SetTrailer(md metadata.MD) {
+	m.trailer = md
+}
+
 // newMockServerStream creates a mockServerStream with the given context.
 func newMockServerStream(ctx context.Context) *mockServerStream {
 	return &mockServerStream{
@@ -407,3 +426,210 @@ func newMockServerStream(ctx context.Context) *mockServerStream {
 		},
 	}
 }
+
+// fakeServerTransportStream is a minimal grpc.ServerTransportStream that
+// captures SetHeader/SetTrailer calls, so grpc.SetHeader/grpc.SetTrailer can
+// be exercised on a unary context in tests without a real gRPC server.
+type fakeServerTransportStream struct {
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (f *fakeServerTransportStream) Method() string { return "/test.Service/Method" }
+
+func (f *fakeServerTransportStream) SetHeader(md metadata.MD) error {
+	f.header = md
+
+	return nil
+}
+
+func (f *fakeServerTransportStream) SendHeader(md metadata.MD) error {
+	f.header = md
+
+	return nil
+}
+
+func (f *fakeServerTransportStream) SetTrailer(md metadata.MD) error {
+	f.trailer = md
+
+	return nil
+}
+
+// TestUnaryServerInterceptor_SetsRetryAfterTrailerWhenRejected tests that a
+// rejected request carries a retry-after-ms trailer with the exact delay
+// until the next token, alongside a RetryInfo status detail.
+func TestUnaryServerInterceptor_SetsRetryAfterTrailerWhenRejected(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:     true,
+		GlobalRPS:   10.0,
+		GlobalBurst: 1,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	interceptor := UnaryServerInterceptor(limiter)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return successResponse, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	transport := &fakeServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), transport)
+
+	// First request consumes the only token.
+	if _, err := interceptor(ctx, "request1", info, handler); err != nil {
+		t.Fatalf("first request should succeed, got error: %v", err)
+	}
+
+	// Second request is rejected and should carry retry metadata.
+	_, err = interceptor(ctx, "request2", info, handler)
+	if err == nil {
+		t.Fatal("second request should be rate limited")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted status, got: %v", err)
+	}
+
+	foundRetryInfo := false
+
+	for _, detail := range st.Details() {
+		if _, ok := detail.(*errdetails.RetryInfo); ok {
+			foundRetryInfo = true
+		}
+	}
+
+	if !foundRetryInfo {
+		t.Error("expected a RetryInfo detail on the rejected status")
+	}
+
+	values := transport.trailer.Get("retry-after-ms")
+	if len(values) == 0 {
+		t.Fatal("expected a retry-after-ms trailer on the rejected request")
+	}
+
+	if values[0] == "0" || values[0] == "" {
+		t.Errorf("expected a positive retry-after-ms value, got: %q", values[0])
+	}
+}
+
+// TestUnaryServerInterceptor_SetsQuotaHeadersOnSuccess tests that a
+// successful request carries x-ratelimit-* headers derived from the
+// configured burst and current token count.
+func TestUnaryServerInterceptor_SetsQuotaHeadersOnSuccess(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:     true,
+		GlobalRPS:   10.0,
+		GlobalBurst: 5,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	interceptor := UnaryServerInterceptor(limiter)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return successResponse, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	transport := &fakeServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), transport)
+
+	if _, err := interceptor(ctx, "request1", info, handler); err != nil {
+		t.Fatalf("request should succeed, got error: %v", err)
+	}
+
+	if got := transport.header.Get("x-ratelimit-limit"); len(got) == 0 || got[0] != "5" {
+		t.Errorf("expected x-ratelimit-limit header = 5, got: %v", got)
+	}
+
+	if got := transport.header.Get("x-ratelimit-remaining"); len(got) == 0 {
+		t.Error("expected an x-ratelimit-remaining header")
+	}
+
+	if got := transport.header.Get("x-ratelimit-reset"); len(got) == 0 {
+		t.Error("expected an x-ratelimit-reset header")
+	}
+}
+
+// TestStreamServerInterceptor_SetsRetryAfterTrailerWhenRejected tests that a
+// rejected stream carries a retry-after-ms trailer, mirroring the unary path.
+func TestStreamServerInterceptor_SetsRetryAfterTrailerWhenRejected(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:     true,
+		GlobalRPS:   10.0,
+		GlobalBurst: 1,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	interceptor := StreamServerInterceptor(limiter)
+
+	handler := func(srv any, stream grpc.ServerStream) error {
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/StreamMethod"}
+
+	stream := newMockServerStream(context.Background())
+
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("first stream should succeed, got error: %v", err)
+	}
+
+	err = interceptor(nil, stream, info, handler)
+	if err == nil {
+		t.Fatal("second stream should be rejected")
+	}
+
+	values := stream.trailer.Get("retry-after-ms")
+	if len(values) == 0 {
+		t.Fatal("expected a retry-after-ms trailer on the rejected stream")
+	}
+}
+
+// TestStreamServerInterceptor_SetsQuotaHeadersOnSuccess tests that a
+// successful stream carries x-ratelimit-* headers, mirroring the unary path.
+func TestStreamServerInterceptor_SetsQuotaHeadersOnSuccess(t *testing.T) {
+	cfg := &config.Config{
+		Enabled:     true,
+		GlobalRPS:   10.0,
+		GlobalBurst: 5,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+
+	interceptor := StreamServerInterceptor(limiter)
+
+	handler := func(srv any, stream grpc.ServerStream) error {
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/StreamMethod"}
+
+	stream := newMockServerStream(context.Background())
+
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("stream should succeed, got error: %v", err)
+	}
+
+	if got := stream.header.Get("x-ratelimit-limit"); len(got) == 0 || got[0] != "5" {
+		t.Errorf("expected x-ratelimit-limit header = 5, got: %v", got)
+	}
+}
@@ -7,15 +7,47 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/agntcy/dir/server/middleware/ratelimit/config"
+	"github.com/agntcy/dir/server/middleware/ratelimit/envoyrls"
+	"github.com/agntcy/dir/server/middleware/ratelimit/etcd"
+	"github.com/agntcy/dir/server/middleware/ratelimit/redis"
 	"github.com/agntcy/dir/utils/logging"
 	"golang.org/x/time/rate"
 )
 
 var logger = logging.Logger("ratelimit")
 
+// nowFunc is overridden in tests so janitor eviction can be exercised without
+// waiting on a real clock.
+var nowFunc = time.Now
+
+// globalBucketKey identifies the global-scope bucket in a DistributedBackend.
+// It is checked alongside the per-client key so GlobalRPS/GlobalBurst still
+// apply cluster-wide even for authenticated clients, not just anonymous ones.
+const globalBucketKey = "__global__"
+
+// distributedPollInterval is how often Wait retries against a
+// DistributedBackend. Unlike golang.org/x/time/rate, a DistributedBackend
+// has no way to report how long until the next token is available, so Wait
+// falls back to polling instead of computing an exact delay.
+const distributedPollInterval = 10 * time.Millisecond
+
+// DistributedBackend stores token bucket state outside the process, so every
+// replica of a horizontally scaled deployment shares the same buckets
+// instead of each enforcing its own independent limit. See the redis and
+// etcd subpackages for implementations.
+type DistributedBackend interface {
+	// Allow atomically refills the bucket at key (creating it with the given
+	// rps/burst if absent) and consumes one token if available. It reports
+	// whether a token was granted.
+	Allow(ctx context.Context, key string, rps float64, burst int) (bool, error)
+}
+
 // Limiter defines the interface for rate limiting operations.
 // Implementations should be thread-safe and support concurrent access.
 type Limiter interface {
@@ -28,6 +60,28 @@ type Limiter interface {
 	Wait(ctx context.Context, clientID string, method string) error
 }
 
+// ReservationHinter is an optional capability of a Limiter: it reports
+// retry/quota metadata for a clientID/method without affecting the outcome
+// of Allow/Wait. ClientLimiter implements it for the in-memory/token-bucket
+// path; backends with no local Reservation concept (envoyrls.RLSLimiter, and
+// ClientLimiter itself when backed by a DistributedBackend) don't, so
+// callers should type-assert for it rather than require it.
+type ReservationHinter interface {
+	Reserve(clientID string, method string) (RateLimitHint, bool)
+}
+
+// limiterEntry wraps a per-client *rate.Limiter with the bookkeeping the
+// janitor needs to decide whether it's idle: the burst it was created with
+// (to recognize a full bucket) and the last time it was touched by a request.
+type limiterEntry struct {
+	limiter *rate.Limiter
+	burst   int
+
+	// lastTouched is a UnixNano timestamp, stored as atomic.Int64 so the
+	// janitor can read it concurrently with getOrCreateLimiter updating it.
+	lastTouched atomic.Int64
+}
+
 // ClientLimiter implements per-client rate limiting using token bucket algorithm.
 // It maintains separate rate limiters for each unique client (identified by SPIFFE ID),
 // with support for global limits (for unauthenticated clients) and per-method overrides.
@@ -36,7 +90,7 @@ type Limiter interface {
 // ClientLimiter is safe for concurrent use by multiple goroutines.
 // It uses sync.Map for lock-free reads and atomic operations for limiter creation.
 type ClientLimiter struct {
-	// limiters stores per-client rate limiters (clientID -> *rate.Limiter)
+	// limiters stores per-client rate limiters (clientID -> *limiterEntry)
 	// Uses sync.Map for efficient concurrent access without locks
 	limiters sync.Map
 
@@ -45,6 +99,42 @@ type ClientLimiter struct {
 
 	// config holds the rate limiting configuration
 	config *config.Config
+
+	// distributed, when non-nil, is used instead of limiters/globalLimiter so
+	// rate limits are enforced cluster-wide rather than per replica.
+	distributed DistributedBackend
+
+	// stopJanitor and janitorDone coordinate shutdown of the idle-eviction
+	// goroutine started by NewClientLimiter when cfg.CleanupInterval > 0.
+	// Both are nil if no janitor was started.
+	stopJanitor chan struct{}
+	janitorDone chan struct{}
+}
+
+// NewLimiter creates the Limiter described by cfg.Backend. For "envoy_rls"
+// it returns an envoyrls.RLSLimiter that delegates every decision to an
+// external RLS deployment; for every other backend ("memory", "redis",
+// "etcd") it returns a *ClientLimiter enforcing a token bucket, locally or
+// via a DistributedBackend. Use this instead of NewClientLimiter directly
+// when cfg.Backend may be "envoy_rls".
+func NewLimiter(cfg *config.Config) (Limiter, error) {
+	if cfg != nil && cfg.Backend == config.BackendEnvoyRLS {
+		limiter, err := envoyrls.New(envoyrls.Config{
+			Address:  cfg.EnvoyRLS.Address,
+			Domain:   cfg.EnvoyRLS.Domain,
+			Timeout:  cfg.EnvoyRLS.Timeout,
+			FailOpen: cfg.EnvoyRLS.FailOpen,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create envoy RLS limiter: %w", err)
+		}
+
+		logger.Info("Envoy RLS rate limiter initialized", "address", cfg.EnvoyRLS.Address, "domain", cfg.EnvoyRLS.Domain)
+
+		return limiter, nil
+	}
+
+	return NewClientLimiter(cfg)
 }
 
 // NewClientLimiter creates a new ClientLimiter with the given configuration.
@@ -70,26 +160,99 @@ func NewClientLimiter(cfg *config.Config) (*ClientLimiter, error) {
 		}, nil
 	}
 
-	// Create global rate limiter for unauthenticated clients
+	distributed, err := newDistributedBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// The in-memory path (limiters/globalLimiter) is only used when no
+	// distributed backend is configured.
 	var globalLimiter *rate.Limiter
-	if cfg.GlobalRPS > 0 {
-		globalLimiter = rate.NewLimiter(rate.Limit(cfg.GlobalRPS), cfg.GlobalBurst)
-		logger.Info("Global rate limiter initialized",
-			"rps", cfg.GlobalRPS,
-			"burst", cfg.GlobalBurst,
-		)
+	if distributed == nil {
+		if cfg.GlobalRPS > 0 {
+			globalLimiter = rate.NewLimiter(rate.Limit(cfg.GlobalRPS), cfg.GlobalBurst)
+			logger.Info("Global rate limiter initialized",
+				"rps", cfg.GlobalRPS,
+				"burst", cfg.GlobalBurst,
+			)
+		}
 	}
 
 	logger.Info("Client rate limiter initialized",
+		"backend", valueOrDefault(cfg.Backend, config.DefaultBackend),
 		"per_client_rps", cfg.PerClientRPS,
 		"per_client_burst", cfg.PerClientBurst,
 		"method_overrides", len(cfg.MethodLimits),
 	)
 
-	return &ClientLimiter{
+	limiter := &ClientLimiter{
 		globalLimiter: globalLimiter,
 		config:        cfg,
-	}, nil
+		distributed:   distributed,
+	}
+
+	// The janitor only makes sense for the in-memory path: a DistributedBackend
+	// owns its own key expiry (TTLs in redis/etcd), so there's no local map to
+	// evict from.
+	if distributed == nil && cfg.CleanupInterval > 0 {
+		limiter.stopJanitor = make(chan struct{})
+		limiter.janitorDone = make(chan struct{})
+
+		go limiter.runJanitor()
+	}
+
+	return limiter, nil
+}
+
+// valueOrDefault returns value unless it is empty, in which case it returns def.
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+
+	return value
+}
+
+// newDistributedBackend constructs the DistributedBackend for cfg.Backend.
+// It returns (nil, nil) for the memory backend (the default), in which case
+// the caller should fall back to the in-memory limiters map.
+func newDistributedBackend(cfg *config.Config) (DistributedBackend, error) {
+	switch cfg.Backend {
+	case "", config.BackendMemory:
+		return nil, nil //nolint:nilnil // nil backend is the documented signal to use the in-memory path
+
+	case config.BackendRedis:
+		backend, err := redis.New(redis.Config{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create redis rate limit backend: %w", err)
+		}
+
+		logger.Info("Distributed rate limit backend initialized", "backend", config.BackendRedis, "addr", cfg.Redis.Addr)
+
+		return backend, nil
+
+	case config.BackendEtcd:
+		backend, err := etcd.New(etcd.Config{
+			Endpoints:   cfg.Etcd.Endpoints,
+			Username:    cfg.Etcd.Username,
+			Password:    cfg.Etcd.Password,
+			DialTimeout: cfg.Etcd.DialTimeout,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create etcd rate limit backend: %w", err)
+		}
+
+		logger.Info("Distributed rate limit backend initialized", "backend", config.BackendEtcd, "endpoints", cfg.Etcd.Endpoints)
+
+		return backend, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported ratelimit backend: %s", cfg.Backend)
+	}
 }
 
 // Allow reports whether an event may happen now for the given client and method.
@@ -108,6 +271,22 @@ func (l *ClientLimiter) Allow(ctx context.Context, clientID string, method strin
 		return true
 	}
 
+	if l.distributed != nil {
+		allowed, err := l.allowDistributed(ctx, clientID, method)
+		if err != nil {
+			// Fail open: a backend outage shouldn't take the whole API down.
+			logger.Error("distributed rate limit backend error, allowing request", "client_id", clientID, "method", method, "error", err)
+
+			return true
+		}
+
+		if !allowed {
+			logger.Warn("Rate limit exceeded", "client_id", clientID, "method", method)
+		}
+
+		return allowed
+	}
+
 	// Get the appropriate rate limiter
 	limiter := l.getLimiterForRequest(clientID, method)
 
@@ -139,6 +318,10 @@ func (l *ClientLimiter) Wait(ctx context.Context, clientID string, method string
 		return nil
 	}
 
+	if l.distributed != nil {
+		return l.waitDistributed(ctx, clientID, method)
+	}
+
 	// Get the appropriate rate limiter
 	limiter := l.getLimiterForRequest(clientID, method)
 
@@ -155,6 +338,72 @@ func (l *ClientLimiter) Wait(ctx context.Context, clientID string, method string
 	return nil
 }
 
+// allowDistributed is the DistributedBackend equivalent of Allow/getLimiterForRequest.
+// Unlike the in-memory path, which falls back to the global limiter only for
+// unauthenticated clients, it checks the global bucket and the per-client
+// bucket independently so GlobalRPS/GlobalBurst still bound the cluster as a
+// whole even when every client has its own per-client limit.
+func (l *ClientLimiter) allowDistributed(ctx context.Context, clientID, method string) (bool, error) {
+	if method != "" {
+		if methodLimit, exists := l.config.MethodLimits[method]; exists {
+			key := fmt.Sprintf("%s:%s", clientID, method)
+
+			allowed, err := l.distributed.Allow(ctx, key, methodLimit.RPS, methodLimit.Burst)
+			if err != nil {
+				return false, fmt.Errorf("method bucket %s: %w", key, err)
+			}
+
+			return allowed, nil
+		}
+	}
+
+	if l.config.GlobalRPS > 0 {
+		allowed, err := l.distributed.Allow(ctx, globalBucketKey, l.config.GlobalRPS, l.config.GlobalBurst)
+		if err != nil {
+			return false, fmt.Errorf("global bucket: %w", err)
+		}
+
+		if !allowed {
+			return false, nil
+		}
+	}
+
+	if clientID != "" && l.config.PerClientRPS > 0 {
+		allowed, err := l.distributed.Allow(ctx, clientID, l.config.PerClientRPS, l.config.PerClientBurst)
+		if err != nil {
+			return false, fmt.Errorf("per-client bucket %s: %w", clientID, err)
+		}
+
+		return allowed, nil
+	}
+
+	return true, nil
+}
+
+// waitDistributed polls allowDistributed until a token is granted or ctx is
+// done. DistributedBackend has no equivalent of golang.org/x/time/rate's
+// exact reservation delay, so polling is the simplest correct option.
+func (l *ClientLimiter) waitDistributed(ctx context.Context, clientID, method string) error {
+	for {
+		allowed, err := l.allowDistributed(ctx, clientID, method)
+		if err != nil {
+			logger.Error("distributed rate limit backend error, allowing request", "client_id", clientID, "method", method, "error", err)
+
+			return nil
+		}
+
+		if allowed {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("rate limit wait failed for client %s method %s: %w", clientID, method, ctx.Err())
+		case <-time.After(distributedPollInterval):
+		}
+	}
+}
+
 // getLimiterForRequest returns the appropriate rate limiter for a request.
 // It checks in order:
 // 1. Method-specific override (if configured)
@@ -163,23 +412,32 @@ func (l *ClientLimiter) Wait(ctx context.Context, clientID string, method string
 //
 // Returns nil if no rate limiter is applicable.
 func (l *ClientLimiter) getLimiterForRequest(clientID string, method string) *rate.Limiter {
+	limiter, _, _ := l.limiterAndLimitsForRequest(clientID, method)
+
+	return limiter
+}
+
+// limiterAndLimitsForRequest is getLimiterForRequest plus the rps/burst that
+// applied, so callers that need to report quota (Reserve) don't have to
+// duplicate the method/per-client/global precedence logic.
+func (l *ClientLimiter) limiterAndLimitsForRequest(clientID, method string) (limiter *rate.Limiter, rps float64, burst int) {
 	// Check for method-specific override first
 	if method != "" {
 		if methodLimit, exists := l.config.MethodLimits[method]; exists {
 			// Create a unique key combining client and method
 			key := fmt.Sprintf("%s:%s", clientID, method)
 
-			return l.getOrCreateLimiter(key, methodLimit.RPS, methodLimit.Burst)
+			return l.getOrCreateLimiter(key, methodLimit.RPS, methodLimit.Burst), methodLimit.RPS, methodLimit.Burst
 		}
 	}
 
 	// If client ID is provided, use per-client limiter
 	if clientID != "" && l.config.PerClientRPS > 0 {
-		return l.getOrCreateLimiter(clientID, l.config.PerClientRPS, l.config.PerClientBurst)
+		return l.getOrCreateLimiter(clientID, l.config.PerClientRPS, l.config.PerClientBurst), l.config.PerClientRPS, l.config.PerClientBurst
 	}
 
 	// Fall back to global limiter
-	return l.globalLimiter
+	return l.globalLimiter, l.config.GlobalRPS, l.config.GlobalBurst
 }
 
 // getOrCreateLimiter gets an existing rate limiter or creates a new one.
@@ -191,13 +449,15 @@ func (l *ClientLimiter) getLimiterForRequest(clientID string, method string) *ra
 func (l *ClientLimiter) getOrCreateLimiter(key string, rps float64, burst int) *rate.Limiter {
 	// Fast path: check if limiter already exists
 	if value, exists := l.limiters.Load(key); exists {
-		limiter, ok := value.(*rate.Limiter)
+		entry, ok := value.(*limiterEntry)
 		if !ok {
 			// This should never happen as we control what goes into the map
-			panic(fmt.Sprintf("invalid type in limiters map: expected *rate.Limiter, got %T", value))
+			panic(fmt.Sprintf("invalid type in limiters map: expected *limiterEntry, got %T", value))
 		}
 
-		return limiter
+		entry.lastTouched.Store(nowFunc().UnixNano())
+
+		return entry.limiter
 	}
 
 	// If RPS is zero, don't create a limiter (unlimited)
@@ -207,8 +467,10 @@ func (l *ClientLimiter) getOrCreateLimiter(key string, rps float64, burst int) *
 
 	// Slow path: create new limiter
 	// Use LoadOrStore to handle race conditions (multiple goroutines creating for same key)
-	newLimiter := rate.NewLimiter(rate.Limit(rps), burst)
-	actual, loaded := l.limiters.LoadOrStore(key, newLimiter)
+	newEntry := &limiterEntry{limiter: rate.NewLimiter(rate.Limit(rps), burst), burst: burst}
+	newEntry.lastTouched.Store(nowFunc().UnixNano())
+
+	actual, loaded := l.limiters.LoadOrStore(key, newEntry)
 
 	if !loaded {
 		logger.Debug("Created new rate limiter",
@@ -218,13 +480,140 @@ func (l *ClientLimiter) getOrCreateLimiter(key string, rps float64, burst int) *
 		)
 	}
 
-	limiter, ok := actual.(*rate.Limiter)
+	entry, ok := actual.(*limiterEntry)
 	if !ok {
 		// This should never happen as we control what goes into the map
-		panic(fmt.Sprintf("invalid type in limiters map: expected *rate.Limiter, got %T", actual))
+		panic(fmt.Sprintf("invalid type in limiters map: expected *limiterEntry, got %T", actual))
 	}
 
-	return limiter
+	if loaded {
+		entry.lastTouched.Store(nowFunc().UnixNano())
+	}
+
+	return entry.limiter
+}
+
+// runJanitor periodically evicts idle limiters until Close stops it.
+func (l *ClientLimiter) runJanitor() {
+	defer close(l.janitorDone)
+
+	ticker := time.NewTicker(l.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stopJanitor:
+			return
+		case <-ticker.C:
+			l.evictIdle()
+		}
+	}
+}
+
+// evictIdle removes limiters whose bucket has sat full for at least
+// config.MaxIdleAge, so a deployment with a large, ever-changing population
+// of clients doesn't grow the limiters map without bound.
+func (l *ClientLimiter) evictIdle() {
+	now := nowFunc()
+
+	l.limiters.Range(func(key, value interface{}) bool {
+		entry, ok := value.(*limiterEntry)
+		if !ok {
+			// This should never happen as we control what goes into the map
+			panic(fmt.Sprintf("invalid type in limiters map: expected *limiterEntry, got %T", value))
+		}
+
+		idleSince := now.Sub(time.Unix(0, entry.lastTouched.Load()))
+		if entry.limiter.Tokens() >= float64(entry.burst) && idleSince >= l.config.MaxIdleAge {
+			l.limiters.Delete(key)
+		}
+
+		return true
+	})
+}
+
+// Close stops the idle-limiter janitor goroutine, if one was started. It is
+// safe to call on a ClientLimiter that never started a janitor (rate
+// limiting disabled, a distributed backend, or CleanupInterval unset).
+// server.New calls this via the io.Closer returned by ServerOptions on
+// server shutdown.
+//
+// Close also closes the DistributedBackend created by newDistributedBackend,
+// when that backend implements io.Closer.
+func (l *ClientLimiter) Close() error {
+	if l.stopJanitor != nil {
+		close(l.stopJanitor)
+		<-l.janitorDone
+	}
+
+	if closer, ok := l.distributed.(io.Closer); ok {
+		return closer.Close() //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// RateLimitHint carries retry/quota metadata for a clientID/method, derived
+// from the token bucket's current state, for callers (the interceptors) to
+// attach to gRPC responses as Retry-After/x-ratelimit-* headers.
+type RateLimitHint struct {
+	// RetryAfter is how long until the next token would be available. It is
+	// zero if a token is available now.
+	RetryAfter time.Duration
+
+	// Limit is the configured burst capacity that applied to this request.
+	Limit int
+
+	// Remaining is the number of tokens currently available, floored at zero.
+	Remaining int
+
+	// ResetAfter is how long until the bucket refills to full capacity.
+	ResetAfter time.Duration
+}
+
+// Reserve reports retry/quota metadata for clientID/method without affecting
+// the outcome of a subsequent or prior Allow/Wait call: it computes the
+// delay via a Reservation that is cancelled immediately, which returns its
+// reserved token to the bucket as if it had never been taken. ok is false
+// when no token bucket applies - rate limiting is disabled, a
+// DistributedBackend is configured (which doesn't expose Reservation-level
+// detail), or the request is unlimited - in which case the caller should
+// skip quota headers entirely.
+func (l *ClientLimiter) Reserve(clientID, method string) (hint RateLimitHint, ok bool) {
+	if !l.config.Enabled || l.distributed != nil {
+		return RateLimitHint{}, false
+	}
+
+	limiter, rps, burst := l.limiterAndLimitsForRequest(clientID, method)
+	if limiter == nil {
+		return RateLimitHint{}, false
+	}
+
+	// Reserve, measure and cancel at one instant: Cancel() at a later time
+	// refunds nothing for a reservation whose time to act has already passed.
+	now := time.Now()
+	rsvp := limiter.ReserveN(now, 1)
+	delay := rsvp.DelayFrom(now)
+	rsvp.CancelAt(now)
+
+	tokens := limiter.Tokens()
+
+	remaining := int(tokens)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	var resetAfter time.Duration
+	if rps > 0 && tokens < float64(burst) {
+		resetAfter = time.Duration((float64(burst) - tokens) / rps * float64(time.Second))
+	}
+
+	return RateLimitHint{
+		RetryAfter: delay,
+		Limit:      burst,
+		Remaining:  remaining,
+		ResetAfter: resetAfter,
+	}, true
 }
 
 // GetLimiterCount returns the number of active rate limiters.
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/agntcy/dir/server/middleware/ratelimit/config"
+	"github.com/alicebob/miniredis/v2"
 )
 
 func TestNewClientLimiter(t *testing.T) {
@@ -656,6 +657,123 @@ func TestClientLimiter_PanicOnInvalidTypeInLoadOrStore(t *testing.T) {
 	_ = limiter.Allow(ctx, "client1", "/test/Method")
 }
 
+// TestClientLimiter_DistributedBackend verifies that two ClientLimiter
+// instances backed by the same Redis server share token bucket state - the
+// property that makes the distributed backend useful for horizontally
+// scaled deployments, where the in-memory backend would let each replica
+// enforce its own independent limit.
+func TestClientLimiter_DistributedBackend(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	cfg := &config.Config{
+		Enabled:        true,
+		Backend:        config.BackendRedis,
+		GlobalRPS:      100.0,
+		GlobalBurst:    200,
+		PerClientRPS:   10.0,
+		PerClientBurst: 10,
+		MethodLimits:   make(map[string]config.MethodLimit),
+		Redis: config.RedisConfig{
+			Addr: mr.Addr(),
+		},
+	}
+
+	limiter1, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error: %v", err)
+	}
+
+	limiter2, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Exhaust client1's burst capacity via limiter1.
+	for i := range 10 {
+		if !limiter1.Allow(ctx, "client1", "/test/Method") {
+			t.Errorf("request %d should be allowed (within burst)", i+1)
+		}
+	}
+
+	// limiter2 shares the same Redis-backed bucket, so it should already see
+	// client1 as rate limited even though it never made a request itself.
+	if limiter2.Allow(ctx, "client1", "/test/Method") {
+		t.Error("second replica should observe the bucket client1 already exhausted")
+	}
+
+	// A different client has an independent bucket.
+	if !limiter2.Allow(ctx, "client2", "/test/Method") {
+		t.Error("a different client should have its own, unexhausted bucket")
+	}
+
+	// Wait for token refill (150ms should give us 1-2 tokens at 10 req/sec).
+	time.Sleep(150 * time.Millisecond)
+
+	if !limiter1.Allow(ctx, "client1", "/test/Method") {
+		t.Error("should be allowed after token refill")
+	}
+}
+
+// TestClientLimiter_JanitorEvictsIdleEntries verifies that evictIdle removes
+// full-bucket limiters that have been idle for at least MaxIdleAge, and
+// leaves recently touched or non-full ones in place. It drives evictIdle
+// directly through the fake nowFunc clock instead of waiting on the real
+// CleanupInterval ticker.
+func TestClientLimiter_JanitorEvictsIdleEntries(t *testing.T) {
+	realNow := nowFunc
+	defer func() { nowFunc = realNow }()
+
+	fakeNow := time.Now()
+	nowFunc = func() time.Time { return fakeNow }
+
+	cfg := &config.Config{
+		Enabled: true,
+		// A very high RPS means golang.org/x/time/rate's own (real-clock)
+		// refill tops the bucket back up within nanoseconds of the Allow()
+		// call below, so entry.limiter.Tokens() reads as full by the time
+		// evictIdle runs regardless of the fake nowFunc driving idleSince.
+		PerClientRPS:    1e9,
+		PerClientBurst:  1,
+		CleanupInterval: time.Hour,
+		MaxIdleAge:      time.Minute,
+	}
+
+	limiter, err := NewClientLimiter(cfg)
+	if err != nil {
+		t.Fatalf("NewClientLimiter() error = %v", err)
+	}
+	defer limiter.Close()
+
+	ctx := context.Background()
+
+	// "idle" sits untouched long enough to be evicted.
+	limiter.Allow(ctx, "idle", "/test/Method")
+
+	// "active" is touched again right before the eviction sweep.
+	limiter.Allow(ctx, "active", "/test/Method")
+
+	fakeNow = fakeNow.Add(2 * time.Minute)
+	nowFunc = func() time.Time { return fakeNow }
+
+	limiter.Allow(ctx, "active", "/test/Method")
+
+	limiter.evictIdle()
+
+	if _, ok := limiter.limiters.Load("idle"); ok {
+		t.Error("idle limiter should have been evicted")
+	}
+
+	if _, ok := limiter.limiters.Load("active"); !ok {
+		t.Error("recently touched limiter should not have been evicted")
+	}
+}
+
 // contains checks if a string contains a substring.
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
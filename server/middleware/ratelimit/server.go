@@ -4,12 +4,17 @@
 package ratelimit
 
 import (
+	"io"
+
 	"github.com/agntcy/dir/server/middleware/ratelimit/config"
 	"google.golang.org/grpc"
 )
 
 // ServerOptions creates unary and stream rate limiting interceptors for gRPC server.
 // These interceptors enforce rate limits based on client identity (SPIFFE ID) and method.
+// The returned io.Closer releases the limiter's resources (the ClientLimiter janitor
+// goroutine, or the envoyrls.RLSLimiter's connection) and must be closed on server
+// shutdown.
 //
 // IMPORTANT: These interceptors should be placed AFTER recovery middleware but BEFORE
 // authentication/authorization middleware in the interceptor chain. This ensures:
@@ -24,16 +29,25 @@ import (
 //	serverOpts = append(serverOpts, recovery.ServerOptions()...)
 //	// Rate limiting AFTER recovery
 //	if rateLimitCfg.Enabled {
-//	    serverOpts = append(serverOpts, ratelimit.ServerOptions(rateLimitCfg)...)
+//	    opts, closer, err := ratelimit.ServerOptions(rateLimitCfg)
+//	    serverOpts = append(serverOpts, opts...)
+//	    defer closer.Close() // on server shutdown, not here
 //	}
 //	// Logging and auth interceptors after rate limiting
 //	serverOpts = append(serverOpts, logging.ServerOptions(...)...)
 //	serverOpts = append(serverOpts, authn.GetServerOptions()...)
-func ServerOptions(cfg *config.Config) ([]grpc.ServerOption, error) {
-	// Create the client limiter
-	limiter, err := NewClientLimiter(cfg)
+func ServerOptions(cfg *config.Config) ([]grpc.ServerOption, io.Closer, error) {
+	// Create the limiter for cfg.Backend: a local/distributed token bucket,
+	// or an envoyrls.RLSLimiter delegating to an external RLS deployment.
+	// Both implement io.Closer.
+	limiter, err := NewLimiter(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	closer, ok := limiter.(io.Closer)
+	if !ok {
+		closer = io.NopCloser(nil)
 	}
 
 	return []grpc.ServerOption{
@@ -43,5 +57,5 @@ func ServerOptions(cfg *config.Config) ([]grpc.ServerOption, error) {
 		grpc.ChainStreamInterceptor(
 			StreamServerInterceptor(limiter),
 		),
-	}, nil
+	}, closer, nil
 }
@@ -0,0 +1,119 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/agntcy/dir/server/naming"
+	"github.com/agntcy/dir/server/naming/dns/config"
+)
+
+// ChallengeRecordPrefix is the subdomain prefix for ACME DNS-01-style
+// domain-ownership challenges.
+const ChallengeRecordPrefix = "_agntcy-challenge."
+
+// ChallengeResolver verifies ACME DNS-01-style domain-ownership challenges:
+// the domain publishes the RFC 7638 JWK thumbprint of the signer's key at
+// _agntcy-challenge.<domain>, rather than publishing the key itself as
+// Resolver's dns:// records do.
+//
+// NOTE: resolution uses the stdlib net.Resolver, which does not validate
+// DNSSEC. A DNSSEC-validating resolver can be substituted via
+// WithChallengeResolver once one is added as a dependency.
+type ChallengeResolver struct {
+	// resolver is the underlying DNS resolver (nil uses default).
+	resolver *net.Resolver
+
+	// timeout is the maximum time to wait for DNS resolution.
+	timeout time.Duration
+}
+
+// ChallengeOption configures a ChallengeResolver.
+type ChallengeOption func(*ChallengeResolver)
+
+// WithChallengeTimeout sets the DNS resolution timeout.
+func WithChallengeTimeout(timeout time.Duration) ChallengeOption {
+	return func(r *ChallengeResolver) {
+		r.timeout = timeout
+	}
+}
+
+// WithChallengeResolver sets a custom DNS resolver.
+func WithChallengeResolver(resolver *net.Resolver) ChallengeOption {
+	return func(r *ChallengeResolver) {
+		r.resolver = resolver
+	}
+}
+
+// NewChallengeResolver creates a new ChallengeResolver with the given options.
+func NewChallengeResolver(opts ...ChallengeOption) *ChallengeResolver {
+	r := &ChallengeResolver{
+		timeout: config.DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// NewChallengeResolverFromConfig creates a new ChallengeResolver from configuration.
+func NewChallengeResolverFromConfig(cfg *config.Config) *ChallengeResolver {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	return NewChallengeResolver(WithChallengeTimeout(cfg.Timeout))
+}
+
+// VerifyChallenge reports whether the JWK thumbprint of signingKey is
+// published at _agntcy-challenge.<domain>. It implements
+// naming.ChallengeVerifier.
+func (r *ChallengeResolver) VerifyChallenge(ctx context.Context, domain string, signingKey []byte) (bool, error) {
+	thumbprint, err := naming.ComputeJWKThumbprint(signingKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to compute JWK thumbprint: %w", err)
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	dnsName := ChallengeRecordPrefix + domain
+
+	logger.Debug("Looking up DNS challenge record", "domain", domain, "dnsName", dnsName)
+
+	var records []string
+
+	if r.resolver != nil {
+		records, err = r.resolver.LookupTXT(lookupCtx, dnsName)
+	} else {
+		records, err = net.DefaultResolver.LookupTXT(lookupCtx, dnsName)
+	}
+
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			logger.Debug("No DNS challenge record found", "domain", domain)
+
+			return false, nil
+		}
+
+		return false, fmt.Errorf("DNS lookup failed for %s: %w", dnsName, err)
+	}
+
+	for _, record := range records {
+		if strings.TrimSpace(record) == thumbprint {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -21,6 +21,12 @@ const (
 
 	// HTTPProtocol indicates JWKS well-known verification via HTTP (testing only).
 	HTTPProtocol = "http://"
+
+	// DNSChallengeProtocol indicates ACME DNS-01-style challenge verification.
+	DNSChallengeProtocol = "dns-challenge://"
+
+	// HTTPChallengeProtocol indicates ACME HTTP-01-style challenge verification.
+	HTTPChallengeProtocol = "http-challenge://"
 )
 
 // ParsedName represents a parsed record name with optional protocol prefix.
@@ -41,6 +47,8 @@ type ParsedName struct {
 //   - "dns://cisco.com/agent" -> Protocol: "dns://", Domain: "cisco.com", Path: "agent"
 //   - "https://cisco.com/agent" -> Protocol: "https://", Domain: "cisco.com", Path: "agent"
 //   - "http://localhost:8080/agent" -> Protocol: "http://", Domain: "localhost:8080", Path: "agent"
+//   - "dns-challenge://cisco.com/agent" -> Protocol: "dns-challenge://", Domain: "cisco.com", Path: "agent"
+//   - "http-challenge://cisco.com/agent" -> Protocol: "http-challenge://", Domain: "cisco.com", Path: "agent"
 //   - "cisco.com/agent" -> Protocol: "", Domain: "cisco.com", Path: "agent" (no verification)
 //   - "cisco.com" -> Protocol: "", Domain: "cisco.com", Path: ""
 //
@@ -64,6 +72,12 @@ func ParseName(name string) *ParsedName {
 	case strings.HasPrefix(name, HTTPProtocol):
 		result.Protocol = HTTPProtocol
 		remaining = strings.TrimPrefix(name, HTTPProtocol)
+	case strings.HasPrefix(name, DNSChallengeProtocol):
+		result.Protocol = DNSChallengeProtocol
+		remaining = strings.TrimPrefix(name, DNSChallengeProtocol)
+	case strings.HasPrefix(name, HTTPChallengeProtocol):
+		result.Protocol = HTTPChallengeProtocol
+		remaining = strings.TrimPrefix(name, HTTPChallengeProtocol)
 	}
 
 	result.FullName = remaining
@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oidc provides OIDC-federated domain-ownership verification: proof
+// is a Fulcio-issued certificate's SAN (oidc-issuer + oidc-subject) rather
+// than a domain-published key or thumbprint.
+//
+// NOTE: unlike dns.ChallengeResolver and wellknown.ChallengeFetcher, this
+// method does not implement naming.ChallengeVerifier and is not wired into
+// naming.Provider: Provider.Verify only has a record's DER-encoded public
+// key to work with, but OIDC proof lives in the signature's Sigstore bundle,
+// which Provider never sees. Verifier is exposed as a standalone helper for
+// callers that do have the signature (e.g. reconciler/tasks/regsync, or a
+// future naming controller code path) instead.
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	"github.com/agntcy/dir/utils/cosign"
+)
+
+// Verifier checks that a signature's Fulcio certificate SAN matches an
+// expected OIDC issuer and subject, reusing the same cosign verification
+// stack as `dirctl verify` (signv1.DefaultVerifyOptionsOIDC).
+type Verifier struct {
+	// ExpectedIssuer is the OIDC issuer the signing certificate must carry.
+	ExpectedIssuer string
+
+	// ExpectedSubject is the OIDC subject/identity the signing certificate must carry.
+	ExpectedSubject string
+}
+
+// NewVerifier creates a Verifier for the given expected OIDC issuer/subject.
+func NewVerifier(expectedIssuer, expectedSubject string) *Verifier {
+	return &Verifier{
+		ExpectedIssuer:  expectedIssuer,
+		ExpectedSubject: expectedSubject,
+	}
+}
+
+// VerifySignature reports whether sig's Sigstore bundle was signed by the
+// configured OIDC issuer/subject.
+func (v *Verifier) VerifySignature(ctx context.Context, sig *signv1.Signature, expectedPayload []byte) (bool, error) {
+	if sig.GetContentBundle() == "" {
+		return false, errors.New("signature has no sigstore bundle")
+	}
+
+	bundleBytes, err := base64.StdEncoding.DecodeString(sig.GetContentBundle())
+	if err != nil {
+		return false, fmt.Errorf("failed to decode bundle: %w", err)
+	}
+
+	result, err := cosign.VerifySignatureWithOIDC(ctx, &cosign.VerifyOIDCOptions{
+		BundleJSON:       string(bundleBytes),
+		ExpectedPayload:  expectedPayload,
+		ExpectedIssuer:   v.ExpectedIssuer,
+		ExpectedIdentity: v.ExpectedSubject,
+	})
+	if err != nil {
+		return false, fmt.Errorf("OIDC verification failed: %w", err)
+	}
+
+	return result.Verified, nil
+}
@@ -34,6 +34,12 @@ type Provider struct {
 
 	// wellKnown is the fetcher for JWKS well-known files.
 	wellKnown KeyLookupWithScheme
+
+	// dnsChallenge verifies ACME DNS-01-style domain-ownership challenges.
+	dnsChallenge ChallengeVerifier
+
+	// httpChallenge verifies ACME HTTP-01-style domain-ownership challenges.
+	httpChallenge ChallengeVerifier
 }
 
 // ProviderOption configures a Provider.
@@ -53,6 +59,20 @@ func WithWellKnownLookup(wk KeyLookupWithScheme) ProviderOption {
 	}
 }
 
+// WithDNSChallengeVerifier sets the DNS-01-style challenge verifier.
+func WithDNSChallengeVerifier(v ChallengeVerifier) ProviderOption {
+	return func(p *Provider) {
+		p.dnsChallenge = v
+	}
+}
+
+// WithHTTPChallengeVerifier sets the HTTP-01-style challenge verifier.
+func WithHTTPChallengeVerifier(v ChallengeVerifier) ProviderOption {
+	return func(p *Provider) {
+		p.httpChallenge = v
+	}
+}
+
 // NewProvider creates a new naming provider with the given options.
 func NewProvider(opts ...ProviderOption) *Provider {
 	p := &Provider{}
@@ -69,6 +89,8 @@ func NewProvider(opts ...ProviderOption) *Provider {
 //   - dns://domain/path -> use DNS TXT records
 //   - https://domain/path -> use JWKS well-known file via HTTPS
 //   - http://domain/path -> use JWKS well-known file via HTTP (testing only)
+//   - dns-challenge://domain/path -> ACME DNS-01-style JWK thumbprint challenge
+//   - http-challenge://domain/path -> ACME HTTP-01-style JWK thumbprint challenge
 //   - domain/path -> no verification (protocol prefix required)
 func (p *Provider) Verify(ctx context.Context, recordName string, signingKey []byte) *Result {
 	result := &Result{
@@ -91,6 +113,12 @@ func (p *Provider) Verify(ctx context.Context, recordName string, signingKey []b
 		"protocol", parsed.Protocol,
 		"recordName", recordName)
 
+	// Challenge-based methods publish a hash of signingKey rather than the
+	// key itself, so they can't reuse the lookupKeys/MatchKey flow below.
+	if challengeResult, handled := p.verifyChallenge(ctx, parsed, signingKey, result); handled {
+		return challengeResult
+	}
+
 	// Look up keys for the domain based on protocol
 	keys, method, err := p.lookupKeys(ctx, parsed)
 	if err != nil {
@@ -179,3 +207,55 @@ func (p *Provider) lookupKeys(ctx context.Context, parsed *ParsedName) ([]Public
 		return nil, MethodNone, errors.New("no verification protocol specified in name (use dns://, https://, or http:// prefix)")
 	}
 }
+
+// verifyChallenge handles the dns-challenge:// and http-challenge://
+// protocols. It returns handled=false for any other protocol, so Verify can
+// fall through to the lookupKeys/MatchKey flow.
+func (p *Provider) verifyChallenge(ctx context.Context, parsed *ParsedName, signingKey []byte, result *Result) (*Result, bool) {
+	var (
+		verifier ChallengeVerifier
+		method   VerificationMethod
+	)
+
+	switch parsed.Protocol {
+	case DNSChallengeProtocol:
+		verifier, method = p.dnsChallenge, MethodDNSChallenge
+	case HTTPChallengeProtocol:
+		verifier, method = p.httpChallenge, MethodHTTPChallenge
+	default:
+		return nil, false
+	}
+
+	result.Method = string(method)
+
+	if verifier == nil {
+		result.Error = fmt.Sprintf("%s verification not configured", method)
+
+		providerLogger.Debug("Challenge verifier not configured", "domain", parsed.Domain, "method", method)
+
+		return result, true
+	}
+
+	verified, err := verifier.VerifyChallenge(ctx, parsed.Domain, signingKey)
+	if err != nil {
+		result.Error = err.Error()
+
+		providerLogger.Debug("Challenge lookup failed", "domain", parsed.Domain, "method", method, "error", err)
+
+		return result, true
+	}
+
+	if !verified {
+		result.Error = "signing key thumbprint does not match published challenge"
+
+		providerLogger.Debug("Challenge mismatch", "domain", parsed.Domain, "method", method)
+
+		return result, true
+	}
+
+	result.Verified = true
+
+	providerLogger.Info("Name ownership verified via challenge", "domain", parsed.Domain, "method", method)
+
+	return result, true
+}
@@ -0,0 +1,36 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package naming
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// ComputeJWKThumbprint computes the RFC 7638 JWK thumbprint (SHA-256,
+// base64url without padding) of a DER-encoded public key. It is used by the
+// dns-challenge:// and http-challenge:// verification methods, which publish
+// this thumbprint instead of the raw key that dns:// and https:// publish.
+func ComputeJWKThumbprint(derKey []byte) (string, error) {
+	pub, err := x509.ParsePKIXPublicKey(derKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	key, err := jwk.FromRaw(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert public key to JWK: %w", err)
+	}
+
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute JWK thumbprint: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
@@ -6,7 +6,10 @@
 // and ACME DNS-01 challenge patterns.
 package naming
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // PublicKey represents a public key extracted from DNS TXT or JWKS.
 type PublicKey struct {
@@ -54,6 +57,29 @@ const (
 	// MethodWellKnown indicates verification via JWKS well-known file (RFC 7517).
 	MethodWellKnown VerificationMethod = "wellknown"
 
+	// MethodDNSChallenge indicates verification via an ACME DNS-01-style
+	// challenge: the domain publishes the RFC 7638 JWK thumbprint of the
+	// signer's key rather than the key itself.
+	MethodDNSChallenge VerificationMethod = "dns-challenge"
+
+	// MethodHTTPChallenge indicates verification via an ACME HTTP-01-style
+	// challenge: the domain serves the signer's JWK thumbprint as a static
+	// file, for domain owners who cannot host a JWKS endpoint.
+	MethodHTTPChallenge VerificationMethod = "http-challenge"
+
+	// MethodOIDC indicates verification via a Fulcio-issued certificate's
+	// OIDC issuer/subject SAN rather than a domain-published artifact.
+	MethodOIDC VerificationMethod = "oidc"
+
 	// MethodNone indicates no verification was possible.
 	MethodNone VerificationMethod = "none"
 )
+
+// ChallengeVerifier defines the interface for proof-of-possession challenges:
+// the domain publishes a hash derived from the signer's key (an RFC 7638 JWK
+// thumbprint) rather than the key itself, as KeyLookup implementations do.
+type ChallengeVerifier interface {
+	// VerifyChallenge reports whether the JWK thumbprint of signingKey matches
+	// the challenge value published for domain.
+	VerifyChallenge(ctx context.Context, domain string, signingKey []byte) (bool, error)
+}
@@ -0,0 +1,159 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package wellknown
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agntcy/dir/server/naming"
+	"github.com/agntcy/dir/server/naming/wellknown/config"
+)
+
+// ChallengePath is the path for the ACME HTTP-01-style domain-ownership
+// challenge file.
+const ChallengePath = "/.well-known/agntcy-challenge"
+
+// ChallengeFetcher verifies ACME HTTP-01-style domain-ownership challenges:
+// the domain serves the RFC 7638 JWK thumbprint of the signer's key as a
+// plain-text file at /.well-known/agntcy-challenge. This is useful when the
+// domain owner cannot serve a full JWKS file (Fetcher's method) but can drop
+// a static file.
+type ChallengeFetcher struct {
+	// client is the HTTP client to use for requests.
+	client *http.Client
+
+	// timeout is the maximum time to wait for HTTP requests.
+	timeout time.Duration
+
+	// maxBodySize is the maximum size of the response body to read.
+	maxBodySize int64
+
+	// allowInsecure allows HTTP instead of HTTPS (for testing only).
+	allowInsecure bool
+}
+
+// ChallengeOption configures a ChallengeFetcher.
+type ChallengeOption func(*ChallengeFetcher)
+
+// WithChallengeHTTPClient sets a custom HTTP client.
+func WithChallengeHTTPClient(client *http.Client) ChallengeOption {
+	return func(f *ChallengeFetcher) {
+		f.client = client
+	}
+}
+
+// WithChallengeTimeout sets the HTTP request timeout.
+func WithChallengeTimeout(timeout time.Duration) ChallengeOption {
+	return func(f *ChallengeFetcher) {
+		f.timeout = timeout
+	}
+}
+
+// WithChallengeMaxBodySize sets the maximum response body size.
+func WithChallengeMaxBodySize(size int64) ChallengeOption {
+	return func(f *ChallengeFetcher) {
+		f.maxBodySize = size
+	}
+}
+
+// WithChallengeAllowInsecure allows HTTP instead of HTTPS for challenge
+// fetching. WARNING: Only use for local development/testing.
+func WithChallengeAllowInsecure(allow bool) ChallengeOption {
+	return func(f *ChallengeFetcher) {
+		f.allowInsecure = allow
+	}
+}
+
+// NewChallengeFetcher creates a new ChallengeFetcher with the given options.
+func NewChallengeFetcher(opts ...ChallengeOption) *ChallengeFetcher {
+	f := &ChallengeFetcher{
+		timeout:     config.DefaultTimeout,
+		maxBodySize: config.DefaultMaxBodySize,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if f.client == nil {
+		f.client = &http.Client{
+			Timeout: f.timeout,
+		}
+	}
+
+	return f
+}
+
+// NewChallengeFetcherFromConfig creates a new ChallengeFetcher from configuration.
+func NewChallengeFetcherFromConfig(cfg *config.Config) *ChallengeFetcher {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	return NewChallengeFetcher(
+		WithChallengeTimeout(cfg.Timeout),
+		WithChallengeMaxBodySize(cfg.MaxBodySize),
+		WithChallengeAllowInsecure(cfg.AllowInsecure),
+	)
+}
+
+// VerifyChallenge reports whether the JWK thumbprint of signingKey matches
+// the file served at https://<domain>/.well-known/agntcy-challenge. It
+// implements naming.ChallengeVerifier.
+func (f *ChallengeFetcher) VerifyChallenge(ctx context.Context, domain string, signingKey []byte) (bool, error) {
+	thumbprint, err := naming.ComputeJWKThumbprint(signingKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to compute JWK thumbprint: %w", err)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	scheme := "https"
+	if f.allowInsecure {
+		scheme = "http"
+	}
+
+	url := scheme + "://" + domain + ChallengePath
+
+	logger.Debug("Fetching domain-ownership challenge file", "domain", domain, "url", url)
+
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/plain")
+	req.Header.Set("User-Agent", "AGNTCY-Directory/1.0")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		logger.Debug("Challenge file not found", "domain", domain)
+
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("HTTP request returned status %d", resp.StatusCode)
+	}
+
+	limitedReader := io.LimitReader(resp.Body, f.maxBodySize)
+
+	body, err := io.ReadAll(limitedReader)
+	if err != nil {
+		return false, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return strings.TrimSpace(string(body)) == thumbprint, nil
+}
@@ -19,6 +19,43 @@ const (
 	DefaultTTL = 24 * time.Hour
 )
 
+// ResourceLockType selects the backing resource client-go's leaderelection
+// package coordinates through.
+type ResourceLockType string
+
+const (
+	// ResourceLockLeases uses the coordination.k8s.io Lease resource.
+	// Recommended default since Kubernetes 1.14.
+	ResourceLockLeases ResourceLockType = "leases"
+
+	// ResourceLockConfigMapsLeases uses a ConfigMap alongside a Lease,
+	// for clusters migrating off the older ConfigMap-only lock.
+	ResourceLockConfigMapsLeases ResourceLockType = "configmapsleases"
+
+	// ResourceLockEndpointsLeases uses an Endpoints resource alongside a
+	// Lease, for clusters migrating off the older Endpoints-only lock.
+	ResourceLockEndpointsLeases ResourceLockType = "endpointsleases"
+
+	// DefaultLeaderElectionResourceLock is used when LeaderElectionResourceLock is unset.
+	DefaultLeaderElectionResourceLock = ResourceLockLeases
+
+	// DefaultLeaderElectionNamespace is used when LeaderElectionNamespace is unset.
+	DefaultLeaderElectionNamespace = "default"
+
+	// DefaultLeaderElectionID is used when LeaderElectionID is unset.
+	DefaultLeaderElectionID = "dir-reverification-leader"
+
+	// DefaultLeaseDuration is the default non-leader candidate wait time.
+	DefaultLeaseDuration = 15 * time.Second
+
+	// DefaultRenewDeadline is the default duration the leader retries
+	// refreshing leadership before giving it up.
+	DefaultRenewDeadline = 10 * time.Second
+
+	// DefaultRetryPeriod is the default wait between leadership acquisition attempts.
+	DefaultRetryPeriod = 2 * time.Second
+)
+
 // Config holds configuration for the re-verification service.
 type Config struct {
 	// SchedulerInterval is how often to check for expired verifications.
@@ -32,6 +69,33 @@ type Config struct {
 
 	// TTL is the time-to-live for verifications.
 	TTL time.Duration `json:"ttl,omitempty" mapstructure:"ttl"`
+
+	// LeaderElection enables leader election so that, when the dir server
+	// runs with multiple replicas, only the elected leader's scheduler
+	// dispatches work; followers stay hot-standby.
+	LeaderElection bool `json:"leader_election,omitempty" mapstructure:"leader_election"`
+
+	// LeaderElectionResourceLock selects the backing resource used to hold
+	// the leader lock (leases, configmapsleases, or endpointsleases).
+	LeaderElectionResourceLock ResourceLockType `json:"leader_election_resource_lock,omitempty" mapstructure:"leader_election_resource_lock"`
+
+	// LeaderElectionNamespace is the namespace the lock resource is created in.
+	LeaderElectionNamespace string `json:"leader_election_namespace,omitempty" mapstructure:"leader_election_namespace"`
+
+	// LeaderElectionID names the lock resource, so multiple services in the
+	// same namespace can each elect their own leader independently.
+	LeaderElectionID string `json:"leader_election_id,omitempty" mapstructure:"leader_election_id"`
+
+	// LeaseDuration is how long a non-leader candidate waits before trying
+	// to acquire leadership after observing a renewal failure.
+	LeaseDuration time.Duration `json:"lease_duration,omitempty" mapstructure:"lease_duration"`
+
+	// RenewDeadline is how long the current leader retries refreshing
+	// leadership before giving it up.
+	RenewDeadline time.Duration `json:"renew_deadline,omitempty" mapstructure:"renew_deadline"`
+
+	// RetryPeriod is how long candidates wait between leadership acquisition attempts.
+	RetryPeriod time.Duration `json:"retry_period,omitempty" mapstructure:"retry_period"`
 }
 
 // GetSchedulerInterval returns the scheduler interval with default.
@@ -69,3 +133,57 @@ func (c *Config) GetTTL() time.Duration {
 
 	return c.TTL
 }
+
+// GetLeaderElectionResourceLock returns the configured resource lock type with default.
+func (c *Config) GetLeaderElectionResourceLock() ResourceLockType {
+	if c.LeaderElectionResourceLock == "" {
+		return DefaultLeaderElectionResourceLock
+	}
+
+	return c.LeaderElectionResourceLock
+}
+
+// GetLeaderElectionNamespace returns the configured namespace with default.
+func (c *Config) GetLeaderElectionNamespace() string {
+	if c.LeaderElectionNamespace == "" {
+		return DefaultLeaderElectionNamespace
+	}
+
+	return c.LeaderElectionNamespace
+}
+
+// GetLeaderElectionID returns the configured lock resource name with default.
+func (c *Config) GetLeaderElectionID() string {
+	if c.LeaderElectionID == "" {
+		return DefaultLeaderElectionID
+	}
+
+	return c.LeaderElectionID
+}
+
+// GetLeaseDuration returns the configured lease duration with default.
+func (c *Config) GetLeaseDuration() time.Duration {
+	if c.LeaseDuration == 0 {
+		return DefaultLeaseDuration
+	}
+
+	return c.LeaseDuration
+}
+
+// GetRenewDeadline returns the configured renew deadline with default.
+func (c *Config) GetRenewDeadline() time.Duration {
+	if c.RenewDeadline == 0 {
+		return DefaultRenewDeadline
+	}
+
+	return c.RenewDeadline
+}
+
+// GetRetryPeriod returns the configured retry period with default.
+func (c *Config) GetRetryPeriod() time.Duration {
+	if c.RetryPeriod == 0 {
+		return DefaultRetryPeriod
+	}
+
+	return c.RetryPeriod
+}
@@ -0,0 +1,108 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package election
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agntcy/dir/server/reverification/config"
+	"github.com/agntcy/dir/utils/logging"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+var logger = logging.Logger("reverification/election")
+
+// k8sLocker implements Locker on top of client-go's leaderelection package,
+// following the controller-runtime convention of a "leases" resource lock
+// by default, with fallbacks to "configmapsleases"/"endpointsleases" for
+// clusters migrating off the older lock kinds.
+type k8sLocker struct {
+	lock   resourcelock.Interface
+	config config.Config
+}
+
+// New creates a Locker backed by a Kubernetes resource lock, using
+// in-cluster config when kubeconfig is empty (for in-cluster deployments)
+// or the given kubeconfig path otherwise.
+func New(cfg config.Config, kubeconfig string) (Locker, error) {
+	var (
+		restConfig *rest.Config
+		err        error
+	)
+
+	if kubeconfig == "" {
+		restConfig, err = rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build in-cluster config: %w", err)
+		}
+	} else {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build kubeconfig from flags: %w", err)
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes clientset: %w", err)
+	}
+
+	identity, err := hostIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := resourcelock.New(
+		string(cfg.GetLeaderElectionResourceLock()),
+		cfg.GetLeaderElectionNamespace(),
+		cfg.GetLeaderElectionID(),
+		clientset.CoreV1(),
+		clientset.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: identity},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create leader election resource lock: %w", err)
+	}
+
+	return &k8sLocker{lock: lock, config: cfg}, nil
+}
+
+// Run implements Locker. RunOrDie returns once leadership is lost, so Run
+// campaigns again until ctx is canceled.
+func (l *k8sLocker) Run(ctx context.Context, onStartedLeading func(context.Context), onStoppedLeading func()) error {
+	for ctx.Err() == nil {
+		l.runOnce(ctx, onStartedLeading, onStoppedLeading)
+	}
+
+	return nil
+}
+
+// runOnce runs a single leader-election round: it returns when ctx is
+// canceled or, after acquiring leadership, when leadership is lost.
+func (l *k8sLocker) runOnce(ctx context.Context, onStartedLeading func(context.Context), onStoppedLeading func()) {
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            l.lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   l.config.GetLeaseDuration(),
+		RenewDeadline:   l.config.GetRenewDeadline(),
+		RetryPeriod:     l.config.GetRetryPeriod(),
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leadingCtx context.Context) {
+				logger.Info("Acquired re-verification scheduler leadership", "identity", l.lock.Identity())
+
+				onStartedLeading(leadingCtx)
+			},
+			OnStoppedLeading: func() {
+				logger.Info("Lost re-verification scheduler leadership", "identity", l.lock.Identity())
+
+				onStoppedLeading()
+			},
+		},
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package election provides the pluggable leader-election abstraction used
+// to gate the re-verification scheduler when the dir server runs with
+// multiple replicas, so only the elected leader dispatches work while
+// followers stay hot-standby.
+package election
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+// Locker coordinates leadership across replicas of the re-verification
+// service. The default implementation (New) is backed by a Kubernetes
+// Lease/ConfigMap/Endpoints resource; non-Kubernetes deployments can supply
+// their own Locker (e.g. Postgres advisory locks, an etcd lease) to the
+// same Service.
+type Locker interface {
+	// Run blocks until ctx is canceled, calling onStartedLeading when this
+	// instance acquires leadership and onStoppedLeading if it is lost. The
+	// onStartedLeading context is canceled the moment leadership is lost, so
+	// callers can use it to stop in-flight leader-only work promptly.
+	Run(ctx context.Context, onStartedLeading func(context.Context), onStoppedLeading func()) error
+}
+
+// hostIdentity builds a unique identity for this process's lock record,
+// combining the pod/host name (for readability in `kubectl describe lease`)
+// with a random suffix so restarts don't collide with a stale lock entry.
+func hostIdentity() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine hostname: %w", err)
+	}
+
+	return fmt.Sprintf("%s_%s", hostname, uuid.NewString()), nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/agntcy/dir/server/naming"
 	"github.com/agntcy/dir/server/reverification/config"
+	"github.com/agntcy/dir/server/reverification/election"
 	revtypes "github.com/agntcy/dir/server/reverification/types"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
@@ -25,6 +26,12 @@ type Service struct {
 	provider *naming.Provider
 	config   config.Config
 
+	// locker gates scheduler dispatch when config.LeaderElection is set, so
+	// only the elected leader enqueues work while replicas without
+	// leadership stay hot-standby. Nil (and config.LeaderElection false)
+	// means this instance always acts as leader.
+	locker election.Locker
+
 	scheduler *Scheduler
 	workers   []*Worker
 
@@ -32,13 +39,17 @@ type Service struct {
 	wg     sync.WaitGroup
 }
 
-// New creates a new re-verification service.
-func New(db types.DatabaseAPI, store types.StoreAPI, provider *naming.Provider, cfg config.Config) *Service {
+// New creates a new re-verification service. locker may be nil when
+// cfg.LeaderElection is false; otherwise it gates scheduler dispatch behind
+// leader election (see the election package for the default Kubernetes
+// Locker and the pluggable interface for other backends).
+func New(db types.DatabaseAPI, store types.StoreAPI, provider *naming.Provider, cfg config.Config, locker election.Locker) *Service {
 	return &Service{
 		db:       db,
 		store:    store,
 		provider: provider,
 		config:   cfg,
+		locker:   locker,
 		stopCh:   make(chan struct{}),
 	}
 }
@@ -69,13 +80,26 @@ func (s *Service) Start(ctx context.Context) error {
 		)
 	}
 
-	// Start scheduler
+	// Start the scheduler, gated behind leader election when configured so
+	// only the elected leader dispatches work; followers' workers stay
+	// running but idle since nothing reaches the shared work queue.
 	s.wg.Add(1)
 
 	go func() {
 		defer s.wg.Done()
 
-		s.scheduler.Run(ctx, s.stopCh)
+		if !s.config.LeaderElection || s.locker == nil {
+			s.scheduler.Run(ctx, s.stopCh)
+
+			return
+		}
+
+		if err := s.locker.Run(ctx,
+			func(leaderCtx context.Context) { s.scheduler.Run(leaderCtx, s.stopCh) },
+			func() { logger.Info("Stepped down from re-verification leadership; scheduler paused") },
+		); err != nil {
+			logger.Error("Leader election failed", "error", err)
+		}
 	}()
 
 	// Start workers
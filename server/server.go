@@ -6,6 +6,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
@@ -19,23 +20,40 @@ import (
 	"github.com/agntcy/dir/server/config"
 	"github.com/agntcy/dir/server/controller"
 	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/deviceauth"
+	"github.com/agntcy/dir/server/healthcheck"
+	"github.com/agntcy/dir/server/internal/admin"
 	"github.com/agntcy/dir/server/internal/p2p"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/middleware/concurrency"
+	"github.com/agntcy/dir/server/middleware/ratelimit"
 	"github.com/agntcy/dir/server/routing"
 	"github.com/agntcy/dir/server/store"
+	"github.com/agntcy/dir/server/telemetry"
 	"github.com/agntcy/dir/server/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
+// adminShutdownTimeout bounds how long Close waits for the internal admin
+// server to drain in-flight requests (pprof profiles in particular can be
+// slow) before the process exits.
+const adminShutdownTimeout = 5 * time.Second
+
 var _ types.API = &Server{}
 
 type Server struct {
-	options       types.APIOptions
-	store         types.StoreAPI
-	routing       types.RoutingAPI
-	healthzServer *healthz.Server
-	grpcServer    *grpc.Server
-	p2pServer     *p2p.Server
+	options           types.APIOptions
+	store             types.StoreAPI
+	routing           types.RoutingAPI
+	healthzServer     *healthz.Server
+	healthChecker     *healthcheck.Checker
+	grpcServer        *grpc.Server
+	p2pServer         *p2p.Server
+	adminServer       *admin.Server
+	deviceAuthServer  *deviceauth.Server
+	telemetryProvider *telemetry.Provider
+	rateLimiter       io.Closer
 }
 
 func Run(ctx context.Context, cfg *config.Config) error {
@@ -101,22 +119,93 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to create routing: %w", err)
 	}
 
+	// Create the Prometheus registry shared by the OTEL MeterProvider below
+	// and, when enabled, the internal admin server's /metrics endpoint.
+	metricsServer := metrics.New(cfg.Metrics.Address)
+
+	// Build the OTEL MeterProvider before the gRPC server so every RPC is
+	// instrumented via otelgrpc from the first call onward, instead of
+	// registering interceptors after the fact.
+	telemetryProvider, err := telemetry.New(ctx, cfg.Telemetry.OTLP, metricsServer.Registry())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry provider: %w", err)
+	}
+
+	grpcServerOptions := telemetryProvider.ServerOptions()
+
+	// Rate limiting is opt-in: only build the limiter (and its interceptors)
+	// when enabled, so a deployment that never configures it doesn't pay for
+	// an idle janitor goroutine.
+	var rateLimiter io.Closer
+
+	if cfg.RateLimit.Enabled {
+		rateLimitOptions, closer, err := ratelimit.ServerOptions(&cfg.RateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rate limiter: %w", err)
+		}
+
+		grpcServerOptions = append(grpcServerOptions, rateLimitOptions...)
+		rateLimiter = closer
+	}
+
+	// Concurrency limiting is likewise opt-in, chained after rate limiting so
+	// both admission controls apply: rate limiting bounds how often a client
+	// can start a request, concurrency limiting bounds how many of those
+	// requests may still be running.
+	if cfg.Concurrency.Enabled {
+		concurrencyOptions, err := concurrency.ServerOptions(&cfg.Concurrency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create concurrency limiter: %w", err)
+		}
+
+		grpcServerOptions = append(grpcServerOptions, concurrencyOptions...)
+	}
+
 	// Create server
 	server := &Server{
-		options:       options,
-		store:         storeAPI,
-		routing:       routingAPI,
-		healthzServer: healthz.NewHealthServer(cfg.HealthCheckAddress),
-		grpcServer:    grpc.NewServer(),
+		options:           options,
+		store:             storeAPI,
+		routing:           routingAPI,
+		healthzServer:     healthz.NewHealthServer(cfg.HealthCheckAddress),
+		healthChecker:     healthcheck.New(),
+		grpcServer:        grpc.NewServer(grpcServerOptions...),
+		telemetryProvider: telemetryProvider,
+		rateLimiter:       rateLimiter,
 	}
 
 	// Register APIs
 	storetypes.RegisterStoreServiceServer(server.grpcServer, controller.NewStoreController(storeAPI))
 	routingtypes.RegisterRoutingServiceServer(server.grpcServer, controller.NewRoutingController(routingAPI, storeAPI))
 
+	// Register gRPC health service so the internal admin server's
+	// /healthz and /readyz can bridge into it.
+	server.healthChecker.Register(server.grpcServer)
+
 	// Register server
 	reflection.Register(server.grpcServer)
 
+	// Create and start the internal admin server (metrics, pprof, health, buildinfo)
+	// on a dedicated port, separate from the user-facing gRPC listener.
+	if cfg.Admin.Enabled {
+		server.adminServer = admin.New(cfg.Admin, metricsServer.Registry(), server.healthChecker, admin.NewBuildInfo(enabledFeatures(cfg)))
+		if err := server.adminServer.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start admin server: %w", err)
+		}
+	}
+
+	// Create and start the device authorization grant server (POST
+	// /device/code, POST /token, GET /device), opt-in via DeviceAuth.Enabled.
+	if cfg.DeviceAuth.Enabled {
+		server.deviceAuthServer = deviceauth.New(dstore, cfg.DeviceAuth, cfg.Expiry.DeviceRequests)
+		if err := server.deviceAuthServer.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start device authorization server: %w", err)
+		}
+	}
+
+	if err := server.healthChecker.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start health checker: %w", err)
+	}
+
 	// Start server
 	if err := server.start(ctx); err != nil {
 		return nil, fmt.Errorf("failed to start server: %w", err)
@@ -125,6 +214,30 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 	return server, nil
 }
 
+// enabledFeatures reports the set of optional server features enabled by
+// cfg, surfaced to operators via the admin server's /buildinfo endpoint.
+func enabledFeatures(cfg *config.Config) []string {
+	features := []string{}
+
+	if cfg.Authn.Enabled {
+		features = append(features, "authn")
+	}
+
+	if cfg.Authz.Enabled {
+		features = append(features, "authz")
+	}
+
+	if cfg.Naming.Enabled {
+		features = append(features, "naming")
+	}
+
+	if cfg.DeviceAuth.Enabled {
+		features = append(features, "deviceauth")
+	}
+
+	return features
+}
+
 func (s Server) Options() types.APIOptions { return s.options }
 
 func (s Server) Store() types.StoreAPI { return s.store }
@@ -132,6 +245,45 @@ func (s Server) Store() types.StoreAPI { return s.store }
 func (s Server) Routing() types.RoutingAPI { return s.routing }
 
 func (s Server) Close() {
+	if s.adminServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), adminShutdownTimeout)
+		defer cancel()
+
+		if err := s.adminServer.Stop(shutdownCtx); err != nil {
+			log.Printf("failed to stop admin server: %v", err)
+		}
+	}
+
+	if s.deviceAuthServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), adminShutdownTimeout)
+		defer cancel()
+
+		if err := s.deviceAuthServer.Stop(shutdownCtx); err != nil {
+			log.Printf("failed to stop device authorization server: %v", err)
+		}
+	}
+
+	if s.healthChecker != nil {
+		if err := s.healthChecker.Stop(context.Background()); err != nil {
+			log.Printf("failed to stop health checker: %v", err)
+		}
+	}
+
+	if s.telemetryProvider != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), adminShutdownTimeout)
+		defer cancel()
+
+		if err := s.telemetryProvider.Shutdown(shutdownCtx); err != nil {
+			log.Printf("failed to stop telemetry provider: %v", err)
+		}
+	}
+
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.Close(); err != nil {
+			log.Printf("failed to stop rate limiter: %v", err)
+		}
+	}
+
 	s.p2pServer.Close()
 	s.grpcServer.GracefulStop()
 }
@@ -0,0 +1,15 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config provides configuration for the signing service.
+package config
+
+// Config holds configuration for the signing service.
+type Config struct {
+	// TrustPolicyPath, if set, points at a YAML or JSON file of named
+	// cosign.TrustPolicy entries. When present, OIDC/keyless signature
+	// verification selects a policy by record CID and enforces its
+	// allowed identities and required verifiers instead of accepting any
+	// Sigstore-valid signer.
+	TrustPolicyPath string `json:"trust_policy_path,omitempty" mapstructure:"trust_policy_path"`
+}
@@ -13,6 +13,7 @@ import (
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/registry"
+	"github.com/agntcy/dir/utils/cosign"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/agntcy/dir/utils/zot"
 )
@@ -21,9 +22,10 @@ var logger = logging.Logger("signing")
 
 // sign handles signature operations for records.
 type sign struct {
-	store     types.ReferrerStoreAPI
-	ociConfig *ociconfig.Config
-	zotConfig *zot.VerifyConfig
+	store         types.ReferrerStoreAPI
+	ociConfig     *ociconfig.Config
+	zotConfig     *zot.VerifyConfig
+	trustPolicies *cosign.TrustPolicyStore
 }
 
 // New creates a new signing service.
@@ -55,6 +57,17 @@ func New(storeAPI types.StoreAPI, opts types.APIOptions) (types.SigningAPI, erro
 		logger.Debug("Signing service configured with Zot verification")
 	}
 
+	if path := cfg.Signing.TrustPolicyPath; path != "" {
+		trustPolicies, err := cosign.LoadTrustPolicyStore(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load trust policy file: %w", err)
+		}
+
+		s.trustPolicies = trustPolicies
+
+		logger.Info("Signing service configured with trust policy file", "path", path, "policies", len(trustPolicies.Policies))
+	}
+
 	logger.Info("Signing service created")
 
 	// Wrap with event emitter
@@ -101,7 +101,7 @@ func (s *sign) Verify(ctx context.Context, recordCID string, options *signv1.Ver
 		}
 
 		// Verify signature
-		signerInfo, err := s.verifySignature(ctx, sig, expectedPayload, publicKeys, options)
+		signerInfo, err := s.verifySignature(ctx, recordCID, sig, expectedPayload, publicKeys, options)
 		if err != nil {
 			logger.Error("Signature verification failed", "error", err, "hasBundle", sig.GetContentBundle() != "")
 			// Cache the failed verification (only if no specific options)
@@ -138,6 +138,10 @@ func (s *sign) Verify(ctx context.Context, recordCID string, options *signv1.Ver
 			legacyMetadata["provider"] = "oidc"
 			legacyMetadata["oidc.issuer"] = oidcInfo.GetIssuer()
 			legacyMetadata["oidc.identity"] = oidcInfo.GetIdentity()
+		} else if keylessInfo := signer.GetKeyless(); keylessInfo != nil {
+			legacyMetadata["provider"] = "keyless"
+			legacyMetadata["oidc.issuer"] = keylessInfo.GetIssuer()
+			legacyMetadata["oidc.identity"] = keylessInfo.GetIdentity()
 		}
 	}
 
@@ -151,9 +155,10 @@ func (s *sign) Verify(ctx context.Context, recordCID string, options *signv1.Ver
 }
 
 // verifySignature verifies a single signature and returns signer information.
-// It handles both key-based and OIDC-based signatures.
+// It handles key-based, OIDC-based, and keyless (Fulcio/OIDC) signatures.
 func (s *sign) verifySignature(
 	ctx context.Context,
+	recordCID string,
 	sig *signv1.Signature,
 	expectedPayload []byte,
 	publicKeys []string,
@@ -161,7 +166,11 @@ func (s *sign) verifySignature(
 ) (*signv1.SignerInfo, error) {
 	// Check if this is an OIDC-signed signature (has a bundle)
 	if sig.GetContentBundle() != "" {
-		return s.verifyOIDCSignature(ctx, sig, expectedPayload, options)
+		if options.GetKeyless() != nil {
+			return s.verifyKeylessSignature(ctx, recordCID, sig, expectedPayload, options.GetKeyless())
+		}
+
+		return s.verifyOIDCSignature(ctx, recordCID, sig, expectedPayload, options)
 	}
 
 	// Otherwise, try key-based verification
@@ -171,6 +180,7 @@ func (s *sign) verifySignature(
 // verifyOIDCSignature verifies a signature with a Sigstore bundle (OIDC-based).
 func (s *sign) verifyOIDCSignature(
 	ctx context.Context,
+	recordCID string,
 	sig *signv1.Signature,
 	expectedPayload []byte,
 	options *signv1.VerifyOptions,
@@ -191,8 +201,10 @@ func (s *sign) verifyOIDCSignature(
 
 	// Build verification options based on trust root configuration
 	verifyOpts := &cosign.VerifyOIDCOptions{
-		BundleJSON:      bundleJSON,
-		ExpectedPayload: expectedPayload,
+		BundleJSON:       bundleJSON,
+		ExpectedPayload:  expectedPayload,
+		TrustPolicyStore: s.trustPolicies,
+		ArtifactScope:    recordCID,
 	}
 
 	// Detect staging environment from bundle content
@@ -252,6 +264,69 @@ func (s *sign) verifyOIDCSignature(
 	}, nil
 }
 
+// verifyKeylessSignature verifies a Sigstore bundle signature against a
+// keyless (Fulcio/OIDC) policy: the signing certificate must chain to the
+// configured Fulcio roots, and its identity/issuer must match the given
+// regular expressions. Unlike verifyOIDCSignature, the exact issuer and
+// identity don't need to be known in advance.
+func (s *sign) verifyKeylessSignature(
+	ctx context.Context,
+	recordCID string,
+	sig *signv1.Signature,
+	expectedPayload []byte,
+	keylessOpts *signv1.KeylessVerifyOptions,
+) (*signv1.SignerInfo, error) {
+	bundleBytes, err := base64.StdEncoding.DecodeString(sig.GetContentBundle())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode bundle: %w", err)
+	}
+
+	bundleJSON := string(bundleBytes)
+
+	verifyOpts := &cosign.VerifyOIDCOptions{
+		BundleJSON:             bundleJSON,
+		ExpectedPayload:        expectedPayload,
+		ExpectedIssuerRegexp:   keylessOpts.GetCertificateOidcIssuer(),
+		ExpectedIdentityRegexp: keylessOpts.GetCertificateIdentity(),
+		TrustPolicyStore:       s.trustPolicies,
+		ArtifactScope:          recordCID,
+	}
+
+	// Detect staging environment from the bundle content or an explicit
+	// staging Rekor URL, same as verifyOIDCSignature.
+	if strings.Contains(bundleJSON, "sigstage.dev") || strings.Contains(keylessOpts.GetRekorURL(), "sigstage.dev") {
+		verifyOpts.UseStaging = true
+	}
+
+	if keylessOpts.GetFulcioRoots() != "" {
+		verifyOpts.TrustRoot = &cosign.TrustRootConfig{
+			FulcioRootPEM: keylessOpts.GetFulcioRoots(),
+		}
+	}
+
+	result, err := cosign.VerifySignatureWithOIDC(ctx, verifyOpts)
+	if err != nil {
+		return nil, fmt.Errorf("keyless verification failed: %w", err)
+	}
+
+	if !result.Verified {
+		return nil, fmt.Errorf("bundle signature invalid")
+	}
+
+	if result.MatchedPolicy != "" {
+		logger.Debug("Signature matched trust policy", "recordCID", recordCID, "policy", result.MatchedPolicy)
+	}
+
+	return &signv1.SignerInfo{
+		SignerType: &signv1.SignerInfo_Keyless{
+			Keyless: &signv1.KeylessSignerInfo{
+				Issuer:   result.Issuer,
+				Identity: result.Identity,
+			},
+		},
+	}, nil
+}
+
 // verifyKeySignature verifies a key-based signature.
 func (s *sign) verifyKeySignature(
 	sig *signv1.Signature,
@@ -269,8 +344,8 @@ func (s *sign) verifyKeySignature(
 		keysToCheck = publicKeys
 	}
 
-	// If options specify OIDC verification, skip key-based signatures
-	if options.GetOidc() != nil {
+	// If options specify OIDC or keyless verification, skip key-based signatures
+	if options.GetOidc() != nil || options.GetKeyless() != nil {
 		return nil, nil
 	}
 
@@ -369,6 +444,10 @@ func (s *sign) cacheVerificationResult(recordCID, sigDigest string, signerInfo *
 			input.SignerType = "oidc"
 			input.OIDCIssuer = oidcInfo.GetIssuer()
 			input.OIDCIdentity = oidcInfo.GetIdentity()
+		} else if keylessInfo := signerInfo.GetKeyless(); keylessInfo != nil {
+			input.SignerType = "keyless"
+			input.OIDCIssuer = keylessInfo.GetIssuer()
+			input.OIDCIdentity = keylessInfo.GetIdentity()
 		}
 	}
 
@@ -403,6 +482,15 @@ func (s *sign) cachedToSignerInfo(cached types.SignatureVerificationObject) *sig
 				},
 			},
 		}
+	case "keyless":
+		return &signv1.SignerInfo{
+			SignerType: &signv1.SignerInfo_Keyless{
+				Keyless: &signv1.KeylessSignerInfo{
+					Issuer:   cached.GetOIDCIssuer(),
+					Identity: cached.GetOIDCIdentity(),
+				},
+			},
+		}
 	default:
 		return nil
 	}
@@ -45,4 +45,10 @@ type VerificationConfig struct {
 	// WARNING: Only use for local development/testing. Never enable in production.
 	// Default: false
 	AllowInsecure bool `json:"allow_insecure,omitempty" mapstructure:"allow_insecure"`
+
+	// ChallengeMethods lists which domain-ownership verification methods to
+	// attempt, in order: "http-01", "dns-01", and/or "well-known". A method
+	// absent from the list is never attempted.
+	// Default (empty): well-known only, preserving the original behavior.
+	ChallengeMethods []string `json:"challenge_methods,omitempty" mapstructure:"challenge_methods"`
 }
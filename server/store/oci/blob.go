@@ -0,0 +1,350 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/google/uuid"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Descriptor identifies a blob by digest, size and media type. It is the
+// blob-service counterpart of ocispec.Descriptor, trimmed to the fields
+// BlobDescriptorService/BlobProvider/BlobIngester need.
+type Descriptor struct {
+	Digest    digest.Digest
+	Size      int64
+	MediaType string
+}
+
+// descriptorFromOCI converts an ocispec.Descriptor to a Descriptor.
+func descriptorFromOCI(d ocispec.Descriptor) Descriptor {
+	return Descriptor{Digest: d.Digest, Size: d.Size, MediaType: d.MediaType}
+}
+
+// BlobDescriptorService tracks the descriptor (digest, size, media type)
+// registered for a blob, independent of fetching its content. This mirrors
+// the distribution project's blob-service split, separating the cheap
+// metadata path from content reads.
+type BlobDescriptorService interface {
+	// Stat returns the descriptor registered for dgst.
+	Stat(ctx context.Context, dgst digest.Digest) (Descriptor, error)
+
+	// SetDescriptor records desc as the descriptor for dgst, so a later Stat
+	// (or Lookup) can answer without re-resolving against the registry.
+	SetDescriptor(ctx context.Context, dgst digest.Digest, desc Descriptor) error
+
+	// Clear removes any descriptor recorded for dgst.
+	Clear(ctx context.Context, dgst digest.Digest) error
+}
+
+// ReadSeekCloser supports random-access reads over a fully buffered blob.
+type ReadSeekCloser interface {
+	io.Reader
+	io.Seeker
+	io.Closer
+}
+
+// BlobProvider reads blob content by digest.
+type BlobProvider interface {
+	// Open returns a seekable reader over the blob identified by dgst.
+	Open(ctx context.Context, dgst digest.Digest) (ReadSeekCloser, error)
+
+	// Get returns the full content of the blob identified by dgst.
+	Get(ctx context.Context, dgst digest.Digest) ([]byte, error)
+}
+
+// BlobWriter accumulates a blob's content across possibly-resumed writes
+// before it is committed (pushed) to the registry under a verified digest.
+type BlobWriter interface {
+	io.Writer
+
+	// ID identifies this upload so it can be resumed after a process restart.
+	ID() string
+
+	// Size returns the number of bytes written so far.
+	Size() int64
+
+	// Cancel discards the in-progress upload.
+	Cancel(ctx context.Context) error
+
+	// Commit finalizes the upload, validating the written content against
+	// expected (when expected.Digest is set) before pushing it and returning
+	// the canonical Descriptor.
+	Commit(ctx context.Context, expected Descriptor) (Descriptor, error)
+}
+
+// BlobIngester begins a resumable blob upload.
+type BlobIngester interface {
+	// Writer opens a new upload.
+	Writer(ctx context.Context) (BlobWriter, error)
+}
+
+// ManifestService provides CRUD access to manifests, layered on top of the
+// blob services since a manifest is itself pushed/fetched as a blob.
+type ManifestService interface {
+	// Exists reports whether a manifest is registered under dgst.
+	Exists(ctx context.Context, dgst digest.Digest) (bool, error)
+
+	// Get fetches and parses the manifest registered under dgst.
+	Get(ctx context.Context, dgst digest.Digest) (*ocispec.Manifest, error)
+
+	// Put pushes manifest and returns its descriptor.
+	Put(ctx context.Context, manifest *ocispec.Manifest) (Descriptor, error)
+
+	// Delete removes the manifest registered under dgst.
+	Delete(ctx context.Context, dgst digest.Digest) error
+}
+
+// Descriptors, Blobs, Ingester and Manifests compose *store out of the
+// blob-oriented services above. Push/Pull/Lookup remain thin, backward
+// compatible wrappers; new code should prefer these narrower services.
+//
+//nolint:ireturn
+func (s *store) Descriptors() BlobDescriptorService { return ociBlobService{store: s} }
+
+//nolint:ireturn
+func (s *store) Blobs() BlobProvider { return ociBlobService{store: s} }
+
+//nolint:ireturn
+func (s *store) Ingester() BlobIngester { return ociBlobService{store: s} }
+
+//nolint:ireturn
+func (s *store) Manifests() ManifestService { return ociManifestService{store: s} }
+
+// ociBlobService implements BlobDescriptorService, BlobProvider and
+// BlobIngester over a *store.
+type ociBlobService struct {
+	store *store
+}
+
+var (
+	_ BlobDescriptorService = ociBlobService{}
+	_ BlobProvider          = ociBlobService{}
+	_ BlobIngester          = ociBlobService{}
+)
+
+// cidForDigest converts dgst to its CID representation, wrapping conversion
+// failures as a gRPC InvalidArgument error.
+func cidForDigest(dgst digest.Digest) (string, error) {
+	cid, err := corev1.ConvertDigestToCID(dgst)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid digest %s: %v", dgst, err)
+	}
+
+	return cid, nil
+}
+
+// Stat returns the descriptor registered for dgst, preferring the local
+// cache over a registry round trip.
+func (b ociBlobService) Stat(ctx context.Context, dgst digest.Digest) (Descriptor, error) {
+	cid, err := cidForDigest(dgst)
+	if err != nil {
+		return Descriptor{}, err
+	}
+
+	if meta, ok := b.store.cache.get(cid); ok {
+		return Descriptor{Digest: dgst, Size: int64(meta.GetSize()), MediaType: meta.GetMediaType()}, nil
+	}
+
+	desc, err := b.store.repo.Resolve(ctx, dgst.String())
+	if err != nil {
+		return Descriptor{}, status.Errorf(codes.NotFound, "failed to stat blob %s: %v", dgst, err)
+	}
+
+	return descriptorFromOCI(desc), nil
+}
+
+// SetDescriptor records desc in the local cache for dgst, so a later Stat or
+// Lookup can answer without a registry round trip.
+func (b ociBlobService) SetDescriptor(_ context.Context, dgst digest.Digest, desc Descriptor) error {
+	cid, err := cidForDigest(dgst)
+	if err != nil {
+		return err
+	}
+
+	b.store.cache.putMeta(cid, &storev1.ObjectMeta{
+		Cid:       cid,
+		Size:      uint64(desc.Size),
+		MediaType: desc.MediaType,
+	})
+
+	return nil
+}
+
+// Clear removes any cached descriptor for dgst.
+func (b ociBlobService) Clear(_ context.Context, dgst digest.Digest) error {
+	cid, err := cidForDigest(dgst)
+	if err != nil {
+		return err
+	}
+
+	b.store.cache.invalidate(cid)
+
+	return nil
+}
+
+// seekableBlob adapts a bytes.Reader to ReadSeekCloser.
+type seekableBlob struct {
+	*bytes.Reader
+}
+
+func (s *seekableBlob) Close() error { return nil }
+
+// Open returns a seekable reader over the blob identified by dgst. The blob
+// is buffered fully in memory, since s.repo's Fetch only exposes a
+// streaming io.ReadCloser.
+func (b ociBlobService) Open(ctx context.Context, dgst digest.Digest) (ReadSeekCloser, error) {
+	data, err := b.Get(ctx, dgst)
+	if err != nil {
+		return nil, err
+	}
+
+	return &seekableBlob{Reader: bytes.NewReader(data)}, nil
+}
+
+// Get returns the full, decompressed content of the blob identified by dgst.
+func (b ociBlobService) Get(ctx context.Context, dgst digest.Digest) ([]byte, error) {
+	rd, err := b.store.repo.Fetch(ctx, ocispec.Descriptor{Digest: dgst})
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to fetch blob %s: %v", dgst, err)
+	}
+	defer rd.Close()
+
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read blob %s: %v", dgst, err)
+	}
+
+	return data, nil
+}
+
+// blobUpload is the in-memory BlobWriter implementation. Content is buffered
+// locally and only pushed to the registry on Commit, mirroring the
+// buffering approach already used by storeWriter in content.go.
+type blobUpload struct {
+	store *store
+	id    string
+	buf   bytes.Buffer
+}
+
+// Writer begins a new resumable upload.
+func (b ociBlobService) Writer(_ context.Context) (BlobWriter, error) {
+	return &blobUpload{store: b.store, id: uuid.NewString()}, nil
+}
+
+func (u *blobUpload) Write(p []byte) (int, error) {
+	return u.buf.Write(p) //nolint:wrapcheck
+}
+
+func (u *blobUpload) ID() string { return u.id }
+
+func (u *blobUpload) Size() int64 { return int64(u.buf.Len()) }
+
+// Cancel discards the buffered content. Since nothing is pushed to the
+// registry until Commit, there is nothing remote to clean up.
+func (u *blobUpload) Cancel(_ context.Context) error {
+	u.buf.Reset()
+
+	return nil
+}
+
+// Commit pushes the buffered content and returns its canonical descriptor,
+// compressed according to the store's configuration exactly like Push.
+func (u *blobUpload) Commit(ctx context.Context, expected Descriptor) (Descriptor, error) {
+	data := u.buf.Bytes()
+
+	actualDigest := digest.FromBytes(data)
+	if expected.Digest != "" && expected.Digest != actualDigest {
+		return Descriptor{}, status.Errorf(codes.InvalidArgument,
+			"committed content digest %s does not match expected digest %s", actualDigest, expected.Digest)
+	}
+
+	mediaType := expected.MediaType
+	if mediaType == "" {
+		mediaType = ocispec.MediaTypeImageLayer
+	}
+
+	compressed, err := compressForPush(mediaType, data, u.store.config)
+	if err != nil {
+		return Descriptor{}, status.Errorf(codes.Internal, "failed to compress committed blob: %v", err)
+	}
+
+	desc, err := u.store.pushDescriptorOrSkip(ctx, compressed.desc, compressed.data)
+	if err != nil {
+		return Descriptor{}, status.Errorf(codes.Internal, "failed to push committed blob: %v", err)
+	}
+
+	return descriptorFromOCI(desc), nil
+}
+
+// ociManifestService implements ManifestService over a *store.
+type ociManifestService struct {
+	store *store
+}
+
+var _ ManifestService = ociManifestService{}
+
+// Exists reports whether a manifest is registered under dgst.
+func (m ociManifestService) Exists(ctx context.Context, dgst digest.Digest) (bool, error) {
+	exists, err := m.store.repo.Exists(ctx, ocispec.Descriptor{Digest: dgst, MediaType: ocispec.MediaTypeImageManifest})
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to check manifest existence for %s: %v", dgst, err)
+	}
+
+	return exists, nil
+}
+
+// Get fetches and parses the manifest registered under dgst.
+func (m ociManifestService) Get(ctx context.Context, dgst digest.Digest) (*ocispec.Manifest, error) {
+	manifest, _, err := m.store.fetchAndParseManifest(ctx, dgst.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// Put pushes manifest and returns its descriptor.
+func (m ociManifestService) Put(ctx context.Context, manifest *ocispec.Manifest) (Descriptor, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return Descriptor{}, status.Errorf(codes.Internal, "failed to marshal manifest: %v", err)
+	}
+
+	desc, err := m.store.pushDescriptorOrSkip(ctx, ocispec.Descriptor{
+		Digest:      digest.FromBytes(data),
+		Size:        int64(len(data)),
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Annotations: manifest.Annotations,
+	}, data)
+	if err != nil {
+		return Descriptor{}, status.Errorf(codes.Internal, "failed to push manifest: %v", err)
+	}
+
+	return descriptorFromOCI(desc), nil
+}
+
+// Delete removes the manifest registered under dgst from the local cache.
+// The underlying registry does not support deleting individual manifests;
+// untagging/garbage collection is left to the registry's own lifecycle.
+func (m ociManifestService) Delete(_ context.Context, dgst digest.Digest) error {
+	cid, err := cidForDigest(dgst)
+	if err != nil {
+		return err
+	}
+
+	m.store.cache.invalidate(cid)
+
+	return status.Errorf(codes.Unimplemented, "manifest deletion is not supported by this store")
+}
@@ -0,0 +1,192 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/klauspost/compress/zstd"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// gzipMediaTypeSuffix and zstdMediaTypeSuffix are appended to a blob's
+	// base media type to record which compression, if any, was applied
+	// (e.g. "application/vnd.oci.image.layer.v1.tar+gzip").
+	gzipMediaTypeSuffix = "+gzip"
+	zstdMediaTypeSuffix = "+zstd"
+
+	// diffIDAnnotation records the digest of the uncompressed content, so
+	// callers can verify content identity without decompressing first.
+	// Mirrors the OCI image-spec's config.rootfs diff_id convention.
+	diffIDAnnotation = "io.agntcy.dir.diffid"
+)
+
+// compressedDescriptor is the result of compressing a blob for Push: the
+// descriptor to push (with its compressed media type and diff-id annotation
+// already set), the compressed bytes, and the digest of the content before
+// compression.
+type compressedDescriptor struct {
+	desc   ocispec.Descriptor
+	data   []byte
+	diffID digest.Digest
+}
+
+// compressForPush compresses data according to cfg's compression settings
+// and returns the descriptor/bytes ready to push under mediaType (suffixed
+// to reflect the compression applied). If compression is disabled, data is
+// returned unchanged and diffID equals the pushed digest.
+func compressForPush(mediaType string, data []byte, cfg ociconfig.Config) (compressedDescriptor, error) {
+	diffID := digest.FromBytes(data)
+
+	alg := cfg.GetCompressionAlgorithm()
+	if alg == ociconfig.CompressionNone {
+		return compressedDescriptor{
+			desc: ocispec.Descriptor{
+				Digest:    diffID,
+				MediaType: mediaType,
+				Size:      int64(len(data)),
+			},
+			data:   data,
+			diffID: diffID,
+		}, nil
+	}
+
+	compressed, suffix, err := compressBytes(data, alg, cfg.GetCompressionLevel())
+	if err != nil {
+		return compressedDescriptor{}, fmt.Errorf("failed to compress blob: %w", err)
+	}
+
+	compressedMediaType := mediaType + suffix
+	compressedDigest := digest.FromBytes(compressed)
+
+	return compressedDescriptor{
+		desc: ocispec.Descriptor{
+			Digest:    compressedDigest,
+			MediaType: compressedMediaType,
+			Size:      int64(len(compressed)),
+			Annotations: map[string]string{
+				diffIDAnnotation: diffID.String(),
+			},
+		},
+		data:   compressed,
+		diffID: diffID,
+	}, nil
+}
+
+// compressBytes compresses data with the given algorithm and level, and
+// returns the compressed bytes along with the media type suffix identifying
+// the algorithm used.
+func compressBytes(data []byte, alg ociconfig.CompressionAlgorithm, level int) ([]byte, string, error) {
+	var buf bytes.Buffer
+
+	switch alg {
+	case ociconfig.CompressionGzip:
+		gzipLevel := level
+		if gzipLevel == 0 {
+			gzipLevel = gzip.DefaultCompression
+		}
+
+		w, err := gzip.NewWriterLevel(&buf, gzipLevel)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create gzip writer: %w", err)
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return nil, "", fmt.Errorf("failed to gzip-compress data: %w", err)
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, "", fmt.Errorf("failed to close gzip writer: %w", err)
+		}
+
+		return buf.Bytes(), gzipMediaTypeSuffix, nil
+	case ociconfig.CompressionZstd:
+		opts := []zstd.EOption{zstd.WithEncoderLevel(zstdEncoderLevel(level))}
+
+		w, err := zstd.NewWriter(&buf, opts...)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create zstd writer: %w", err)
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return nil, "", fmt.Errorf("failed to zstd-compress data: %w", err)
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, "", fmt.Errorf("failed to close zstd writer: %w", err)
+		}
+
+		return buf.Bytes(), zstdMediaTypeSuffix, nil
+	case ociconfig.CompressionNone:
+		return data, "", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported compression algorithm: %s", alg)
+	}
+}
+
+// zstdEncoderLevel maps a generic 0-N compression level to zstd's named
+// encoder levels, defaulting to the library's default when level is unset.
+func zstdEncoderLevel(level int) zstd.EncoderLevel {
+	switch {
+	case level <= 0:
+		return zstd.SpeedDefault
+	case level == 1:
+		return zstd.SpeedFastest
+	case level >= 3:
+		return zstd.SpeedBestCompression
+	default:
+		return zstd.SpeedBetterCompression
+	}
+}
+
+// decompressOnPull transparently decompresses rd based on mediaType's
+// compression suffix, returning the underlying reader unchanged for any
+// media type that carries no recognized suffix.
+func decompressOnPull(_ context.Context, mediaType string, rd io.ReadCloser) (io.ReadCloser, error) {
+	switch {
+	case strings.HasSuffix(mediaType, gzipMediaTypeSuffix):
+		gr, err := gzip.NewReader(rd)
+		if err != nil {
+			rd.Close()
+
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+
+		return &decompressingReadCloser{Reader: gr, src: rd}, nil
+	case strings.HasSuffix(mediaType, zstdMediaTypeSuffix):
+		zr, err := zstd.NewReader(rd)
+		if err != nil {
+			rd.Close()
+
+			return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+
+		return &decompressingReadCloser{Reader: zr.IOReadCloser(), src: rd}, nil
+	default:
+		return rd, nil
+	}
+}
+
+// decompressingReadCloser closes both the decompression reader and the
+// underlying source reader it was wrapping.
+type decompressingReadCloser struct {
+	io.Reader
+	src io.ReadCloser
+}
+
+func (d *decompressingReadCloser) Close() error {
+	if closer, ok := d.Reader.(io.Closer); ok {
+		closer.Close()
+	}
+
+	return d.src.Close() //nolint:wrapcheck
+}
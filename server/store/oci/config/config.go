@@ -3,7 +3,11 @@
 
 package config
 
-import "github.com/agntcy/dir/utils/logging"
+import (
+	"time"
+
+	"github.com/agntcy/dir/utils/logging"
+)
 
 var logger = logging.Logger("store/oci/config")
 
@@ -49,6 +53,70 @@ const (
 	DefaultRepositoryName     = "dir"
 )
 
+// CompressionAlgorithm selects how Push compresses blob content before
+// writing it to the backing registry.
+type CompressionAlgorithm string
+
+const (
+	// CompressionNone disables compression; blobs are pushed exactly as given.
+	// This is the default, preserving existing behavior.
+	CompressionNone CompressionAlgorithm = ""
+
+	// CompressionGzip compresses blobs with gzip, appending a "+gzip" suffix
+	// to the blob's media type.
+	CompressionGzip CompressionAlgorithm = "gzip"
+
+	// CompressionZstd compresses blobs with zstd, appending a "+zstd" suffix
+	// to the blob's media type.
+	CompressionZstd CompressionAlgorithm = "zstd"
+
+	// DefaultCompressionLevel is used when CompressionLevel is left unset.
+	DefaultCompressionLevel = 0
+)
+
+const (
+	// DefaultCacheMaxBlobSize is the largest non-manifest blob that will be
+	// mirrored into the local manifest cache when CacheConfig.MaxBlobSize is
+	// left unset. Manifests are always cached regardless of size.
+	DefaultCacheMaxBlobSize = 64 * 1024 // 64 KiB
+
+	// DefaultCacheTTL is how long a cached manifest/blob is served before the
+	// cache treats it as stale and re-fetches from the remote registry.
+	DefaultCacheTTL = 10 * time.Minute
+)
+
+// CacheConfig configures the local manifest/blob cache that sits in front of
+// a remote OCI registry backend.
+type CacheConfig struct {
+	// MaxBlobSize bounds the size (in bytes) of a non-manifest blob (e.g. a
+	// small config) that will be mirrored locally. Zero uses DefaultCacheMaxBlobSize.
+	MaxBlobSize int64 `json:"max_blob_size,omitempty" mapstructure:"max_blob_size"`
+
+	// TTL bounds how long a cached entry is served before being refreshed
+	// from the remote registry. Zero uses DefaultCacheTTL; a negative value
+	// disables expiry (cached entries are served until invalidated).
+	TTL time.Duration `json:"ttl,omitempty" mapstructure:"ttl"`
+}
+
+// GetMaxBlobSize returns the configured max blob size, or DefaultCacheMaxBlobSize
+// if unset.
+func (c CacheConfig) GetMaxBlobSize() int64 {
+	if c.MaxBlobSize == 0 {
+		return DefaultCacheMaxBlobSize
+	}
+
+	return c.MaxBlobSize
+}
+
+// GetTTL returns the configured cache TTL, or DefaultCacheTTL if unset.
+func (c CacheConfig) GetTTL() time.Duration {
+	if c.TTL == 0 {
+		return DefaultCacheTTL
+	}
+
+	return c.TTL
+}
+
 type Config struct {
 	// Type specifies the registry type (zot, ghcr, dockerhub).
 	// Defaults to "zot" for backward compatibility.
@@ -62,6 +130,36 @@ type Config struct {
 	// If empty, caching will not be used.
 	CacheDir string `json:"cache_dir,omitempty" mapstructure:"cache_dir"`
 
+	// LeasesEnabled turns on the protective lease tracked around every Push
+	// (see server/store/oci.LeaseManager). Defaults to false: nothing in this
+	// tree yet runs a garbage collection pass that consults leases, so until
+	// one exists, enabling this only adds a bbolt transaction pair per push
+	// with no corresponding protection.
+	LeasesEnabled bool `json:"leases_enabled,omitempty" mapstructure:"leases_enabled"`
+
+	// Path to the lease metadata database used to pin in-flight content
+	// against concurrent garbage collection. Only used when LeasesEnabled is
+	// true. If empty but CacheDir is set, defaults to a "leases.db" file
+	// under CacheDir. If both are empty, leases are not tracked.
+	LeaseDBPath string `json:"lease_db_path,omitempty" mapstructure:"lease_db_path"`
+
+	// Cache configures the local manifest/blob cache placed in front of a
+	// remote registry backend. Only used when CacheDir is set.
+	Cache CacheConfig `json:"cache,omitempty" mapstructure:"cache"`
+
+	// CompressionAlgorithm selects the compression applied to blobs on Push.
+	// Empty (CompressionNone) preserves existing passthrough behavior.
+	CompressionAlgorithm CompressionAlgorithm `json:"compression_algorithm,omitempty" mapstructure:"compression_algorithm"`
+
+	// CompressionLevel is the algorithm-specific compression level. Zero uses
+	// the algorithm's default level.
+	CompressionLevel int `json:"compression_level,omitempty" mapstructure:"compression_level"`
+
+	// ForcePassthrough disables compression regardless of CompressionAlgorithm,
+	// for callers that need to guarantee byte-for-byte passthrough behavior
+	// (e.g. compatibility with older clients reading this registry directly).
+	ForcePassthrough bool `json:"force_passthrough,omitempty" mapstructure:"force_passthrough"`
+
 	// Registry address to connect to
 	RegistryAddress string `json:"registry_address,omitempty" mapstructure:"registry_address"`
 
@@ -81,6 +179,26 @@ func (c Config) GetType() RegistryType {
 	return c.Type
 }
 
+// GetCompressionAlgorithm returns the configured compression algorithm, or
+// CompressionNone if ForcePassthrough is set or no algorithm is configured.
+func (c Config) GetCompressionAlgorithm() CompressionAlgorithm {
+	if c.ForcePassthrough {
+		return CompressionNone
+	}
+
+	return c.CompressionAlgorithm
+}
+
+// GetCompressionLevel returns the configured compression level, or
+// DefaultCompressionLevel if unset.
+func (c Config) GetCompressionLevel() int {
+	if c.CompressionLevel == 0 {
+		return DefaultCompressionLevel
+	}
+
+	return c.CompressionLevel
+}
+
 // AuthConfig represents the configuration for authentication.
 type AuthConfig struct {
 	Insecure bool `json:"insecure" mapstructure:"insecure"`
@@ -0,0 +1,98 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+)
+
+// ContentStore exposes lower-level, containerd-style content primitives over
+// the OCI backend, alongside the higher-level Push/Pull/Lookup operations on
+// *store. It exists mainly so that multi-step ingestion (e.g. sign-then-publish)
+// can hold resources open under a lease without going through a full
+// Push/Pull round-trip for every intermediate step.
+type ContentStore interface {
+	// Writer opens a ContentWriter for a new blob of the given media type.
+	// The write is only visible to the store once the writer is closed.
+	Writer(ctx context.Context, mediaType string) (ContentWriter, error)
+	// ReaderAt returns random-access content for ref.
+	ReaderAt(ctx context.Context, ref *storev1.ObjectRef) (io.ReaderAt, error)
+	// Info returns metadata for ref, identical to Lookup.
+	Info(ctx context.Context, ref *storev1.ObjectRef) (*storev1.ObjectMeta, error)
+	// Delete removes ref from the store.
+	Delete(ctx context.Context, ref *storev1.ObjectRef) error
+	// Walk traverses ref and its children, identical to the Walk on *store.
+	Walk(ctx context.Context, head *storev1.ObjectRef, walkFn func(*storev1.ObjectMeta) error, walkOpts ...WalkOption) error
+}
+
+// ContentWriter is an in-progress content write. The resulting ObjectRef is
+// only valid once Close returns a nil error.
+type ContentWriter interface {
+	io.WriteCloser
+	// Ref returns the pushed object's reference. Only valid after Close.
+	Ref() *storev1.ObjectRef
+}
+
+var _ ContentStore = (*store)(nil)
+
+// storeWriter buffers writes in memory and pushes them as a single blob on
+// Close, mirroring how pushOrSkip already reads its input reader fully
+// before pushing.
+type storeWriter struct {
+	ctx       context.Context //nolint:containedctx
+	store     *store
+	mediaType string
+	buf       bytes.Buffer
+	ref       *storev1.ObjectRef
+}
+
+func (w *storeWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p) //nolint:wrapcheck
+}
+
+func (w *storeWriter) Close() error {
+	ref, err := w.store.Push(w.ctx, w.mediaType, io.NopCloser(bytes.NewReader(w.buf.Bytes())))
+	if err != nil {
+		return fmt.Errorf("failed to push buffered content: %w", err)
+	}
+
+	w.ref = ref
+
+	return nil
+}
+
+func (w *storeWriter) Ref() *storev1.ObjectRef {
+	return w.ref
+}
+
+// Writer implements ContentStore.
+func (s *store) Writer(ctx context.Context, mediaType string) (ContentWriter, error) {
+	return &storeWriter{ctx: ctx, store: s, mediaType: mediaType}, nil
+}
+
+// ReaderAt implements ContentStore.
+func (s *store) ReaderAt(ctx context.Context, ref *storev1.ObjectRef) (io.ReaderAt, error) {
+	_, rd, err := s.Pull(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull object with CID %s: %w", ref.GetCid(), err)
+	}
+	defer rd.Close()
+
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object with CID %s: %w", ref.GetCid(), err)
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+// Info implements ContentStore.
+func (s *store) Info(ctx context.Context, ref *storev1.ObjectRef) (*storev1.ObjectMeta, error) {
+	return s.Lookup(ctx, ref) //nolint:wrapcheck
+}
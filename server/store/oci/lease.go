@@ -0,0 +1,190 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	leaseBucket    = []byte("leases")
+	resourceBucket = []byte("lease_resources")
+)
+
+// Lease pins a set of content resources against garbage collection for as
+// long as it exists, borrowed from containerd's lease model
+// (https://github.com/containerd/containerd/blob/main/leases/lease.go).
+// A zero ExpiresAt means the lease never expires on its own and must be
+// deleted explicitly.
+type Lease struct {
+	ID        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	Labels    map[string]string
+}
+
+// LeaseResource identifies one piece of content pinned by a lease.
+type LeaseResource struct {
+	CID  string
+	Type string
+}
+
+// LeaseManager creates and tracks leases that protect content from
+// concurrent garbage collection while it is being written, or while it
+// otherwise needs to stay live across multiple steps (e.g. sign-then-publish).
+type LeaseManager interface {
+	// Create starts a new lease with the given TTL (zero means no expiry)
+	// and labels.
+	Create(ctx context.Context, ttl time.Duration, labels map[string]string) (*Lease, error)
+	// Delete removes a lease and all of its tracked resources.
+	Delete(ctx context.Context, leaseID string) error
+	// AddResource pins resource against garbage collection for the lifetime
+	// of leaseID.
+	AddResource(ctx context.Context, leaseID string, resource LeaseResource) error
+	// ListResources returns every resource currently pinned by leaseID.
+	ListResources(ctx context.Context, leaseID string) ([]LeaseResource, error)
+}
+
+// boltLeaseManager is a LeaseManager backed by an embedded bbolt database, so
+// lease state survives process restarts and can be inspected independently
+// of the OCI content store itself.
+type boltLeaseManager struct {
+	db *bolt.DB
+}
+
+// NewBoltLeaseManager opens (creating if necessary) a bbolt-backed
+// LeaseManager at dbPath.
+func NewBoltLeaseManager(dbPath string) (LeaseManager, error) {
+	db, err := bolt.Open(dbPath, 0o600, &bolt.Options{Timeout: 5 * time.Second}) //nolint:mnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lease database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(leaseBucket); err != nil {
+			return fmt.Errorf("failed to create lease bucket: %w", err)
+		}
+
+		if _, err := tx.CreateBucketIfNotExists(resourceBucket); err != nil {
+			return fmt.Errorf("failed to create lease resource bucket: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &boltLeaseManager{db: db}, nil
+}
+
+func (m *boltLeaseManager) Create(_ context.Context, ttl time.Duration, labels map[string]string) (*Lease, error) {
+	now := time.Now()
+
+	lease := &Lease{
+		ID:        uuid.NewString(),
+		CreatedAt: now,
+		Labels:    labels,
+	}
+	if ttl > 0 {
+		lease.ExpiresAt = now.Add(ttl)
+	}
+
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lease: %w", err)
+	}
+
+	err = m.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(leaseBucket).Put([]byte(lease.ID), data) //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to persist lease: %w", err)
+	}
+
+	return lease, nil
+}
+
+func (m *boltLeaseManager) Delete(_ context.Context, leaseID string) error {
+	err := m.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(leaseBucket).Delete([]byte(leaseID)); err != nil {
+			return fmt.Errorf("failed to delete lease: %w", err)
+		}
+
+		resources := tx.Bucket(resourceBucket)
+		prefix := resourceKeyPrefix(leaseID)
+
+		cursor := resources.Cursor()
+		for key, _ := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+			if err := resources.Delete(key); err != nil {
+				return fmt.Errorf("failed to delete lease resource: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete lease %s: %w", leaseID, err)
+	}
+
+	return nil
+}
+
+func (m *boltLeaseManager) AddResource(_ context.Context, leaseID string, resource LeaseResource) error {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return fmt.Errorf("failed to encode lease resource: %w", err)
+	}
+
+	err = m.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(leaseBucket).Get([]byte(leaseID)) == nil {
+			return fmt.Errorf("lease %s not found", leaseID)
+		}
+
+		key := append(resourceKeyPrefix(leaseID), []byte(resource.CID)...)
+
+		return tx.Bucket(resourceBucket).Put(key, data) //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add resource to lease %s: %w", leaseID, err)
+	}
+
+	return nil
+}
+
+func (m *boltLeaseManager) ListResources(_ context.Context, leaseID string) ([]LeaseResource, error) {
+	var resources []LeaseResource
+
+	err := m.db.View(func(tx *bolt.Tx) error {
+		prefix := resourceKeyPrefix(leaseID)
+		cursor := tx.Bucket(resourceBucket).Cursor()
+
+		for key, value := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, value = cursor.Next() {
+			var resource LeaseResource
+			if err := json.Unmarshal(value, &resource); err != nil {
+				return fmt.Errorf("failed to decode lease resource: %w", err)
+			}
+
+			resources = append(resources, resource)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources for lease %s: %w", leaseID, err)
+	}
+
+	return resources, nil
+}
+
+func resourceKeyPrefix(leaseID string) []byte {
+	return []byte(leaseID + "/")
+}
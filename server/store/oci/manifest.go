@@ -1,13 +1,18 @@
 package oci
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"path"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	imagespecs "github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"oras.land/oras-go/v2"
 )
 
@@ -99,3 +104,41 @@ func ManifestToObject(manifest *ocispec.Manifest) (*storev1.Object, error) {
 
 	return &object, nil
 }
+
+// fetchAndParseManifestFromDescriptor fetches the manifest identified by desc
+// from the store's backing repository and parses it as an OCI manifest.
+func (s *store) fetchAndParseManifestFromDescriptor(ctx context.Context, desc ocispec.Descriptor) (*ocispec.Manifest, error) {
+	reader, err := s.repo.Fetch(ctx, desc)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to fetch manifest %s: %v", desc.Digest.String(), err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read manifest %s: %v", desc.Digest.String(), err)
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse manifest %s: %v", desc.Digest.String(), err)
+	}
+
+	return &manifest, nil
+}
+
+// fetchAndParseManifest resolves ref to a descriptor and parses the manifest
+// it points to.
+func (s *store) fetchAndParseManifest(ctx context.Context, ref string) (*ocispec.Manifest, ocispec.Descriptor, error) {
+	desc, err := s.repo.Resolve(ctx, ref)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, status.Errorf(codes.NotFound, "failed to resolve manifest %s: %v", ref, err)
+	}
+
+	manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, desc)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, err
+	}
+
+	return manifest, desc, nil
+}
@@ -0,0 +1,156 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+// cachedEntry is a local mirror of one piece of remote content, plus the
+// metadata needed to answer Lookup without talking to the registry again.
+type cachedEntry struct {
+	meta     *storev1.ObjectMeta
+	cachedAt time.Time
+}
+
+// manifestCache mirrors remote manifests (and small config/blobs, up to
+// MaxBlobSize) into a local content/oci store keyed by digest, so repeated
+// Lookup/Pull calls against the same digest avoid a registry round trip.
+// A nil *manifestCache is valid and behaves as a no-op cache.
+type manifestCache struct {
+	local       oras.GraphTarget
+	ttl         time.Duration
+	maxBlobSize int64
+
+	mu      sync.Mutex
+	entries map[string]cachedEntry
+}
+
+// newManifestCache opens a local content/oci store under cfg.CacheDir to
+// back the cache, or returns nil if no cache directory is configured.
+func newManifestCache(cfg ociconfig.Config) (*manifestCache, error) {
+	if cfg.CacheDir == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	local, err := oci.New(cfg.CacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open local manifest cache at %s: %w", cfg.CacheDir, err)
+	}
+
+	return &manifestCache{
+		local:       local,
+		ttl:         cfg.Cache.GetTTL(),
+		maxBlobSize: cfg.Cache.GetMaxBlobSize(),
+		entries:     make(map[string]cachedEntry),
+	}, nil
+}
+
+// get returns cached metadata for cid, if present and not expired.
+func (c *manifestCache) get(cid string) (*storev1.ObjectMeta, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[cid]
+	c.mu.Unlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if c.ttl > 0 && time.Since(entry.cachedAt) > c.ttl {
+		return nil, false
+	}
+
+	return entry.meta, true
+}
+
+// fetchContent returns the locally mirrored bytes for desc, if present and
+// not expired.
+func (c *manifestCache) fetchContent(ctx context.Context, cid string, desc ocispec.Descriptor) (io.ReadCloser, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[cid]
+	c.mu.Unlock()
+
+	if !ok || (c.ttl > 0 && time.Since(entry.cachedAt) > c.ttl) {
+		return nil, false
+	}
+
+	exists, err := c.local.Exists(ctx, desc)
+	if err != nil || !exists {
+		return nil, false
+	}
+
+	rd, err := c.local.Fetch(ctx, desc)
+	if err != nil {
+		return nil, false
+	}
+
+	return rd, true
+}
+
+// putMeta records meta for cid without mirroring any content bytes, for
+// Lookup call sites that never hold the raw content in hand.
+func (c *manifestCache) putMeta(cid string, meta *storev1.ObjectMeta) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.entries[cid] = cachedEntry{meta: meta, cachedAt: time.Now()}
+	c.mu.Unlock()
+}
+
+// put mirrors data locally (manifests unconditionally, other media types up
+// to maxBlobSize) and records meta so future Lookups are served from cache.
+func (c *manifestCache) put(ctx context.Context, cid string, desc ocispec.Descriptor, data []byte, meta *storev1.ObjectMeta) {
+	if c == nil {
+		return
+	}
+
+	if desc.MediaType != ocispec.MediaTypeImageManifest && int64(len(data)) > c.maxBlobSize {
+		return
+	}
+
+	if exists, err := c.local.Exists(ctx, desc); err == nil && !exists {
+		if err := c.local.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+			logger.Debug("Failed to mirror content into local manifest cache", "cid", cid, "error", err)
+
+			return
+		}
+	}
+
+	c.mu.Lock()
+	c.entries[cid] = cachedEntry{meta: meta, cachedAt: time.Now()}
+	c.mu.Unlock()
+}
+
+// invalidate drops any cached metadata for cid, so the next Lookup/Pull
+// falls back to the remote registry.
+func (c *manifestCache) invalidate(cid string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	delete(c.entries, cid)
+	c.mu.Unlock()
+}
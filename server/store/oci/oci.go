@@ -4,9 +4,11 @@
 package oci
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"path/filepath"
 	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
@@ -32,16 +34,57 @@ const (
 	initialRetryDelay = 50 * time.Millisecond
 	// maxRetryDelay is the maximum delay between retries.
 	maxRetryDelay = 500 * time.Millisecond
+	// pushLeaseTTL bounds how long a Push's protective lease is held, in case
+	// the process dies before the deferred release runs.
+	pushLeaseTTL = 5 * time.Minute
+	// defaultLeaseDBFilename is used under cfg.CacheDir when cfg.LeaseDBPath
+	// is not set explicitly.
+	defaultLeaseDBFilename = "leases.db"
 )
 
 type store struct {
 	repo   oras.GraphTarget
 	config ociconfig.Config
+	// leases pins in-flight pushes against concurrent garbage collection.
+	// Nil when no cache/lease directory is configured.
+	leases LeaseManager
+	// cache mirrors remote manifests (and small blobs) locally so repeated
+	// Lookup/Pull calls avoid a registry round trip. Nil when no cache
+	// directory is configured, or when the store itself is already local.
+	cache *manifestCache
+}
+
+// newLeaseManager opens a bbolt-backed LeaseManager under cfg.LeaseDBPath
+// (or cfg.CacheDir/leases.db), or returns nil if cfg.LeasesEnabled is false
+// or neither path is configured. LeasesEnabled defaults to false: nothing
+// in this tree runs a GC pass that consults leases yet, so there is no
+// protection to buy with the per-push bbolt transaction pair until one does.
+func newLeaseManager(cfg ociconfig.Config) (LeaseManager, error) {
+	if !cfg.LeasesEnabled {
+		return nil, nil //nolint:nilnil
+	}
+
+	dbPath := cfg.LeaseDBPath
+	if dbPath == "" && cfg.CacheDir != "" {
+		dbPath = filepath.Join(cfg.CacheDir, defaultLeaseDBFilename)
+	}
+
+	if dbPath == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	leases, err := NewBoltLeaseManager(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lease manager: %w", err)
+	}
+
+	return leases, nil
 }
 
 // Compile-time interface checks to ensure store implements all capability interfaces.
 var (
-	_ types.StoreAPI = (*store)(nil)
+	_ types.StoreAPI      = (*store)(nil)
+	_ types.ReferrerStore = (*store)(nil)
 	// _ types.VerifierStore = (*store)(nil)
 )
 
@@ -51,6 +94,11 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 	// if local dir used, return client for that local path.
 	// allows mounting of data via volumes
 	// allows S3 usage for backup store
+	leases, err := newLeaseManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	if repoPath := cfg.LocalDir; repoPath != "" {
 		repo, err := oci.New(repoPath)
 		if err != nil {
@@ -60,6 +108,7 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 		return &store{
 			repo:   repo,
 			config: cfg,
+			leases: leases,
 		}, nil
 	}
 
@@ -68,10 +117,19 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 		return nil, fmt.Errorf("failed to create remote repo: %w", err)
 	}
 
+	// A local manifest cache only makes sense in front of a remote backend;
+	// a LocalDir store is already local.
+	cache, err := newManifestCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create store API
 	return &store{
 		repo:   repo,
 		config: cfg,
+		leases: leases,
+		cache:  cache,
 	}, nil
 }
 
@@ -89,8 +147,35 @@ func (s *store) Push(ctx context.Context, mediaType string, rd io.ReadCloser) (*
 	// Close reader when done
 	defer rd.Close()
 
-	// Step 1: Use oras.PushBytes to push the object data and get Layer Descriptor
-	desc, err := s.pushOrSkip(ctx, rd, mediaType)
+	// Acquire a short-lived lease so a concurrent GC pass cannot delete this
+	// content while it is still being written and tagged.
+	lease, err := s.acquirePushLease(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to acquire push lease: %v", err)
+	}
+
+	if lease != nil {
+		defer func() {
+			if err := s.leases.Delete(ctx, lease.ID); err != nil {
+				logger.Warn("Failed to release push lease", "lease_id", lease.ID, "error", err)
+			}
+		}()
+	}
+
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read object data: %v", err)
+	}
+
+	// Step 1: compress the object (if configured) and push it, recording the
+	// uncompressed digest as a diff-id annotation so callers can verify
+	// content identity without decompressing first.
+	compressed, err := compressForPush(mediaType, data, s.config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compress object bytes: %v", err)
+	}
+
+	desc, err := s.pushDescriptorOrSkip(ctx, compressed.desc, compressed.data)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to push object bytes: %v", err)
 	}
@@ -101,12 +186,37 @@ func (s *store) Push(ctx context.Context, mediaType string, rd io.ReadCloser) (*
 		return nil, status.Errorf(codes.Internal, "failed to convert digest %s to CID: %v", desc.Digest.String(), err)
 	}
 
+	if lease != nil {
+		if err := s.leases.AddResource(ctx, lease.ID, LeaseResource{CID: cid, Type: "blob"}); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to pin pushed content under lease: %v", err)
+		}
+	}
+
 	// Return object reference
 	return &storev1.ObjectRef{Cid: cid}, nil
 }
 
+// acquirePushLease creates a protective lease for a single Push call, or
+// returns nil if the store has no LeaseManager configured.
+func (s *store) acquirePushLease(ctx context.Context) (*Lease, error) {
+	if s.leases == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	lease, err := s.leases.Create(ctx, pushLeaseTTL, map[string]string{"op": "push"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lease: %w", err)
+	}
+
+	return lease, nil
+}
+
 // Lookup checks if the ref exists as a tagged object.
 func (s *store) Lookup(ctx context.Context, ref *storev1.ObjectRef) (*storev1.ObjectMeta, error) {
+	if meta, ok := s.cache.get(ref.GetCid()); ok {
+		return meta, nil
+	}
+
 	// Convert ref to digest
 	digets, err := corev1.ConvertCIDToDigest(ref.GetCid())
 	if err != nil {
@@ -128,25 +238,33 @@ func (s *store) Lookup(ctx context.Context, ref *storev1.ObjectRef) (*storev1.Ob
 			return nil, err // Error already has proper context from helper
 		}
 
-		return &storev1.ObjectMeta{
+		meta := &storev1.ObjectMeta{
 			Cid:          ref.GetCid(),
 			Size:         uint64(manifestDesc.Size),
 			MediaType:    manifest.MediaType,
 			ArtifactType: manifest.ArtifactType,
 			Annotations:  manifest.Annotations,
-		}, nil
+		}
+
+		s.cache.putMeta(ref.GetCid(), meta)
+
+		return meta, nil
 	case ocispec.MediaTypeImageIndex:
 		// extract meta from index
 	}
 
 	// any other media type, return only basic info
-	return &storev1.ObjectMeta{
+	meta := &storev1.ObjectMeta{
 		Cid:          ref.GetCid(),
 		Size:         uint64(desc.Size),
 		MediaType:    desc.MediaType,
 		ArtifactType: desc.ArtifactType,
 		Annotations:  desc.Annotations,
-	}, nil
+	}
+
+	s.cache.putMeta(ref.GetCid(), meta)
+
+	return meta, nil
 }
 
 func (s *store) Pull(ctx context.Context, ref *storev1.ObjectRef) (*storev1.ObjectMeta, io.ReadCloser, error) {
@@ -162,6 +280,21 @@ func (s *store) Pull(ctx context.Context, ref *storev1.ObjectRef) (*storev1.Obje
 		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid CID %s: %v", ref.GetCid(), err)
 	}
 
+	desc := ocispec.Descriptor{
+		Digest:    digets,
+		MediaType: meta.GetMediaType(),
+		Size:      int64(meta.GetSize()),
+	}
+
+	if rd, ok := s.cache.fetchContent(ctx, ref.GetCid(), desc); ok {
+		rd, err := decompressOnPull(ctx, meta.GetMediaType(), rd)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to decompress cached object data for CID %s: %v", ref.GetCid(), err)
+		}
+
+		return meta, rd, nil
+	}
+
 	// Pull object data
 	rd, err := s.repo.Fetch(ctx, ocispec.Descriptor{
 		Digest: digets,
@@ -170,20 +303,41 @@ func (s *store) Pull(ctx context.Context, ref *storev1.ObjectRef) (*storev1.Obje
 		return nil, nil, status.Errorf(codes.Internal, "failed to pull object data for CID %s: %v", ref.GetCid(), err)
 	}
 
+	if s.cache != nil {
+		data, err := io.ReadAll(rd)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to read object data for CID %s: %v", ref.GetCid(), err)
+		}
+
+		rd.Close()
+
+		s.cache.put(ctx, ref.GetCid(), desc, data, meta)
+
+		rd, err = decompressOnPull(ctx, meta.GetMediaType(), io.NopCloser(bytes.NewReader(data)))
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to decompress object data for CID %s: %v", ref.GetCid(), err)
+		}
+
+		return meta, rd, nil
+	}
+
+	rd, err = decompressOnPull(ctx, meta.GetMediaType(), rd)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to decompress object data for CID %s: %v", ref.GetCid(), err)
+	}
+
 	return meta, rd, nil
 }
 
 func (s *store) Delete(ctx context.Context, ref *storev1.ObjectRef) error {
 	logger.Debug("Deleting object from OCI store", "ref", ref)
 
+	// TODO: s.cache.invalidate(ref.GetCid()) once Delete is implemented -
+	// invalidating the cache ahead of a delete that always panics has no
+	// observable effect.
 	panic("unimplemented")
 }
 
-// Walk implements types.StoreAPI.
-func (s *store) Walk(ctx context.Context, head *storev1.ObjectRef, walkFn func(*storev1.ObjectMeta) error, walkOpts ...func()) error {
-	return fmt.Errorf("unimplemented")
-}
-
 // IsReady checks if the storage backend is ready to serve traffic.
 // For local stores, always returns true.
 // For remote OCI registries, checks Zot's /readyz endpoint to verify it's ready.
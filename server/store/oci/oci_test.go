@@ -45,6 +45,16 @@ func TestStore(t *testing.T) {
 	t.Run("Manifest/PushPullManifest", TestPushPullManifest)
 	t.Run("Manifest/LookupManifestMetadata", TestLookupManifestMetadata)
 	t.Run("Manifest/WalkManifestLayers", TestWalkManifestLayers)
+
+	// Referrer tests - OCI 1.1 subject-linked artifacts
+	t.Run("Referrers/PushListReferrers", TestPushListReferrers)
+
+	// Compression tests - transparent gzip/zstd negotiation on push/pull
+	t.Run("Compression/PushPullGzipBlob", TestPushPullGzipBlob)
+	t.Run("Compression/PushPullZstdBlob", TestPushPullZstdBlob)
+
+	// Blob-service tests - the BlobDescriptorService/BlobProvider/BlobIngester split
+	t.Run("BlobService/IngestAndRead", TestBlobServiceIngestAndRead)
 }
 
 // TestPushPullSimpleBlob tests pushing and pulling a basic blob (layer).
@@ -248,8 +258,6 @@ func TestLookupManifestMetadata(t *testing.T) {
 // Walk allows traversing the layers/objects referenced by a manifest.
 // Note: Walk is only meaningful for manifests, not for blobs.
 func TestWalkManifestLayers(t *testing.T) {
-	t.Skip("Walk implementation is not yet complete - test will be implemented when Walk is functional")
-
 	store := loadLocalStore(t)
 
 	// Create multiple layers
@@ -293,6 +301,137 @@ func TestWalkManifestLayers(t *testing.T) {
 	assert.Contains(t, visited, layer2Ref.Cid, "Should have visited layer2")
 }
 
+// TestPushListReferrers tests pushing an OCI 1.1 referrer artifact linked to
+// a subject manifest and then listing it back, exercising the tag-schema
+// fallback path since the local content/oci store doesn't implement the
+// native referrers API.
+func TestPushListReferrers(t *testing.T) {
+	rawStore := loadLocalStore(t)
+	store, ok := rawStore.(*store)
+	assert.True(t, ok, "local store should be a concrete *store")
+
+	// Push a subject manifest that the referrer will point at.
+	subjectData := `{
+		"schemaVersion": 2,
+		"mediaType": "application/vnd.oci.image.manifest.v1+json",
+		"config": {
+			"mediaType": "application/vnd.oci.empty.v1+json",
+			"digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+			"size": 2
+		},
+		"layers": []
+	}`
+
+	subjectRef, err := store.Push(testCtx, "application/vnd.oci.image.manifest.v1+json",
+		io.NopCloser(strings.NewReader(subjectData)))
+	assert.NoError(t, err)
+
+	// Attach a referrer artifact to the subject.
+	referrerPayload := `{"attestation": "example"}`
+	referrerType := "application/vnd.agntcy.dir.attestation.v1+json"
+
+	referrerRef, err := store.PushReferrer(testCtx, subjectRef, referrerType,
+		io.NopCloser(strings.NewReader(referrerPayload)))
+	assert.NoError(t, err, "PushReferrer should succeed")
+	assert.NotNil(t, referrerRef, "Referrer ref should not be nil")
+
+	// List referrers for the subject and confirm the pushed artifact is found.
+	referrers, err := store.ListReferrers(testCtx, subjectRef, referrerType)
+	assert.NoError(t, err, "ListReferrers should succeed")
+	assert.Len(t, referrers, 1, "Should find exactly one referrer")
+	assert.Equal(t, referrerRef.Cid, referrers[0].Cid, "Referrer CID should match")
+	assert.Equal(t, referrerType, referrers[0].ArtifactType, "Referrer artifact type should match")
+
+	// Filtering by an unrelated artifact type should find nothing.
+	filtered, err := store.ListReferrers(testCtx, subjectRef, "application/vnd.agntcy.dir.unrelated.v1+json")
+	assert.NoError(t, err, "ListReferrers should succeed even with no matches")
+	assert.Empty(t, filtered, "Should find no referrers for an unrelated artifact type")
+}
+
+// TestPushPullGzipBlob tests that a blob pushed under a store configured for
+// gzip compression is stored compressed (media type gains a "+gzip" suffix)
+// but is transparently decompressed back to the original bytes on Pull.
+func TestPushPullGzipBlob(t *testing.T) {
+	testPushPullCompressedBlob(t, ociconfig.CompressionGzip, "+gzip")
+}
+
+// TestPushPullZstdBlob is the zstd counterpart of TestPushPullGzipBlob.
+func TestPushPullZstdBlob(t *testing.T) {
+	testPushPullCompressedBlob(t, ociconfig.CompressionZstd, "+zstd")
+}
+
+func testPushPullCompressedBlob(t *testing.T, alg ociconfig.CompressionAlgorithm, wantSuffix string) {
+	t.Helper()
+
+	store, err := New(ociconfig.Config{
+		LocalDir:             t.TempDir(),
+		CompressionAlgorithm: alg,
+	})
+	assert.NoError(t, err)
+
+	mediaType := "application/vnd.oci.image.layer.v1.tar"
+	data := strings.Repeat("compress-me ", 256)
+
+	ref, err := store.Push(testCtx, mediaType, io.NopCloser(strings.NewReader(data)))
+	assert.NoError(t, err, "Push should succeed")
+
+	meta, err := store.Lookup(testCtx, ref)
+	assert.NoError(t, err, "Lookup should succeed")
+	assert.True(t, strings.HasSuffix(meta.MediaType, wantSuffix), "MediaType should carry the compression suffix, got %q", meta.MediaType)
+
+	_, reader, err := store.Pull(testCtx, ref)
+	assert.NoError(t, err, "Pull should succeed")
+	defer reader.Close()
+
+	pulledData, err := io.ReadAll(reader)
+	assert.NoError(t, err, "Reading pulled data should succeed")
+	assert.Equal(t, data, string(pulledData), "Pulled data should match the original, uncompressed content")
+}
+
+// TestBlobServiceIngestAndRead exercises the BlobIngester/BlobProvider split:
+// content is written through a resumable BlobWriter and committed, then read
+// back both as a seekable stream and as a full byte slice.
+func TestBlobServiceIngestAndRead(t *testing.T) {
+	rawStore := loadLocalStore(t)
+	s, ok := rawStore.(*store)
+	assert.True(t, ok, "local store should be a concrete *store")
+
+	data := "blob-service content"
+
+	writer, err := s.Ingester().Writer(testCtx)
+	assert.NoError(t, err, "Writer should succeed")
+	assert.NotEmpty(t, writer.ID(), "upload should have an ID")
+
+	n, err := writer.Write([]byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, int64(len(data)), writer.Size())
+
+	desc, err := writer.Commit(testCtx, Descriptor{MediaType: "application/vnd.agntcy.test.blob.v1"})
+	assert.NoError(t, err, "Commit should succeed")
+	assert.Equal(t, int64(len(data)), desc.Size)
+
+	statDesc, err := s.Descriptors().Stat(testCtx, desc.Digest)
+	assert.NoError(t, err, "Stat should succeed")
+	assert.Equal(t, desc.Digest, statDesc.Digest)
+
+	got, err := s.Blobs().Get(testCtx, desc.Digest)
+	assert.NoError(t, err, "Get should succeed")
+	assert.Equal(t, data, string(got))
+
+	reader, err := s.Blobs().Open(testCtx, desc.Digest)
+	assert.NoError(t, err, "Open should succeed")
+	defer reader.Close()
+
+	seeked, err := reader.Seek(int64(len("blob-service ")), io.SeekStart)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len("blob-service ")), seeked)
+
+	rest, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(rest))
+}
+
 func loadLocalStore(t *testing.T) types.StoreAPI {
 	t.Helper()
 
@@ -5,14 +5,18 @@ package oci
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
+	corev1 "github.com/agntcy/dir/api/core/v1"
 	signv1 "github.com/agntcy/dir/api/sign/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/utils/cosign"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/agntcy/dir/utils/zot"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -266,6 +270,172 @@ func (s *store) PullPublicKeys(ctx context.Context, recordCID string) ([]string,
 	return publicKeys, nil
 }
 
+// ListReferrers returns metadata for every OCI 1.1 referrer of subjectRef,
+// optionally filtered to a single artifact type (empty means no filter).
+//
+// It prefers the registry's native referrers API (via ReferrersLister) and
+// falls back to the pre-1.1 tag-schema convention (an image index tagged
+// "<alg>-<digest>") for registries that don't support it yet. Filtering is
+// always re-applied client-side, since ReferrersLister doesn't surface the
+// registry's OCI-Filters-Applied response header to callers.
+func (s *store) ListReferrers(ctx context.Context, subjectRef *storev1.ObjectRef, artifactTypeFilter string) ([]*storev1.ObjectMeta, error) {
+	digets, err := corev1.ConvertCIDToDigest(subjectRef.GetCid())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid CID %s: %v", subjectRef.GetCid(), err)
+	}
+
+	subjectDesc, err := s.repo.Resolve(ctx, digets.String())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to resolve subject %s: %v", subjectRef.GetCid(), err)
+	}
+
+	matcher := func(_ context.Context, referrer ocispec.Descriptor) bool {
+		return artifactTypeFilter == "" || referrer.ArtifactType == artifactTypeFilter
+	}
+
+	var referrerDescs []ocispec.Descriptor
+
+	if lister, ok := s.repo.(ReferrersLister); ok {
+		err = lister.Referrers(ctx, subjectDesc, artifactTypeFilter, func(referrers []ocispec.Descriptor) error {
+			for _, referrer := range referrers {
+				if matcher(ctx, referrer) {
+					referrerDescs = append(referrerDescs, referrer)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to query referrers for %s: %v", subjectRef.GetCid(), err)
+		}
+	} else {
+		referrerDescs, err = s.listReferrersByTagSchema(ctx, subjectDesc, matcher)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	referrerMetas := make([]*storev1.ObjectMeta, 0, len(referrerDescs))
+
+	for _, desc := range referrerDescs {
+		cid, err := corev1.ConvertDigestToCID(desc.Digest)
+		if err != nil {
+			referrersLogger.Warn("Failed to convert referrer digest to CID", "digest", desc.Digest.String(), "error", err)
+
+			continue
+		}
+
+		referrerMetas = append(referrerMetas, &storev1.ObjectMeta{
+			Cid:          cid,
+			Size:         uint64(desc.Size),
+			MediaType:    desc.MediaType,
+			ArtifactType: desc.ArtifactType,
+			Annotations:  desc.Annotations,
+		})
+	}
+
+	return referrerMetas, nil
+}
+
+// listReferrersByTagSchema looks up referrers via the pre-OCI-1.1 tag-schema
+// convention: an image index tagged "<alg>-<digest>" whose manifests are the
+// referrers, for registries that don't implement the native referrers API.
+func (s *store) listReferrersByTagSchema(ctx context.Context, subjectDesc ocispec.Descriptor, matcher ReferrerMatcher) ([]ocispec.Descriptor, error) {
+	tag := referrersFallbackTag(subjectDesc.Digest)
+
+	indexDesc, err := s.repo.Resolve(ctx, tag)
+	if err != nil {
+		// No fallback referrers index has been published for this subject yet.
+		return nil, nil //nolint:nilnil
+	}
+
+	reader, err := s.repo.Fetch(ctx, indexDesc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch referrers index %s: %v", tag, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read referrers index %s: %v", tag, err)
+	}
+
+	var index ocispec.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse referrers index %s: %v", tag, err)
+	}
+
+	var referrers []ocispec.Descriptor
+
+	for _, desc := range index.Manifests {
+		if matcher(ctx, desc) {
+			referrers = append(referrers, desc)
+		}
+	}
+
+	return referrers, nil
+}
+
+// referrersFallbackTag derives the pre-OCI-1.1 tag-schema fallback tag for
+// dgst (e.g. "sha256-<hex>").
+func referrersFallbackTag(dgst digest.Digest) string {
+	return fmt.Sprintf("%s-%s", dgst.Algorithm(), dgst.Encoded())
+}
+
+// PushReferrer pushes payload as an OCI 1.1 artifact manifest whose subject
+// points at subjectRef, for attaching signatures, SBOMs and provenance
+// attestations to a record without modifying the record's own manifest.
+func (s *store) PushReferrer(ctx context.Context, subjectRef *storev1.ObjectRef, artifactType string, payload io.ReadCloser) (*storev1.ObjectRef, error) {
+	defer payload.Close()
+
+	if subjectRef.GetCid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "subject CID is required") //nolint:wrapcheck
+	}
+
+	if artifactType == "" {
+		return nil, status.Error(codes.InvalidArgument, "artifact type is required") //nolint:wrapcheck
+	}
+
+	digets, err := corev1.ConvertCIDToDigest(subjectRef.GetCid())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid CID %s: %v", subjectRef.GetCid(), err)
+	}
+
+	subjectDesc, err := s.repo.Resolve(ctx, digets.String())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to resolve subject %s: %v", subjectRef.GetCid(), err)
+	}
+
+	data, err := io.ReadAll(payload)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read referrer payload: %v", err)
+	}
+
+	blobDesc, err := oras.PushBytes(ctx, s.repo, artifactType, data)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to push referrer blob: %v", err)
+	}
+
+	manifestDesc, err := oras.PackManifest(ctx, s.repo, oras.PackManifestVersion1_1, artifactType,
+		oras.PackManifestOptions{
+			Subject: &subjectDesc,
+			Layers:  []ocispec.Descriptor{blobDesc},
+		},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to pack referrer manifest: %v", err)
+	}
+
+	cid, err := corev1.ConvertDigestToCID(manifestDesc.Digest)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert referrer digest to CID: %v", err)
+	}
+
+	referrersLogger.Debug("Pushed referrer", "subjectCID", subjectRef.GetCid(), "artifactType", artifactType, "referrerCID", cid)
+
+	return &storev1.ObjectRef{Cid: cid}, nil
+}
+
 // findReferrersByType searches for all referrer artifacts of the specified type that reference the given record manifest.
 func (s *store) findReferrersByType(ctx context.Context, recordManifestDesc ocispec.Descriptor, referrerType string, matcher ReferrerMatcher) ([]ocispec.Descriptor, error) {
 	referrersLister, ok := s.repo.(ReferrersLister)
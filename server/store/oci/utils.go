@@ -4,6 +4,7 @@
 package oci
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -17,7 +18,6 @@ import (
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"golang.org/x/sync/errgroup"
-	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras-go/v2/registry/remote/retry"
@@ -104,22 +104,29 @@ func (s *store) pushOrSkip(ctx context.Context, reader io.Reader, mediaType stri
 		return ocispec.Descriptor{}, fmt.Errorf("failed to read data from reader: %w", err)
 	}
 
-	// Compute digest
-	dgst := digest.FromBytes(data)
+	return s.pushDescriptorOrSkip(ctx, ocispec.Descriptor{
+		Digest:    digest.FromBytes(data),
+		MediaType: mediaType,
+		Size:      int64(len(data)),
+	}, data)
+}
 
-	// Check if data already exists
-	exists, err := s.repo.Exists(ctx, ocispec.Descriptor{Digest: dgst})
+// pushDescriptorOrSkip pushes data under the exact descriptor given (media
+// type, size and annotations included, e.g. a compressed blob's diff-id
+// annotation) if it does not already exist in the registry.
+func (s *store) pushDescriptorOrSkip(ctx context.Context, desc ocispec.Descriptor, data []byte) (ocispec.Descriptor, error) {
+	exists, err := s.repo.Exists(ctx, desc)
 	if err != nil {
 		return ocispec.Descriptor{}, fmt.Errorf("failed to check blob existence: %w", err)
 	}
+
 	if !exists {
-		_, err := oras.PushBytes(ctx, s.repo, mediaType, data)
-		if err != nil {
+		if err := s.repo.Push(ctx, desc, bytes.NewReader(data)); err != nil {
 			return ocispec.Descriptor{}, fmt.Errorf("failed to push object bytes: %w", err)
 		}
 	}
 
-	return ocispec.Descriptor{Digest: dgst, MediaType: mediaType, Size: int64(len(data))}, nil
+	return desc, nil
 }
 
 // tagWithRetry attempts to tag a manifest with exponential backoff retry logic.
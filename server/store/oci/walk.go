@@ -0,0 +1,133 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// DefaultWalkMaxDepth bounds how many manifest levels Walk will descend into
+// before stopping, guarding against unexpectedly deep manifest graphs.
+const DefaultWalkMaxDepth = 32
+
+// ErrSkipSubtree can be returned by a Walk callback to skip descending into
+// that object's children (config, layers, subject) without aborting the walk.
+var ErrSkipSubtree = errors.New("skip subtree")
+
+// WalkOption configures a Walk traversal.
+type WalkOption func(*walkConfig)
+
+type walkConfig struct {
+	maxDepth int
+}
+
+// WithMaxDepth overrides the maximum manifest recursion depth for a Walk
+// call (default DefaultWalkMaxDepth).
+func WithMaxDepth(maxDepth int) WalkOption {
+	return func(c *walkConfig) {
+		c.maxDepth = maxDepth
+	}
+}
+
+// Walk implements types.StoreAPI.
+//
+// Given a manifest ObjectRef, Walk parses the manifest and invokes walkFn
+// once for the manifest itself, then recurses into its config, layers and
+// subject references, descending into any child that is itself a manifest.
+// A blob ObjectRef is a no-op beyond the single callback invocation.
+//
+// Recursion is bounded by a max depth (see WithMaxDepth) and guarded against
+// cycles with a visited set keyed by CID. walkFn may return ErrSkipSubtree to
+// skip a node's children without aborting the walk; any other error aborts
+// the walk and is returned from Walk.
+func (s *store) Walk(ctx context.Context, head *storev1.ObjectRef, walkFn func(*storev1.ObjectMeta) error, walkOpts ...WalkOption) error {
+	cfg := &walkConfig{maxDepth: DefaultWalkMaxDepth}
+	for _, opt := range walkOpts {
+		opt(cfg)
+	}
+
+	return s.walk(ctx, head, walkFn, cfg.maxDepth, make(map[string]bool))
+}
+
+func (s *store) walk(
+	ctx context.Context,
+	ref *storev1.ObjectRef,
+	walkFn func(*storev1.ObjectMeta) error,
+	depthRemaining int,
+	visited map[string]bool,
+) error {
+	if visited[ref.GetCid()] {
+		return nil
+	}
+
+	visited[ref.GetCid()] = true
+
+	meta, err := s.Lookup(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("failed to lookup object with CID %s: %w", ref.GetCid(), err)
+	}
+
+	if err := walkFn(meta); err != nil {
+		if errors.Is(err, ErrSkipSubtree) {
+			return nil
+		}
+
+		return err
+	}
+
+	if meta.GetMediaType() != ocispec.MediaTypeImageManifest {
+		// Blobs have no children to descend into.
+		return nil
+	}
+
+	if depthRemaining <= 0 {
+		logger.Warn("Walk reached max depth, not descending further", "cid", ref.GetCid())
+
+		return nil
+	}
+
+	digest, err := corev1.ConvertCIDToDigest(ref.GetCid())
+	if err != nil {
+		return fmt.Errorf("invalid CID %s: %w", ref.GetCid(), err)
+	}
+
+	manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, ocispec.Descriptor{Digest: digest})
+	if err != nil {
+		return fmt.Errorf("failed to parse manifest with CID %s: %w", ref.GetCid(), err)
+	}
+
+	children := make([]ocispec.Descriptor, 0, len(manifest.Layers)+2) //nolint:mnd
+	children = append(children, manifest.Config)
+	children = append(children, manifest.Layers...)
+
+	if manifest.Subject != nil {
+		children = append(children, *manifest.Subject)
+	}
+
+	for _, child := range children {
+		// The well-known empty JSON descriptor is a conventional placeholder
+		// (e.g. for configless manifests) and is never pushed as its own
+		// object, so it has nothing to walk into.
+		if child.Digest == ocispec.DescriptorEmptyJSON.Digest {
+			continue
+		}
+
+		childCID, err := corev1.ConvertDigestToCID(child.Digest)
+		if err != nil {
+			return fmt.Errorf("failed to convert child digest to CID: %w", err)
+		}
+
+		if err := s.walk(ctx, &storev1.ObjectRef{Cid: childCID}, walkFn, depthRemaining-1, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
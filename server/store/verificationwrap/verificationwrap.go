@@ -42,10 +42,21 @@ func Wrap(source types.StoreAPI, cfg storeconfig.VerificationConfig) types.Store
 		cacheTTL = storeconfig.DefaultVerificationCacheTTL
 	}
 
-	verifier := verification.NewVerifier(
+	opts := []verification.VerifierOption{
 		verification.WithCacheTTL(cacheTTL),
 		verification.WithAllowInsecureWellKnown(cfg.AllowInsecure),
-	)
+	}
+
+	if len(cfg.ChallengeMethods) > 0 {
+		methods := make([]verification.ChallengeMethod, len(cfg.ChallengeMethods))
+		for i, m := range cfg.ChallengeMethods {
+			methods[i] = verification.ChallengeMethod(m)
+		}
+
+		opts = append(opts, verification.WithChallengeMethods(methods...))
+	}
+
+	verifier := verification.NewVerifier(opts...)
 
 	logger.Info("Domain verification enabled",
 		"cache_ttl", cacheTTL,
@@ -162,7 +173,7 @@ func (s *Store) addVerificationStatus(ctx context.Context, cid string, meta *cor
 	}
 
 	// Verify domain ownership
-	result := s.verifier.Verify(ctx, recordName, publicKey)
+	result := s.verifier.Verify(ctx, cid, recordName, publicKey)
 
 	// Add verification results to annotations
 	if result.Verified {
@@ -0,0 +1,63 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config provides configuration for the OpenTelemetry OTLP metrics
+// pipeline, which runs alongside (not instead of) the Prometheus pull
+// endpoint hosted by the internal admin server.
+package config
+
+import "time"
+
+const (
+	// DefaultEnabled leaves the OTLP pipeline off by default; most
+	// deployments scrape Prometheus and only opt into OTLP push when they
+	// already run a collector.
+	DefaultEnabled = false
+
+	// DefaultProtocol is the OTLP wire protocol used to reach Endpoint.
+	DefaultProtocol = ProtocolGRPC
+
+	// DefaultInterval is how often metrics are exported to the collector.
+	DefaultInterval = 15 * time.Second
+)
+
+// Protocol selects the OTLP transport used to reach Endpoint.
+type Protocol string
+
+const (
+	// ProtocolGRPC exports metrics over OTLP/gRPC.
+	ProtocolGRPC Protocol = "grpc"
+
+	// ProtocolHTTPProtobuf exports metrics over OTLP/HTTP with protobuf bodies.
+	ProtocolHTTPProtobuf Protocol = "http/protobuf"
+)
+
+// Config holds configuration for the OTLP metrics exporter.
+type Config struct {
+	// Enabled turns on the OTLP metrics pipeline alongside the existing
+	// Prometheus pull endpoint.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Endpoint is the OTLP collector address, e.g. "otel-collector:4317".
+	Endpoint string `json:"endpoint,omitempty" mapstructure:"endpoint"`
+
+	// Protocol selects "grpc" or "http/protobuf".
+	// Default: "grpc"
+	Protocol Protocol `json:"protocol,omitempty" mapstructure:"protocol"`
+
+	// Insecure disables TLS when talking to Endpoint (e.g. a sidecar collector).
+	Insecure bool `json:"insecure,omitempty" mapstructure:"insecure"`
+
+	// Headers are additional headers sent with every export request,
+	// e.g. for collector authentication.
+	Headers map[string]string `json:"headers,omitempty" mapstructure:"headers"`
+
+	// Interval is how often the periodic reader exports metrics.
+	// Default: 15s
+	Interval time.Duration `json:"interval,omitempty" mapstructure:"interval"`
+
+	// ResourceAttributes are additional OTEL resource attributes attached to
+	// every exported metric, e.g. "deployment.environment=production".
+	ResourceAttributes map[string]string `json:"resource_attributes,omitempty" mapstructure:"resource_attributes"`
+}
@@ -0,0 +1,134 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package telemetry wires an OpenTelemetry MeterProvider that exports to
+// both the existing Prometheus pull endpoint (server/metrics) and, when
+// configured, an OTLP collector. gRPC instrumentation goes through
+// otelgrpc so the same MeterProvider backs counters, histograms, and
+// traces instead of running a parallel grpc_prometheus pipeline.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	telemetryconfig "github.com/agntcy/dir/server/telemetry/config"
+	"github.com/agntcy/dir/utils/logging"
+	prometheusclient "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"google.golang.org/grpc"
+)
+
+var logger = logging.Logger("telemetry")
+
+// ServiceName is the OTEL resource service.name attached to every exported
+// metric and trace.
+const ServiceName = "agntcy-dir"
+
+// Provider owns the OTEL MeterProvider and its exporters. Call Shutdown to
+// flush pending metrics and stop the periodic OTLP export goroutine.
+type Provider struct {
+	meterProvider *metric.MeterProvider
+}
+
+// New builds a MeterProvider that always reports to registry (the same
+// registry server/metrics and the internal admin server's /metrics
+// endpoint expose) and, when cfg.Enabled, additionally pushes to an OTLP
+// collector on cfg.Interval.
+func New(ctx context.Context, cfg telemetryconfig.Config, registry *prometheusclient.Registry) (*Provider, error) {
+	attrs := []attribute.KeyValue{semconv.ServiceName(ServiceName)}
+	for k, v := range cfg.ResourceAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attrs...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OTEL resource: %w", err)
+	}
+
+	promExporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Prometheus exporter: %w", err)
+	}
+
+	opts := []metric.Option{
+		metric.WithResource(res),
+		metric.WithReader(promExporter),
+	}
+
+	if cfg.Enabled {
+		reader, err := newOTLPReader(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP reader: %w", err)
+		}
+
+		opts = append(opts, metric.WithReader(reader))
+	}
+
+	meterProvider := metric.NewMeterProvider(opts...)
+	otel.SetMeterProvider(meterProvider)
+
+	logger.Info("Telemetry provider initialized", "otlp_enabled", cfg.Enabled, "otlp_endpoint", cfg.Endpoint)
+
+	return &Provider{meterProvider: meterProvider}, nil
+}
+
+// ServerOptions returns gRPC server options that instrument every unary and
+// streaming RPC via otelgrpc, sharing this Provider's MeterProvider so
+// metrics and traces come from one instrumentation source.
+func (p *Provider) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithMeterProvider(p.meterProvider))),
+	}
+}
+
+// Shutdown flushes pending metrics and stops all registered readers.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if err := p.meterProvider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown telemetry provider: %w", err)
+	}
+
+	return nil
+}
+
+// newOTLPReader builds a periodic metric.Reader that pushes to cfg.Endpoint
+// over the configured protocol.
+func newOTLPReader(ctx context.Context, cfg telemetryconfig.Config) (metric.Reader, error) {
+	var (
+		exporter metric.Exporter
+		err      error
+	)
+
+	switch cfg.Protocol {
+	case telemetryconfig.ProtocolHTTPProtobuf:
+		httpOpts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(cfg.Endpoint), otlpmetrichttp.WithHeaders(cfg.Headers)}
+		if cfg.Insecure {
+			httpOpts = append(httpOpts, otlpmetrichttp.WithInsecure())
+		}
+
+		exporter, err = otlpmetrichttp.New(ctx, httpOpts...)
+	case telemetryconfig.ProtocolGRPC:
+		fallthrough
+	default:
+		grpcOpts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.Endpoint), otlpmetricgrpc.WithHeaders(cfg.Headers)}
+		if cfg.Insecure {
+			grpcOpts = append(grpcOpts, otlpmetricgrpc.WithInsecure())
+		}
+
+		exporter, err = otlpmetricgrpc.New(ctx, grpcOpts...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	return metric.NewPeriodicReader(exporter, metric.WithInterval(cfg.Interval)), nil
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	storev1 "github.com/agntcy/dir/api/store/v1"
 )
 
 // StoreAPI handles management of content-addressable object storage.
@@ -31,6 +32,21 @@ type StoreAPI interface {
 	IsReady(context.Context) bool
 }
 
+// ReferrerStore provides access to the OCI 1.1 referrers API, for attaching
+// signatures, SBOMs and provenance attestations to a record without
+// modifying the record's own manifest.
+//
+// Implementations: oci.Store.
+type ReferrerStore interface {
+	// ListReferrers returns metadata for every referrer of subject, optionally
+	// filtered to a single artifact type (empty means no filter).
+	ListReferrers(ctx context.Context, subject *storev1.ObjectRef, artifactTypeFilter string) ([]*storev1.ObjectMeta, error)
+
+	// PushReferrer pushes payload as an OCI 1.1 artifact manifest whose
+	// subject points at subject.
+	PushReferrer(ctx context.Context, subject *storev1.ObjectRef, artifactType string, payload io.ReadCloser) (*storev1.ObjectRef, error)
+}
+
 // VerifierStore provides signature verification using Zot registry.
 // This is implemented by OCI-backed stores that have access to a Zot registry
 // with cosign/notation signature support.
@@ -47,4 +63,5 @@ type VerifierStore interface {
 type FullStore interface {
 	StoreAPI
 	VerifierStore
+	ReferrerStore
 }
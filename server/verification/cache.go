@@ -0,0 +1,56 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package verification
+
+import (
+	"sync"
+	"time"
+)
+
+// resultCache caches successful verification results for a TTL, keyed by
+// domain, so repeated Verify calls for the same record don't redo DNS/HTTP
+// challenges or well-known fetches on every request.
+type resultCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedResult
+}
+
+// cachedResult is a single cached verification outcome.
+type cachedResult struct {
+	result    *Result
+	expiresAt time.Time
+}
+
+func newResultCache(ttl time.Duration) *resultCache {
+	return &resultCache{
+		ttl:     ttl,
+		entries: make(map[string]cachedResult),
+	}
+}
+
+// get returns a cached result for domain, if present and not expired.
+func (c *resultCache) get(domain string) (*Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[domain]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+
+	return entry.result, true
+}
+
+// put caches result under domain until the configured TTL elapses.
+func (c *resultCache) put(domain string, result *Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[domain] = cachedResult{
+		result:    result,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
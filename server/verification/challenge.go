@@ -0,0 +1,133 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package verification
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ChallengeMethod identifies a pluggable domain-ownership verification
+// method Verify can attempt, modeled on RFC 8555 ACME challenges. Unlike
+// MethodDNS/MethodWellKnown (selected via the dns:// and wellknown://
+// protocol prefixes), challenge methods are tried in an operator-configured
+// order regardless of the record name's prefix.
+type ChallengeMethod string
+
+const (
+	// ChallengeHTTP01 proves ownership by serving the expected key
+	// authorization at a well-known HTTP path.
+	ChallengeHTTP01 ChallengeMethod = "http-01"
+
+	// ChallengeDNS01 proves ownership by publishing a digest of the key
+	// authorization in a TXT record.
+	ChallengeDNS01 ChallengeMethod = "dns-01"
+
+	// ChallengeWellKnown proves ownership via the well-known OASF key file
+	// lookup (WellKnownFetcher), tried regardless of protocol prefix.
+	ChallengeWellKnown ChallengeMethod = "well-known"
+)
+
+// DefaultChallengeMethods preserves the original behavior when no methods
+// are configured: only the well-known file lookup is attempted.
+var DefaultChallengeMethods = []ChallengeMethod{ChallengeWellKnown}
+
+// challengeHTTPPath is the path segment under .well-known that serves the
+// http-01 challenge response, keyed by the record's challenge token.
+const challengeHTTPPath = "/.well-known/agntcy-challenge/"
+
+// ChallengeRecordPrefix is the subdomain prefix for the dns-01 challenge
+// TXT record.
+const ChallengeRecordPrefix = "_agntcy-challenge."
+
+// deriveChallengeToken deterministically derives a record's challenge token
+// from its CID and published public key, so it can be recomputed by any
+// party and verified without a server-issued nonce.
+func deriveChallengeToken(recordCID string, publicKeyDER []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(recordCID))
+	h.Write(publicKeyDER)
+
+	return h.Sum(nil)
+}
+
+// httpChallengeValue is the expected http-01 response body: the ACME-style
+// key authorization, base64url(token) || "." || base64url(thumbprint).
+func httpChallengeValue(token, thumbprint []byte) string {
+	return base64.RawURLEncoding.EncodeToString(token) + "." + base64.RawURLEncoding.EncodeToString(thumbprint)
+}
+
+// dns01ChallengeValue is the expected dns-01 TXT record value:
+// base64url(sha256(token || "." || thumbprint)).
+func dns01ChallengeValue(token, thumbprint []byte) string {
+	h := sha256.New()
+	h.Write(token)
+	h.Write([]byte("."))
+	h.Write(thumbprint)
+
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// verifyHTTP01 fetches http(s)://<domain>/.well-known/agntcy-challenge/<token>
+// and checks its body against the expected key authorization.
+func (v *Verifier) verifyHTTP01(ctx context.Context, domain string, token, thumbprint []byte) (bool, error) {
+	scheme := "https"
+	if v.allowInsecure {
+		scheme = "http"
+	}
+
+	url := scheme + "://" + domain + challengeHTTPPath + base64.RawURLEncoding.EncodeToString(token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create http-01 challenge request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := v.wellKnown.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("http-01 challenge request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("http-01 challenge returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, defaultMaxBodySize))
+	if err != nil {
+		return false, fmt.Errorf("failed to read http-01 challenge response: %w", err)
+	}
+
+	return strings.TrimSpace(string(body)) == httpChallengeValue(token, thumbprint), nil
+}
+
+// verifyDNS01 resolves TXT _agntcy-challenge.<domain> and checks it against
+// the expected challenge digest.
+func (v *Verifier) verifyDNS01(ctx context.Context, domain string, token, thumbprint []byte) (bool, error) {
+	records, err := v.dns.LookupChallengeRecords(ctx, domain)
+	if err != nil {
+		return false, err
+	}
+
+	expected := dns01ChallengeValue(token, thumbprint)
+
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,129 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package verification
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func generateTestKey(t *testing.T) []byte {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to marshal test key: %v", err)
+	}
+
+	return der
+}
+
+func TestDeriveChallengeToken(t *testing.T) {
+	key := generateTestKey(t)
+
+	tokenA := deriveChallengeToken("cid-a", key)
+	tokenASecond := deriveChallengeToken("cid-a", key)
+	tokenB := deriveChallengeToken("cid-b", key)
+
+	if string(tokenA) != string(tokenASecond) {
+		t.Errorf("deriveChallengeToken is not deterministic for the same inputs")
+	}
+
+	if string(tokenA) == string(tokenB) {
+		t.Errorf("deriveChallengeToken returned the same token for different CIDs")
+	}
+}
+
+func TestVerifyHTTP01(t *testing.T) {
+	key := generateTestKey(t)
+	token := deriveChallengeToken("test-cid", key)
+
+	thumbprint, err := jwkThumbprint(key)
+	if err != nil {
+		t.Fatalf("jwkThumbprint failed: %v", err)
+	}
+
+	expected := httpChallengeValue(token, thumbprint)
+
+	wantPath := challengeHTTPPath + base64.RawURLEncoding.EncodeToString(token)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != wantPath {
+			t.Errorf("unexpected challenge path: %s, want %s", r.URL.Path, wantPath)
+		}
+
+		_, _ = w.Write([]byte(expected))
+	}))
+	defer srv.Close()
+
+	v := NewVerifier(WithAllowInsecureWellKnown(true))
+
+	domain := srv.Listener.Addr().String()
+
+	ok, err := v.verifyHTTP01(context.Background(), domain, token, thumbprint)
+	if err != nil {
+		t.Fatalf("verifyHTTP01 returned error: %v", err)
+	}
+
+	if !ok {
+		t.Errorf("verifyHTTP01 = false, want true")
+	}
+}
+
+func TestVerifyHTTP01Mismatch(t *testing.T) {
+	key := generateTestKey(t)
+	token := deriveChallengeToken("test-cid", key)
+
+	thumbprint, err := jwkThumbprint(key)
+	if err != nil {
+		t.Fatalf("jwkThumbprint failed: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("not-the-expected-value"))
+	}))
+	defer srv.Close()
+
+	v := NewVerifier(WithAllowInsecureWellKnown(true))
+
+	ok, err := v.verifyHTTP01(context.Background(), srv.Listener.Addr().String(), token, thumbprint)
+	if err != nil {
+		t.Fatalf("verifyHTTP01 returned error: %v", err)
+	}
+
+	if ok {
+		t.Errorf("verifyHTTP01 = true, want false")
+	}
+}
+
+func TestDNS01ChallengeValue(t *testing.T) {
+	key := generateTestKey(t)
+	token := deriveChallengeToken("test-cid", key)
+
+	thumbprint, err := jwkThumbprint(key)
+	if err != nil {
+		t.Fatalf("jwkThumbprint failed: %v", err)
+	}
+
+	valueA := dns01ChallengeValue(token, thumbprint)
+	valueB := dns01ChallengeValue(token, thumbprint)
+
+	if valueA != valueB {
+		t.Errorf("dns01ChallengeValue is not deterministic for the same inputs")
+	}
+
+	if valueA == httpChallengeValue(token, thumbprint) {
+		t.Errorf("dns01ChallengeValue should not match the http-01 key authorization format")
+	}
+}
@@ -64,38 +64,14 @@ func NewDNSResolver(opts ...DNSResolverOption) *DNSResolver {
 // LookupKeys retrieves public keys from DNS TXT records for the given domain.
 // It looks up _oasf.<domain> and parses any OASF-formatted TXT records.
 func (r *DNSResolver) LookupKeys(ctx context.Context, domain string) ([]PublicKey, error) {
-	// Create context with timeout
-	lookupCtx, cancel := context.WithTimeout(ctx, r.timeout)
-	defer cancel()
-
 	// Build the DNS name to lookup
 	dnsName := DNSRecordPrefix + domain
 
 	dnsLogger.Debug("Looking up DNS TXT records", "domain", domain, "dnsName", dnsName)
 
-	// Perform DNS TXT lookup
-	var records []string
-
-	var err error
-
-	if r.resolver != nil {
-		records, err = r.resolver.LookupTXT(lookupCtx, dnsName)
-	} else {
-		records, err = net.DefaultResolver.LookupTXT(lookupCtx, dnsName)
-	}
-
+	records, err := r.lookupTXT(ctx, dnsName)
 	if err != nil {
-		// Check if it's a "not found" error (NXDOMAIN)
-		var dnsErr *net.DNSError
-		if errors.As(err, &dnsErr) {
-			if dnsErr.IsNotFound {
-				dnsLogger.Debug("No DNS TXT records found", "domain", domain)
-
-				return nil, nil // Not an error, just no records
-			}
-		}
-
-		return nil, fmt.Errorf("DNS lookup failed for %s: %w", dnsName, err)
+		return nil, err
 	}
 
 	dnsLogger.Debug("Found DNS TXT records", "domain", domain, "count", len(records))
@@ -125,6 +101,48 @@ func (r *DNSResolver) LookupKeys(ctx context.Context, domain string) ([]PublicKe
 	return keys, nil
 }
 
+// LookupChallengeRecords retrieves the raw TXT record values published at
+// _agntcy-challenge.<domain>, for the dns-01 challenge.
+func (r *DNSResolver) LookupChallengeRecords(ctx context.Context, domain string) ([]string, error) {
+	dnsName := ChallengeRecordPrefix + domain
+
+	dnsLogger.Debug("Looking up DNS challenge record", "domain", domain, "dnsName", dnsName)
+
+	return r.lookupTXT(ctx, dnsName)
+}
+
+// lookupTXT resolves the TXT records for name, treating NXDOMAIN as an
+// empty result rather than an error.
+func (r *DNSResolver) lookupTXT(ctx context.Context, name string) ([]string, error) {
+	lookupCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var (
+		records []string
+		err     error
+	)
+
+	if r.resolver != nil {
+		records, err = r.resolver.LookupTXT(lookupCtx, name)
+	} else {
+		records, err = net.DefaultResolver.LookupTXT(lookupCtx, name)
+	}
+
+	if err != nil {
+		// Check if it's a "not found" error (NXDOMAIN)
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			dnsLogger.Debug("No DNS TXT records found", "name", name)
+
+			return nil, nil // Not an error, just no records
+		}
+
+		return nil, fmt.Errorf("DNS lookup failed for %s: %w", name, err)
+	}
+
+	return records, nil
+}
+
 // isOASFRecord checks if a TXT record appears to be an OASF record.
 func isOASFRecord(record string) bool {
 	// OASF records start with "v=oasf"
@@ -0,0 +1,34 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package verification
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// jwkThumbprint computes the raw RFC 7638 JWK SHA-256 thumbprint bytes of a
+// DER-encoded public key, used by the http-01/dns-01 challenges to prove
+// possession without publishing the key itself.
+func jwkThumbprint(derKey []byte) ([]byte, error) {
+	pub, err := x509.ParsePKIXPublicKey(derKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	key, err := jwk.FromRaw(pub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert public key to JWK: %w", err)
+	}
+
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute JWK thumbprint: %w", err)
+	}
+
+	return thumbprint, nil
+}
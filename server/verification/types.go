@@ -72,7 +72,8 @@ const (
 	// MethodDNS indicates verification via DNS TXT record.
 	MethodDNS VerificationMethod = "dns"
 
-	// MethodWellKnown indicates verification via well-known file.
+	// MethodWellKnown indicates verification via well-known file, reached
+	// through the wellknown:// protocol prefix.
 	MethodWellKnown VerificationMethod = "wellknown"
 
 	// MethodNone indicates no verification was possible.
@@ -6,6 +6,7 @@ package verification
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/agntcy/dir/utils/logging"
@@ -14,7 +15,8 @@ import (
 var verifierLogger = logging.Logger("verification/verifier")
 
 // Verifier handles name ownership verification for OASF records.
-// It supports DNS TXT records and well-known files based on the protocol prefix.
+// It supports DNS TXT records and well-known files based on the protocol prefix,
+// plus a pluggable, operator-ordered set of ACME-style challenge methods.
 type Verifier struct {
 	// dns is the DNS resolver for TXT record lookups.
 	dns *DNSResolver
@@ -24,6 +26,13 @@ type Verifier struct {
 
 	// allowInsecure allows HTTP instead of HTTPS for well-known fetching (testing only).
 	allowInsecure bool
+
+	// challengeMethods are the methods Verify attempts, in order, before
+	// falling back to the dns:// / wellknown:// protocol-prefixed lookup.
+	challengeMethods []ChallengeMethod
+
+	// cache holds successful verification results, if WithCacheTTL was given.
+	cache *resultCache
 }
 
 // VerifierOption configures a Verifier.
@@ -51,6 +60,25 @@ func WithAllowInsecureWellKnown(allow bool) VerifierOption {
 	}
 }
 
+// WithChallengeMethods sets which ACME-style challenge methods Verify
+// attempts, and in what order, before falling back to the dns:// /
+// wellknown:// protocol-prefixed lookup. Methods not included are never
+// attempted. Defaults to DefaultChallengeMethods if never called.
+func WithChallengeMethods(methods ...ChallengeMethod) VerifierOption {
+	return func(v *Verifier) {
+		v.challengeMethods = methods
+	}
+}
+
+// WithCacheTTL caches successful verification results for ttl, keyed by
+// domain, so repeated Verify calls for the same record don't redo DNS/HTTP
+// lookups on every call.
+func WithCacheTTL(ttl time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.cache = newResultCache(ttl)
+	}
+}
+
 // NewVerifier creates a new verifier with the given options.
 func NewVerifier(opts ...VerifierOption) *Verifier {
 	v := &Verifier{}
@@ -69,35 +97,144 @@ func NewVerifier(opts ...VerifierOption) *Verifier {
 		v.wellKnown = NewWellKnownFetcher(WithAllowInsecure(v.allowInsecure))
 	}
 
+	// Preserve original behavior if no challenge methods were configured.
+	if v.challengeMethods == nil {
+		v.challengeMethods = DefaultChallengeMethods
+	}
+
 	return v
 }
 
 // Verify checks if the given signing key is authorized for the name.
-// It parses the protocol prefix from the record name to determine the verification method:
+// recordCID identifies the record being verified, used to derive the
+// deterministic challenge token for the http-01/dns-01 methods.
+//
+// It first tries the configured ChallengeMethods, in order, regardless of
+// any protocol prefix on recordName. If none succeed, it falls back to the
+// protocol prefix to determine the legacy verification method:
 //   - dns://domain/path -> use DNS TXT records only
 //   - wellknown://domain/path -> use well-known file only
 //   - domain/path -> try DNS first, then fall back to well-known
-func (v *Verifier) Verify(ctx context.Context, recordName string, signingKey []byte) *Result {
-	result := &Result{
-		VerifiedAt: time.Now(),
-	}
-
+func (v *Verifier) Verify(ctx context.Context, recordCID, recordName string, signingKey []byte) *Result {
 	// Parse the record name
 	parsed := ParseName(recordName)
 	if parsed == nil {
-		result.Error = "could not parse record name"
-
 		verifierLogger.Debug("Name parsing failed", "recordName", recordName)
 
-		return result
+		return &Result{VerifiedAt: time.Now(), Error: "could not parse record name"}
+	}
+
+	if v.cache != nil {
+		if cached, ok := v.cache.get(parsed.Domain); ok {
+			return cached
+		}
 	}
 
-	result.Domain = parsed.Domain
 	verifierLogger.Debug("Verifying name ownership",
 		"domain", parsed.Domain,
 		"protocol", parsed.Protocol,
 		"recordName", recordName)
 
+	result := v.verifyChallenges(ctx, recordCID, parsed.Domain, signingKey)
+	if result == nil {
+		result = v.verifyLegacy(ctx, parsed, signingKey)
+	}
+
+	if v.cache != nil && result.Verified {
+		v.cache.put(parsed.Domain, result)
+	}
+
+	return result
+}
+
+// verifyChallenges tries v.challengeMethods, in order, against domain.
+// Returns nil if none succeeded, so Verify can fall back to the legacy
+// protocol-prefixed lookup.
+func (v *Verifier) verifyChallenges(ctx context.Context, recordCID, domain string, publicKeyDER []byte) *Result {
+	token := deriveChallengeToken(recordCID, publicKeyDER)
+
+	thumbprint, thumbprintErr := jwkThumbprint(publicKeyDER)
+	if thumbprintErr != nil {
+		verifierLogger.Debug("Failed to compute JWK thumbprint for challenge", "domain", domain, "error", thumbprintErr)
+	}
+
+	for _, method := range v.challengeMethods {
+		matched, matchedKeyID, err := v.attemptChallenge(ctx, method, domain, publicKeyDER, token, thumbprint, thumbprintErr)
+		if err != nil {
+			verifierLogger.Debug("Challenge attempt failed", "domain", domain, "method", method, "error", err)
+
+			continue
+		}
+
+		if !matched {
+			continue
+		}
+
+		verifierLogger.Info("Name ownership verified via challenge", "domain", domain, "method", method)
+
+		return &Result{
+			Verified:     true,
+			Domain:       domain,
+			Method:       string(method),
+			VerifiedAt:   time.Now(),
+			MatchedKeyID: matchedKeyID,
+		}
+	}
+
+	return nil
+}
+
+// attemptChallenge runs a single configured ChallengeMethod against domain.
+func (v *Verifier) attemptChallenge(
+	ctx context.Context,
+	method ChallengeMethod,
+	domain string,
+	publicKeyDER []byte,
+	token, thumbprint []byte,
+	thumbprintErr error,
+) (bool, string, error) {
+	switch method {
+	case ChallengeHTTP01, ChallengeDNS01:
+		if thumbprintErr != nil {
+			return false, "", thumbprintErr
+		}
+
+		if method == ChallengeHTTP01 {
+			ok, err := v.verifyHTTP01(ctx, domain, token, thumbprint)
+
+			return ok, "", err
+		}
+
+		ok, err := v.verifyDNS01(ctx, domain, token, thumbprint)
+
+		return ok, "", err
+
+	case ChallengeWellKnown:
+		keys, err := v.wellKnown.FetchKeys(ctx, domain)
+		if err != nil {
+			return false, "", err
+		}
+
+		matchedKey, ok := MatchKey(publicKeyDER, keys)
+		if !ok {
+			return false, "", nil
+		}
+
+		return true, matchedKey.ID, nil
+
+	default:
+		return false, "", fmt.Errorf("unknown challenge method: %s", method)
+	}
+}
+
+// verifyLegacy implements the original dns:// / wellknown:// protocol-prefix
+// based verification, used when no configured ChallengeMethod succeeds.
+func (v *Verifier) verifyLegacy(ctx context.Context, parsed *ParsedName, signingKey []byte) *Result {
+	result := &Result{
+		Domain:     parsed.Domain,
+		VerifiedAt: time.Now(),
+	}
+
 	// Look up keys for the domain based on protocol
 	keys, method, err := v.lookupKeys(ctx, parsed)
 	if err != nil {
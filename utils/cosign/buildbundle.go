@@ -0,0 +1,98 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	rekorv1 "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// BuildBundle assembles a Sigstore protobuf bundle from a legacy detached
+// signature (a raw signature plus, optionally, the certificate and
+// transparency-log/timestamp material that came with it), so cosign
+// v1-style signatures can be re-verified through the same VerifyWithOIDC
+// codepath as natively-bundled signatures, instead of needing a separate
+// key-only verifier or a live Rekor/TSA round-trip.
+//
+// certPEM, rekorEntryJSON, and tsaTokens are all optional; pass "" / nil /
+// nil respectively to omit the corresponding verification material.
+// rekorEntryJSON, if set, is the protojson encoding of a single
+// rekorv1.TransparencyLogEntry (the inclusion proof returned by Rekor).
+func BuildBundle(payload, sigBytes []byte, certPEM string, rekorEntryJSON []byte, tsaTokens [][]byte) (*bundle.Bundle, error) {
+	digest := sha256.Sum256(payload)
+
+	pbBundle := &protobundle.Bundle{
+		MediaType: bundle.SigstoreBundleMediaType01,
+		Content: &protobundle.Bundle_MessageSignature{
+			MessageSignature: &protocommon.MessageSignature{
+				MessageDigest: &protocommon.HashOutput{
+					Algorithm: protocommon.HashAlgorithm_SHA2_256,
+					Digest:    digest[:],
+				},
+				Signature: sigBytes,
+			},
+		},
+	}
+
+	verificationMaterial := &protobundle.VerificationMaterial{}
+
+	if certPEM != "" {
+		certChain, err := x509CertificateChainFromPEM(certPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate chain: %w", err)
+		}
+
+		verificationMaterial.Content = &protobundle.VerificationMaterial_X509CertificateChain{
+			X509CertificateChain: certChain,
+		}
+	}
+
+	if len(rekorEntryJSON) > 0 {
+		tlogEntry := &rekorv1.TransparencyLogEntry{}
+		if err := protojson.Unmarshal(rekorEntryJSON, tlogEntry); err != nil {
+			return nil, fmt.Errorf("failed to parse rekor inclusion proof: %w", err)
+		}
+
+		verificationMaterial.TlogEntries = []*rekorv1.TransparencyLogEntry{tlogEntry}
+	}
+
+	if len(tsaTokens) > 0 {
+		timestamps := make([]*protocommon.RFC3161SignedTimestamp, 0, len(tsaTokens))
+		for _, token := range tsaTokens {
+			timestamps = append(timestamps, &protocommon.RFC3161SignedTimestamp{SignedTimestamp: token})
+		}
+
+		verificationMaterial.TimestampVerificationData = &protobundle.TimestampVerificationData{
+			Rfc3161Timestamps: timestamps,
+		}
+	}
+
+	pbBundle.VerificationMaterial = verificationMaterial
+
+	return &bundle.Bundle{Bundle: pbBundle}, nil
+}
+
+// x509CertificateChainFromPEM parses a PEM bundle of certificates (leaf
+// first) into the protobuf X509CertificateChain used by a bundle's
+// VerificationMaterial.
+func x509CertificateChainFromPEM(certPEM string) (*protocommon.X509CertificateChain, error) {
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(certPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificates: %w", err)
+	}
+
+	pbCerts := make([]*protocommon.X509Certificate, 0, len(certs))
+	for _, cert := range certs {
+		pbCerts = append(pbCerts, &protocommon.X509Certificate{RawBytes: cert.Raw})
+	}
+
+	return &protocommon.X509CertificateChain{Certificates: pbCerts}, nil
+}
@@ -0,0 +1,190 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	signv1 "github.com/agntcy/dir/api/sign/v1"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/trustroot/v1"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/sign"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	fulcioTimeout    = 30 * time.Second
+	rekorTimeout     = 30 * time.Second
+	timestampTimeout = 30 * time.Second
+	serviceWindow    = time.Hour
+)
+
+// KeylessSigner mints a short-lived Fulcio certificate from a workload
+// identity OIDC token, signs a payload with the resulting ephemeral key,
+// and emits a Sigstore bundle in the same shape utils/cosign.VerifySignatureWithOIDC
+// verifies - so records can be signed from CI runners and pods without a
+// long-lived key or an interactive login.
+type KeylessSigner struct {
+	// TokenProvider supplies the OIDC ID token exchanged at Fulcio.
+	TokenProvider TokenProvider
+
+	// FulcioURL, RekorURL, TimestampURL, and OIDCProviderURL default to the
+	// public good Sigstore instance (signv1.Default*URL) when empty.
+	FulcioURL       string
+	RekorURL        string
+	TimestampURL    string
+	OIDCProviderURL string
+}
+
+// NewKeylessSigner creates a KeylessSigner using the public good Sigstore
+// instance. Override the URL fields on the returned signer for a private
+// or staging instance.
+func NewKeylessSigner(tokenProvider TokenProvider) *KeylessSigner {
+	return &KeylessSigner{
+		TokenProvider:   tokenProvider,
+		FulcioURL:       signv1.DefaultFulcioURL,
+		RekorURL:        signv1.DefaultRekorURL,
+		TimestampURL:    signv1.DefaultTimestampURL,
+		OIDCProviderURL: signv1.DefaultOIDCProviderURL,
+	}
+}
+
+// Sign fetches an ID token from TokenProvider, exchanges it at Fulcio for a
+// short-lived signing certificate, signs payload, and returns the resulting
+// Sigstore bundle as JSON.
+func (s *KeylessSigner) Sign(ctx context.Context, payload []byte) (string, error) {
+	idToken, err := s.TokenProvider.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain OIDC token: %w", err)
+	}
+
+	signingTime := time.Now()
+
+	services, err := s.selectServices(signingTime)
+	if err != nil {
+		return "", err
+	}
+
+	keypair, err := sign.NewEphemeralKeypair(nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create ephemeral keypair: %w", err)
+	}
+
+	signOpts := sign.BundleOptions{
+		Context: ctx,
+		CertificateProviderOptions: &sign.CertificateProviderOptions{
+			IDToken: idToken,
+		},
+		CertificateProvider: sign.NewFulcio(&sign.FulcioOptions{
+			BaseURL: services.fulcioURL,
+			Timeout: fulcioTimeout,
+			Retries: 1,
+		}),
+	}
+
+	for _, tsaURL := range services.timestampURLs {
+		signOpts.TimestampAuthorities = append(signOpts.TimestampAuthorities,
+			sign.NewTimestampAuthority(&sign.TimestampAuthorityOptions{
+				URL:     tsaURL,
+				Timeout: timestampTimeout,
+				Retries: 1,
+			}))
+	}
+
+	for _, rekorURL := range services.rekorURLs {
+		signOpts.TransparencyLogs = append(signOpts.TransparencyLogs,
+			sign.NewRekor(&sign.RekorOptions{
+				BaseURL: rekorURL,
+				Timeout: rekorTimeout,
+				Retries: 1,
+			}))
+	}
+
+	bundle, err := sign.Bundle(&sign.PlainData{Data: payload}, keypair, signOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign payload: %w", err)
+	}
+
+	bundleJSON, err := protojson.Marshal(bundle)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	return string(bundleJSON), nil
+}
+
+// selectedServices holds the service URLs selectServices resolved for a
+// single signing operation: one Fulcio CA, and every configured Rekor/TSA
+// instance the resulting bundle should carry material for.
+type selectedServices struct {
+	fulcioURL     string
+	rekorURLs     []string
+	timestampURLs []string
+}
+
+// selectServices builds a minimal signing config scoped to s's service URLs
+// and selects them, reusing root.SelectService/root.SelectServices'
+// validity-window handling instead of using the configured URLs directly,
+// matching how the rest of the cosign signing stack resolves service URLs.
+// The Rekor and TSA URLs it returns are wired into sign.BundleOptions so the
+// resulting bundle carries the transparency-log entry and signed timestamp
+// utils/cosign.VerifySignatureWithOIDC expects by default.
+func (s *KeylessSigner) selectServices(signingTime time.Time) (selectedServices, error) {
+	window := func(url string) []root.Service {
+		return []root.Service{
+			{
+				URL:                 url,
+				MajorAPIVersion:     1,
+				ValidityPeriodStart: signingTime.Add(-serviceWindow),
+				ValidityPeriodEnd:   signingTime.Add(serviceWindow),
+			},
+		}
+	}
+
+	signingConfig, err := root.NewSigningConfig(
+		root.SigningConfigMediaType02,
+		window(s.FulcioURL),
+		window(s.OIDCProviderURL),
+		window(s.RekorURL),
+		root.ServiceConfiguration{Selector: v1.ServiceSelector_ANY},
+		window(s.TimestampURL),
+		root.ServiceConfiguration{Selector: v1.ServiceSelector_ANY},
+	)
+	if err != nil {
+		return selectedServices{}, fmt.Errorf("failed to build signing config: %w", err)
+	}
+
+	fulcio, err := root.SelectService(signingConfig.FulcioCertificateAuthorityURLs(), []uint32{1}, signingTime)
+	if err != nil {
+		return selectedServices{}, fmt.Errorf("failed to select fulcio URL: %w", err)
+	}
+
+	rekorServices, err := root.SelectServices(
+		signingConfig.RekorLogURLs(), signingConfig.RekorLogURLsConfig(), []uint32{1}, signingTime,
+	)
+	if err != nil {
+		return selectedServices{}, fmt.Errorf("failed to select rekor URL: %w", err)
+	}
+
+	timestampServices, err := root.SelectServices(
+		signingConfig.TimestampAuthorityURLs(), signingConfig.TimestampAuthorityURLsConfig(), []uint32{1}, signingTime,
+	)
+	if err != nil {
+		return selectedServices{}, fmt.Errorf("failed to select timestamp authority URL: %w", err)
+	}
+
+	services := selectedServices{fulcioURL: fulcio.URL}
+
+	for _, svc := range rekorServices {
+		services.rekorURLs = append(services.rekorURLs, svc.URL)
+	}
+
+	for _, svc := range timestampServices {
+		services.timestampURLs = append(services.timestampURLs, svc.URL)
+	}
+
+	return services, nil
+}
@@ -0,0 +1,188 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package signer mints keyless Sigstore signatures from OIDC tokens obtained
+// via cloud provider workload identity, rather than a long-lived key or an
+// interactive browser login. It pairs a pluggable TokenProvider (AWS IRSA,
+// GCP/Azure metadata server, Kubernetes projected service account, or an
+// interactive flow) with KeylessSigner, which exchanges the token at Fulcio
+// and emits a bundle utils/cosign.VerifySignatureWithOIDC can verify.
+package signer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TokenProvider produces an OIDC ID token to present to Fulcio.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// readTokenFile reads and trims the token at path, falling back to the
+// value of envVar when path is empty, and to defaultPath when neither is
+// set - this is the common shape of every file-based workload identity
+// token (AWS IRSA, Azure workload identity, Kubernetes projected SA tokens
+// all mount a plain-text JWT to a well-known path).
+func readTokenFile(path, envVar, defaultPath string) (string, error) {
+	if path == "" {
+		path = os.Getenv(envVar)
+	}
+
+	if path == "" {
+		path = defaultPath
+	}
+
+	if path == "" {
+		return "", fmt.Errorf("no token file configured (set the path explicitly or %s)", envVar)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file %q: %w", path, err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// AWSTokenProvider reads the OIDC token AWS IAM Roles for Service Accounts
+// (IRSA) projects into the pod, the same file EKS's webhook points at via
+// AWS_WEB_IDENTITY_TOKEN_FILE.
+type AWSTokenProvider struct {
+	// TokenFilePath overrides the token file location. If empty,
+	// AWS_WEB_IDENTITY_TOKEN_FILE is used.
+	TokenFilePath string
+}
+
+// Token implements TokenProvider.
+func (p *AWSTokenProvider) Token(_ context.Context) (string, error) {
+	//nolint:wrapcheck
+	return readTokenFile(p.TokenFilePath, "AWS_WEB_IDENTITY_TOKEN_FILE", "")
+}
+
+// AzureTokenProvider reads the federated identity token Azure Workload
+// Identity projects into the pod, pointed at via AZURE_FEDERATED_TOKEN_FILE.
+type AzureTokenProvider struct {
+	// TokenFilePath overrides the token file location. If empty,
+	// AZURE_FEDERATED_TOKEN_FILE is used.
+	TokenFilePath string
+}
+
+// Token implements TokenProvider.
+func (p *AzureTokenProvider) Token(_ context.Context) (string, error) {
+	//nolint:wrapcheck
+	return readTokenFile(p.TokenFilePath, "AZURE_FEDERATED_TOKEN_FILE", "")
+}
+
+// defaultK8sSATokenPath is where Kubernetes mounts the legacy,
+// non-audience-bound service account token. A projected, audience-bound
+// token (the recommended setup) is mounted elsewhere and should be
+// configured explicitly via K8sSATokenProvider.TokenFilePath.
+const defaultK8sSATokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// K8sSATokenProvider reads a Kubernetes service account token from disk,
+// typically a projected, audience-bound token mounted via a pod's
+// spec.volumes[].projected.sources[].serviceAccountToken.
+type K8sSATokenProvider struct {
+	// TokenFilePath overrides the token file location. If empty,
+	// KUBERNETES_SERVICEACCOUNT_TOKEN_PATH is used, falling back to the
+	// default (non-projected) service account mount.
+	TokenFilePath string
+}
+
+// Token implements TokenProvider.
+func (p *K8sSATokenProvider) Token(_ context.Context) (string, error) {
+	//nolint:wrapcheck
+	return readTokenFile(p.TokenFilePath, "KUBERNETES_SERVICEACCOUNT_TOKEN_PATH", defaultK8sSATokenPath)
+}
+
+const gcpMetadataFlavorHeader = "Metadata-Flavor"
+
+// defaultGCPMetadataServerURL is the well-known GCE/GKE metadata server
+// address used to request instance and service account identity tokens.
+const defaultGCPMetadataServerURL = "http://metadata.google.internal"
+
+// GCPTokenProvider requests an identity token scoped to Audience from the
+// GCE/GKE instance metadata server, the mechanism GCP Workload Identity
+// Federation and GCE service accounts both expose.
+type GCPTokenProvider struct {
+	// Audience is the OIDC audience the token is minted for (required).
+	Audience string
+
+	// MetadataServerURL overrides the metadata server base URL, mainly for
+	// tests. Defaults to http://metadata.google.internal.
+	MetadataServerURL string
+
+	// HTTPClient overrides the HTTP client used to reach the metadata
+	// server. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Token implements TokenProvider.
+func (p *GCPTokenProvider) Token(ctx context.Context) (string, error) {
+	if p.Audience == "" {
+		return "", fmt.Errorf("GCPTokenProvider: audience is required")
+	}
+
+	base := p.MetadataServerURL
+	if base == "" {
+		base = defaultGCPMetadataServerURL
+	}
+
+	url := fmt.Sprintf(
+		"%s/computeMetadata/v1/instance/service-accounts/default/identity?audience=%s&format=full",
+		base, p.Audience,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build metadata server request: %w", err)
+	}
+
+	req.Header.Set(gcpMetadataFlavorHeader, "Google")
+
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach GCP metadata server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GCP metadata server returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read metadata server response: %w", err)
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// InteractiveOIDCTokenProvider defers token acquisition to a caller-supplied
+// function, e.g. a browser-based login flow or an existing cached session
+// store (such as the CLI's hub login session). Kept decoupled from any
+// specific flow so this package has no dependency on CLI/hub-specific code.
+type InteractiveOIDCTokenProvider struct {
+	// Fetch performs the interactive flow (or reads a cached session) and
+	// returns the resulting ID token.
+	Fetch func(ctx context.Context) (string, error)
+}
+
+// Token implements TokenProvider.
+func (p *InteractiveOIDCTokenProvider) Token(ctx context.Context) (string, error) {
+	if p.Fetch == nil {
+		return "", fmt.Errorf("InteractiveOIDCTokenProvider: Fetch is required")
+	}
+
+	return p.Fetch(ctx)
+}
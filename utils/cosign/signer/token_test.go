@@ -0,0 +1,139 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeToken(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	return path
+}
+
+func TestAWSTokenProvider(t *testing.T) {
+	p := &AWSTokenProvider{TokenFilePath: writeToken(t, "aws-token\n")}
+
+	token, err := p.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+
+	if token != "aws-token" {
+		t.Errorf("expected trimmed token, got %q", token)
+	}
+}
+
+func TestAzureTokenProvider(t *testing.T) {
+	p := &AzureTokenProvider{TokenFilePath: writeToken(t, "azure-token")}
+
+	token, err := p.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+
+	if token != "azure-token" {
+		t.Errorf("expected %q, got %q", "azure-token", token)
+	}
+}
+
+func TestK8sSATokenProvider(t *testing.T) {
+	p := &K8sSATokenProvider{TokenFilePath: writeToken(t, "k8s-token")}
+
+	token, err := p.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+
+	if token != "k8s-token" {
+		t.Errorf("expected %q, got %q", "k8s-token", token)
+	}
+}
+
+func TestFileTokenProvider_MissingPath(t *testing.T) {
+	p := &AWSTokenProvider{}
+
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "")
+
+	if _, err := p.Token(context.Background()); err == nil {
+		t.Fatal("expected an error when no token file is configured")
+	}
+}
+
+func TestGCPTokenProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(gcpMetadataFlavorHeader) != "Google" {
+			http.Error(w, "missing metadata flavor header", http.StatusBadRequest)
+
+			return
+		}
+
+		if r.URL.Query().Get("audience") != "sigstore" {
+			http.Error(w, "missing audience", http.StatusBadRequest)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("gcp-id-token\n"))
+	}))
+	defer srv.Close()
+
+	p := &GCPTokenProvider{Audience: "sigstore", MetadataServerURL: srv.URL}
+
+	token, err := p.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+
+	if token != "gcp-id-token" {
+		t.Errorf("expected %q, got %q", "gcp-id-token", token)
+	}
+}
+
+func TestGCPTokenProvider_RequiresAudience(t *testing.T) {
+	p := &GCPTokenProvider{}
+
+	if _, err := p.Token(context.Background()); err == nil {
+		t.Fatal("expected an error when Audience is empty")
+	}
+}
+
+func TestInteractiveOIDCTokenProvider(t *testing.T) {
+	p := &InteractiveOIDCTokenProvider{
+		Fetch: func(context.Context) (string, error) { return "interactive-token", nil },
+	}
+
+	token, err := p.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+
+	if token != "interactive-token" {
+		t.Errorf("expected %q, got %q", "interactive-token", token)
+	}
+
+	failing := &InteractiveOIDCTokenProvider{
+		Fetch: func(context.Context) (string, error) { return "", errors.New("login cancelled") },
+	}
+
+	if _, err := failing.Token(context.Background()); err == nil {
+		t.Fatal("expected Fetch's error to propagate")
+	}
+
+	if _, err := (&InteractiveOIDCTokenProvider{}).Token(context.Background()); err == nil {
+		t.Fatal("expected an error when Fetch is nil")
+	}
+}
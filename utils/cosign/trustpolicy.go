@@ -0,0 +1,211 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SignatureVerificationLevel controls how strictly a TrustPolicy's identity
+// and verifier requirements are enforced.
+type SignatureVerificationLevel string
+
+const (
+	// SignatureVerificationStrict requires the signature to match one of the
+	// policy's AllowedIdentities and satisfy RequiredVerifiers.
+	SignatureVerificationStrict SignatureVerificationLevel = "strict"
+
+	// SignatureVerificationPermissive requires a valid Sigstore signature but
+	// does not require the identity to match AllowedIdentities; a match is
+	// still reported when one exists.
+	SignatureVerificationPermissive SignatureVerificationLevel = "permissive"
+
+	// SignatureVerificationSkip accepts the artifact without performing any
+	// cryptographic verification. Intended for migration/bootstrap scopes
+	// only - use with care.
+	SignatureVerificationSkip SignatureVerificationLevel = "skip"
+)
+
+// RequiredVerifiers declares which Sigstore verification sources a policy
+// demands before a signature is accepted.
+type RequiredVerifiers struct {
+	// Fulcio requires the signing certificate to chain to a Fulcio root.
+	// This is implicit in keyless verification and is informational for now.
+	Fulcio bool `json:"fulcio" yaml:"fulcio"`
+
+	// Rekor requires a transparency log inclusion proof.
+	Rekor bool `json:"rekor" yaml:"rekor"`
+
+	// TSA requires an RFC 3161 signed timestamp from a Timestamp Authority.
+	TSA bool `json:"tsa" yaml:"tsa"`
+}
+
+// AllowedIdentity describes one OIDC identity a TrustPolicy accepts. Issuer
+// and Subject are exact matches; IssuerRegexp and SubjectRegexp are used when
+// the corresponding exact field is empty, mirroring the exact-match-first
+// convention used by VerifyOIDCOptions.
+type AllowedIdentity struct {
+	Issuer        string `json:"issuer,omitempty"        yaml:"issuer,omitempty"`
+	IssuerRegexp  string `json:"issuerRegexp,omitempty"  yaml:"issuerRegexp,omitempty"`
+	Subject       string `json:"subject,omitempty"       yaml:"subject,omitempty"`
+	SubjectRegexp string `json:"subjectRegexp,omitempty" yaml:"subjectRegexp,omitempty"`
+}
+
+// TrustPolicy is a named rule set describing which signers are trusted for a
+// given class of artifact.
+type TrustPolicy struct {
+	// Name identifies the policy for logging and for VerifyOIDCResult.MatchedPolicy.
+	Name string `json:"name" yaml:"name"`
+
+	// Scope is a regular expression matched against VerifyOIDCOptions.ArtifactScope
+	// (e.g. a record CID prefix or an object kind) to decide whether this
+	// policy applies to a given artifact. An empty Scope matches anything.
+	Scope string `json:"scope,omitempty" yaml:"scope,omitempty"`
+
+	// AllowedIdentities lists the OIDC identities this policy accepts. At
+	// least one must match under SignatureVerificationStrict.
+	AllowedIdentities []AllowedIdentity `json:"allowedIdentities,omitempty" yaml:"allowedIdentities,omitempty"`
+
+	// SignatureVerification controls how strictly this policy is enforced.
+	// Defaults to SignatureVerificationStrict when empty.
+	SignatureVerification SignatureVerificationLevel `json:"signatureVerification,omitempty" yaml:"signatureVerification,omitempty"`
+
+	// RequiredVerifiers declares which Sigstore verification sources must
+	// back the signature.
+	RequiredVerifiers RequiredVerifiers `json:"requiredVerifiers,omitempty" yaml:"requiredVerifiers,omitempty"`
+
+	scopeRe *regexp.Regexp
+}
+
+// TrustPolicyStore holds a set of named TrustPolicy entries, tried in order.
+type TrustPolicyStore struct {
+	Policies []TrustPolicy `json:"policies" yaml:"policies"`
+}
+
+// LoadTrustPolicyStore reads a TrustPolicyStore from a YAML or JSON file,
+// chosen by the path's extension (.json is parsed as JSON, anything else as
+// YAML, since YAML is a superset of JSON).
+func LoadTrustPolicyStore(path string) (*TrustPolicyStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust policy file: %w", err)
+	}
+
+	var store TrustPolicyStore
+	if err := yaml.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("failed to parse trust policy file: %w", err)
+	}
+
+	for i := range store.Policies {
+		if err := store.Policies[i].compile(); err != nil {
+			return nil, fmt.Errorf("policy %q: %w", store.Policies[i].Name, err)
+		}
+	}
+
+	return &store, nil
+}
+
+// compile pre-parses the policy's Scope regexp so SelectPolicy doesn't
+// recompile it on every call.
+func (p *TrustPolicy) compile() error {
+	if p.Scope == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(p.Scope)
+	if err != nil {
+		return fmt.Errorf("invalid scope regexp %q: %w", p.Scope, err)
+	}
+
+	p.scopeRe = re
+
+	return nil
+}
+
+// matchesScope reports whether the policy applies to the given artifact
+// scope. An empty Scope matches everything.
+func (p *TrustPolicy) matchesScope(scope string) bool {
+	if p.scopeRe == nil {
+		return true
+	}
+
+	return p.scopeRe.MatchString(scope)
+}
+
+// level returns the policy's enforcement level, defaulting to strict.
+func (p *TrustPolicy) level() SignatureVerificationLevel {
+	if p.SignatureVerification == "" {
+		return SignatureVerificationStrict
+	}
+
+	return p.SignatureVerification
+}
+
+// matches reports whether issuer/identity satisfies one of the policy's
+// AllowedIdentities, returning the matching entry.
+func (p *TrustPolicy) matches(issuer, identity string) (*AllowedIdentity, bool) {
+	for i := range p.AllowedIdentities {
+		allowed := p.AllowedIdentities[i]
+
+		if !matchesField(issuer, allowed.Issuer, allowed.IssuerRegexp) {
+			continue
+		}
+
+		if !matchesField(identity, allowed.Subject, allowed.SubjectRegexp) {
+			continue
+		}
+
+		return &allowed, true
+	}
+
+	return nil, false
+}
+
+// matchesField checks value against an exact match (preferred) or, if exact
+// is empty, a regexp. An empty exact and empty pattern matches anything.
+func matchesField(value, exact, pattern string) bool {
+	if exact != "" {
+		return value == exact
+	}
+
+	if pattern == "" {
+		return true
+	}
+
+	matched, err := regexp.MatchString(pattern, value)
+
+	return err == nil && matched
+}
+
+// SelectPolicy returns the first policy whose Scope matches the given
+// artifact scope (e.g. a record CID or object kind). Policies are tried in
+// the order they appear in the store.
+func (s *TrustPolicyStore) SelectPolicy(scope string) (*TrustPolicy, bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	for i := range s.Policies {
+		if s.Policies[i].matchesScope(scope) {
+			return &s.Policies[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// String implements fmt.Stringer for logging.
+func (p *TrustPolicy) String() string {
+	identities := make([]string, 0, len(p.AllowedIdentities))
+	for _, a := range p.AllowedIdentities {
+		identities = append(identities, fmt.Sprintf("issuer=%s subject=%s", a.Issuer+a.IssuerRegexp, a.Subject+a.SubjectRegexp))
+	}
+
+	return fmt.Sprintf("%s[%s](%s)", p.Name, p.level(), strings.Join(identities, ", "))
+}
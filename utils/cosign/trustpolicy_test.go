@@ -0,0 +1,102 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTrustPolicyStore(t *testing.T) {
+	yaml := `
+policies:
+  - name: records
+    scope: "^baeabc.*"
+    signatureVerification: strict
+    requiredVerifiers:
+      rekor: true
+    allowedIdentities:
+      - issuer: https://token.actions.githubusercontent.com
+        subjectRegexp: "^https://github.com/agntcy/.*$"
+  - name: catch-all
+    signatureVerification: permissive
+`
+
+	path := filepath.Join(t.TempDir(), "trustpolicy.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("failed to write policy file: %v", err)
+	}
+
+	store, err := LoadTrustPolicyStore(path)
+	if err != nil {
+		t.Fatalf("LoadTrustPolicyStore failed: %v", err)
+	}
+
+	if len(store.Policies) != 2 {
+		t.Fatalf("expected 2 policies, got %d", len(store.Policies))
+	}
+
+	policy, ok := store.SelectPolicy("baeabcdef123")
+	if !ok || policy.Name != "records" {
+		t.Fatalf("expected scoped policy %q to be selected, got %+v", "records", policy)
+	}
+
+	policy, ok = store.SelectPolicy("something-else")
+	if !ok || policy.Name != "catch-all" {
+		t.Fatalf("expected catch-all policy to be selected, got %+v", policy)
+	}
+}
+
+func TestTrustPolicyMatches(t *testing.T) {
+	store, err := LoadTrustPolicyStore(writeTempPolicy(t, `
+policies:
+  - name: records
+    allowedIdentities:
+      - issuer: https://token.actions.githubusercontent.com
+        subjectRegexp: "^https://github.com/agntcy/.*$"
+`))
+	if err != nil {
+		t.Fatalf("LoadTrustPolicyStore failed: %v", err)
+	}
+
+	policy, ok := store.SelectPolicy("")
+	if !ok {
+		t.Fatal("expected a policy to be selected")
+	}
+
+	if _, ok := policy.matches("https://token.actions.githubusercontent.com", "https://github.com/agntcy/dir/.github/workflows/ci.yml@refs/heads/main"); !ok {
+		t.Error("expected matching issuer/subject to satisfy policy")
+	}
+
+	if _, ok := policy.matches("https://accounts.google.com", "someone@example.com"); ok {
+		t.Error("expected non-matching issuer to be rejected")
+	}
+}
+
+func TestTrustPolicyStore_SelectPolicy_NoMatch(t *testing.T) {
+	store, err := LoadTrustPolicyStore(writeTempPolicy(t, `
+policies:
+  - name: records-only
+    scope: "^baeabc.*"
+`))
+	if err != nil {
+		t.Fatalf("LoadTrustPolicyStore failed: %v", err)
+	}
+
+	if _, ok := store.SelectPolicy("not-a-record"); ok {
+		t.Error("expected no policy to match an out-of-scope artifact")
+	}
+}
+
+func writeTempPolicy(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "trustpolicy.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write policy file: %v", err)
+	}
+
+	return path
+}
@@ -6,9 +6,13 @@ package cosign
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"time"
 
+	rekorv1 "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/sigstore/sigstore-go/pkg/bundle"
 	"github.com/sigstore/sigstore-go/pkg/verify"
 )
@@ -30,6 +34,25 @@ type VerifyOIDCOptions struct {
 	// If empty, identity is not verified.
 	ExpectedIdentity string
 
+	// ExpectedIssuerRegexp is a regular expression the OIDC issuer URL must
+	// match. Ignored if ExpectedIssuer is set. Used for keyless verification,
+	// where the exact issuer isn't known in advance.
+	ExpectedIssuerRegexp string
+
+	// ExpectedIdentityRegexp is a regular expression the OIDC subject/identity
+	// must match. Ignored if ExpectedIdentity is set. Used for keyless
+	// verification, where the exact identity isn't known in advance.
+	ExpectedIdentityRegexp string
+
+	// AllowedIdentities, if non-empty, replaces ExpectedIssuer/ExpectedIdentity/
+	// ExpectedIssuerRegexp/ExpectedIdentityRegexp with a list of acceptable
+	// identities: verification succeeds if the certificate matches any one of
+	// them (e.g. any GitHub Actions workflow under a given org, tagged
+	// https://github.com/ORG/*/.github/workflows/*.yml@refs/tags/v*, signed
+	// via https://token.actions.githubusercontent.com). The matching entry is
+	// reported on VerifyOIDCResult.MatchedIdentity.
+	AllowedIdentities []AllowedIdentity
+
 	// TrustRoot is the trust root configuration.
 	// If nil, uses Sigstore public good instance (or staging if UseStaging is true).
 	TrustRoot *TrustRootConfig
@@ -37,6 +60,48 @@ type VerifyOIDCOptions struct {
 	// UseStaging uses the Sigstore staging environment trust root.
 	// This should be true when verifying signatures created with staging.
 	UseStaging bool
+
+	// TrustPolicyStore, if set, is consulted to select a TrustPolicy for
+	// ArtifactScope. When a policy is found, it takes precedence over
+	// ExpectedIssuer/ExpectedIdentity/ExpectedIssuerRegexp/ExpectedIdentityRegexp:
+	// the signature must match one of the policy's AllowedIdentities (unless
+	// the policy is permissive or skip), and the policy's RequiredVerifiers
+	// controls which Sigstore verification sources are demanded.
+	TrustPolicyStore *TrustPolicyStore
+
+	// ArtifactScope identifies the artifact being verified (e.g. a record CID
+	// or object kind), used to select a policy from TrustPolicyStore.
+	ArtifactScope string
+
+	// MinSignedTimestamps is the number of TSA (RFC3161) signed timestamps
+	// the bundle must carry. Defaults to 1 when both this and
+	// MinIntegratedTimestamps are zero, preserving the pre-existing
+	// behavior. Ignored when TrustPolicyStore selects a policy - the
+	// policy's RequiredVerifiers takes precedence.
+	MinSignedTimestamps int
+
+	// MinIntegratedTimestamps is the number of Rekor integrated timestamps
+	// (the transparency log entry's own inclusion time) the bundle must
+	// carry. Set this instead of, or alongside, MinSignedTimestamps to
+	// accept bundles that only have a Rekor inclusion proof and no TSA
+	// countersignature.
+	MinIntegratedTimestamps int
+
+	// RequireTransparencyLog demands the bundle carry a verified Rekor
+	// transparency log entry, independent of timestamp verification. This is
+	// a convenience equivalent to setting TransparencyLogThreshold >= 1.
+	RequireTransparencyLog bool
+
+	// TransparencyLogThreshold is the number of Rekor transparency log
+	// entries required to back the signature. If zero and
+	// RequireTransparencyLog is true, defaults to 1.
+	TransparencyLogThreshold int
+
+	// RekorOnlineVerification, when true, verifies the transparency log
+	// entry by contacting Rekor directly instead of relying solely on the
+	// inclusion proof already embedded in the bundle. Leave false for the
+	// common case of a fully offline-verifiable bundle.
+	RekorOnlineVerification bool
 }
 
 // VerifyOIDCResult contains the result of OIDC signature verification.
@@ -52,6 +117,32 @@ type VerifyOIDCResult struct {
 
 	// Certificate is the PEM-encoded signing certificate.
 	Certificate string
+
+	// MatchedPolicy is the Name of the TrustPolicy that was selected from
+	// VerifyOIDCOptions.TrustPolicyStore, if any.
+	MatchedPolicy string
+
+	// MatchedIdentity is the AllowedIdentity entry of MatchedPolicy that the
+	// certificate's issuer/identity satisfied, if any.
+	MatchedIdentity *AllowedIdentity
+
+	// TSATimestamps are the timestamps the verifier actually validated
+	// (RFC3161 TSA signed timestamps and/or Rekor integrated timestamps,
+	// per VerificationResult.VerifiedTimestamps), not merely present in the
+	// bundle unverified.
+	TSATimestamps []time.Time
+
+	// RekorLogIndexes are the Rekor transparency log indexes of the
+	// bundle's TlogEntries, confirming which log positions were verified.
+	RekorLogIndexes []int64
+
+	// RekorEntryUUIDs are the Rekor entry UUIDs derived from the bundle's
+	// TlogEntries, following Rekor's own convention of a log ID prefix
+	// followed by the RFC6962 leaf hash of the entry's canonicalized body.
+	// Sigstore bundles don't carry the UUID directly, so this is computed
+	// rather than read off a field - it will be empty if a TlogEntry is
+	// missing the canonicalized body or log ID needed to derive it.
+	RekorEntryUUIDs []string
 }
 
 // VerifySignatureWithOIDC verifies a Sigstore bundle using OIDC identity.
@@ -73,6 +164,23 @@ func VerifySignatureWithOIDC(ctx context.Context, opts *VerifyOIDCOptions) (*Ver
 		return nil, errors.New("expected payload is required")
 	}
 
+	// Select a trust policy for this artifact, if a store was given. A
+	// skip-level policy bypasses Sigstore verification entirely.
+	var trustPolicy *TrustPolicy
+
+	if opts.TrustPolicyStore != nil {
+		if p, ok := opts.TrustPolicyStore.SelectPolicy(opts.ArtifactScope); ok {
+			trustPolicy = p
+		}
+	}
+
+	if trustPolicy != nil && trustPolicy.level() == SignatureVerificationSkip {
+		return &VerifyOIDCResult{
+			Verified:      true,
+			MatchedPolicy: trustPolicy.Name,
+		}, nil
+	}
+
 	// Parse the bundle
 	b := &bundle.Bundle{}
 	if err := b.UnmarshalJSON([]byte(opts.BundleJSON)); err != nil {
@@ -105,8 +213,24 @@ func VerifySignatureWithOIDC(ctx context.Context, opts *VerifyOIDCOptions) (*Ver
 	// - Signed timestamps from TSA (Timestamp Authority)
 	// - Integrated timestamps from Rekor transparency log
 	// We accept any one of these as valid
-	verifyOpts := []verify.VerifierOption{
-		verify.WithSignedTimestamps(1), // Use TSA signed timestamps from bundle
+	verifyOpts := verifierOptions(opts)
+
+	// A policy's RequiredVerifiers narrows or extends which Sigstore
+	// verification sources are demanded, in place of the options above.
+	if trustPolicy != nil {
+		var required []verify.VerifierOption
+
+		if trustPolicy.RequiredVerifiers.TSA {
+			required = append(required, verify.WithSignedTimestamps(1))
+		}
+
+		if trustPolicy.RequiredVerifiers.Rekor {
+			required = append(required, verify.WithTransparencyLog(1))
+		}
+
+		if len(required) > 0 {
+			verifyOpts = required
+		}
 	}
 
 	// Create the verifier
@@ -115,46 +239,37 @@ func VerifySignatureWithOIDC(ctx context.Context, opts *VerifyOIDCOptions) (*Ver
 		return nil, fmt.Errorf("failed to create verifier: %w", err)
 	}
 
-	// Build policy for identity verification
-	policyOpts := []verify.PolicyOption{}
+	// Candidate identities to try, in order. A trust policy with
+	// AllowedIdentities overrides the single Expected* identity - each
+	// allowed identity is tried until one verifies, aggregating the policy's
+	// acceptable signers into one verification pass.
+	candidates, err := certificateIdentityCandidates(opts, trustPolicy)
+	if err != nil {
+		return nil, err
+	}
 
-	// Add identity policy - if issuer/identity are specified, use exact match
-	// Otherwise use a permissive match that accepts any identity
-	if opts.ExpectedIssuer != "" || opts.ExpectedIdentity != "" {
-		certIdentity, err := verify.NewShortCertificateIdentity(
-			opts.ExpectedIssuer,
-			"", // issuer regexp (not used - exact match only)
-			opts.ExpectedIdentity,
-			"", // identity regexp (not used - exact match only)
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create certificate identity: %w", err)
-		}
+	var (
+		result    *verify.VerificationResult
+		verifyErr error
+		matched   *AllowedIdentity
+	)
 
-		policyOpts = append(policyOpts, verify.WithCertificateIdentity(certIdentity))
-	} else {
-		// Use permissive identity that matches any issuer/identity
-		certIdentity, err := verify.NewShortCertificateIdentity(
-			"",   // any issuer
-			".*", // issuer regexp - match anything
-			"",   // any identity
-			".*", // identity regexp - match anything
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create permissive certificate identity: %w", err)
-		}
+	for _, candidate := range candidates {
+		policyOpts := []verify.PolicyOption{verify.WithCertificateIdentity(candidate.identity)}
+		policy := verify.NewPolicy(verify.WithArtifact(bytes.NewReader(opts.ExpectedPayload)), policyOpts...)
 
-		policyOpts = append(policyOpts, verify.WithCertificateIdentity(certIdentity))
-	}
+		result, verifyErr = verifier.Verify(b, policy)
+		if verifyErr == nil {
+			matched = candidate.allowed
 
-	policy := verify.NewPolicy(verify.WithArtifact(bytes.NewReader(opts.ExpectedPayload)), policyOpts...)
+			break
+		}
+	}
 
-	// Verify the bundle
-	result, err := verifier.Verify(b, policy)
-	if err != nil {
+	if verifyErr != nil {
 		return &VerifyOIDCResult{
 			Verified: false,
-		}, fmt.Errorf("verification failed: %w", err)
+		}, fmt.Errorf("verification failed: %w", verifyErr)
 	}
 
 	// Extract OIDC info from the verified certificate
@@ -162,6 +277,12 @@ func VerifySignatureWithOIDC(ctx context.Context, opts *VerifyOIDCOptions) (*Ver
 		Verified: true,
 	}
 
+	if trustPolicy != nil {
+		verifyResult.MatchedPolicy = trustPolicy.Name
+	}
+
+	verifyResult.MatchedIdentity = matched
+
 	// Get certificate from verification result
 	if result.Signature != nil && result.Signature.Certificate != nil {
 		// Extract OIDC info using our helper
@@ -180,9 +301,194 @@ func VerifySignatureWithOIDC(ctx context.Context, opts *VerifyOIDCOptions) (*Ver
 		}
 	}
 
+	populateTransparencyProvenance(verifyResult, result, b)
+
 	return verifyResult, nil
 }
 
+// verifierOptions translates the direct timestamp/transparency-log fields on
+// opts into verify.VerifierOption. A trust policy's RequiredVerifiers, when
+// present, is applied afterwards by the caller and takes precedence over
+// these. When none of the new fields are set, this preserves the pre-existing
+// default of requiring at least one TSA signed timestamp.
+func verifierOptions(opts *VerifyOIDCOptions) []verify.VerifierOption {
+	var verifyOpts []verify.VerifierOption
+
+	if opts.MinSignedTimestamps > 0 {
+		verifyOpts = append(verifyOpts, verify.WithSignedTimestamps(opts.MinSignedTimestamps))
+	}
+
+	if opts.MinIntegratedTimestamps > 0 {
+		verifyOpts = append(verifyOpts, verify.WithIntegratedTimestamps(opts.MinIntegratedTimestamps))
+	}
+
+	threshold := opts.TransparencyLogThreshold
+	if threshold == 0 && opts.RequireTransparencyLog {
+		threshold = 1
+	}
+
+	if threshold > 0 {
+		verifyOpts = append(verifyOpts, verify.WithTransparencyLog(threshold))
+	}
+
+	if opts.RekorOnlineVerification {
+		verifyOpts = append(verifyOpts, verify.WithOnlineVerification())
+	}
+
+	if len(verifyOpts) == 0 {
+		// No explicit configuration: keep the original default of demanding a
+		// TSA signed timestamp from the bundle.
+		verifyOpts = append(verifyOpts, verify.WithSignedTimestamps(1))
+	}
+
+	return verifyOpts
+}
+
+// populateTransparencyProvenance fills in verifyResult's TSATimestamps,
+// RekorLogIndexes, and RekorEntryUUIDs from the verifier's result and the
+// bundle's own verification material, so downstream storage/audit code can
+// persist which timestamp and transparency log sources actually backed a
+// successful verification.
+func populateTransparencyProvenance(verifyResult *VerifyOIDCResult, result *verify.VerificationResult, b *bundle.Bundle) {
+	for _, ts := range result.VerifiedTimestamps {
+		verifyResult.TSATimestamps = append(verifyResult.TSATimestamps, ts.Timestamp)
+	}
+
+	vm := b.VerificationMaterial
+	if vm == nil {
+		return
+	}
+
+	for _, entry := range vm.GetTlogEntries() {
+		verifyResult.RekorLogIndexes = append(verifyResult.RekorLogIndexes, entry.GetLogIndex())
+
+		if uuid := rekorEntryUUID(entry); uuid != "" {
+			verifyResult.RekorEntryUUIDs = append(verifyResult.RekorEntryUUIDs, uuid)
+		}
+	}
+}
+
+// rekorEntryUUID derives a Rekor entry UUID from entry, following Rekor's own
+// convention: a 16 hex character log ID prefix (the tree's public key hash,
+// truncated) followed by the 64 hex character RFC6962 leaf hash of the
+// entry's canonicalized body. The bundle's TransparencyLogEntry doesn't carry
+// the UUID directly, so this recomputes it rather than fabricating one; it
+// returns "" if entry is missing the fields needed to do so.
+func rekorEntryUUID(entry *rekorv1.TransparencyLogEntry) string {
+	logID := entry.GetLogId().GetKeyId()
+	body := entry.GetCanonicalizedBody()
+
+	if len(logID) == 0 || len(body) == 0 {
+		return ""
+	}
+
+	leafHash := sha256.Sum256(append([]byte{0x00}, body...))
+
+	treePrefix := logID
+	if len(treePrefix) > 8 {
+		treePrefix = treePrefix[:8]
+	}
+
+	return hex.EncodeToString(treePrefix) + hex.EncodeToString(leafHash[:])
+}
+
+// identityCandidate pairs a Sigstore certificate identity with the
+// AllowedIdentity it was built from, so a successful match can be reported
+// back on VerifyOIDCResult.MatchedIdentity.
+type identityCandidate struct {
+	identity verify.CertificateIdentity
+	allowed  *AllowedIdentity
+}
+
+// certificateIdentityCandidates builds the list of identities to try against
+// the bundle. When trustPolicy has AllowedIdentities, each one becomes a
+// candidate - this is the "aggregate results across multiple acceptable
+// identities" behavior a TrustPolicy provides. A permissive policy also
+// appends a catch-all candidate, so a validly-signed bundle is still
+// accepted when its identity matches none of the policy's entries, just
+// without MatchedIdentity set. Otherwise, if opts.AllowedIdentities is set,
+// each entry becomes a candidate the same way, without requiring a
+// TrustPolicyStore - this is the ad hoc equivalent for a caller that already
+// knows its acceptable identities (e.g. "any GitHub Actions workflow under
+// this org"). With neither, the single Expected* identity from opts is used,
+// preserving the pre-existing behavior.
+func certificateIdentityCandidates(opts *VerifyOIDCOptions, trustPolicy *TrustPolicy) ([]identityCandidate, error) {
+	if trustPolicy != nil && len(trustPolicy.AllowedIdentities) > 0 {
+		candidates, err := candidatesFromAllowedIdentities(trustPolicy.AllowedIdentities, trustPolicy.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if trustPolicy.level() == SignatureVerificationPermissive {
+			catchAll, err := verify.NewShortCertificateIdentity("", ".*", "", ".*")
+			if err != nil {
+				return nil, fmt.Errorf("failed to create catch-all certificate identity: %w", err)
+			}
+
+			candidates = append(candidates, identityCandidate{identity: catchAll})
+		}
+
+		return candidates, nil
+	}
+
+	if len(opts.AllowedIdentities) > 0 {
+		return candidatesFromAllowedIdentities(opts.AllowedIdentities, "AllowedIdentities")
+	}
+
+	// Build policy for identity verification. Exact-match fields take
+	// priority; a regexp is used when no exact match was given for that
+	// field (this is how keyless verification pins identity/issuer without
+	// knowing the exact values in advance), and an unconstrained field falls
+	// back to matching any value.
+	identity, err := verify.NewShortCertificateIdentity(
+		opts.ExpectedIssuer, orMatchAny(opts.ExpectedIssuer, opts.ExpectedIssuerRegexp),
+		opts.ExpectedIdentity, orMatchAny(opts.ExpectedIdentity, opts.ExpectedIdentityRegexp),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate identity: %w", err)
+	}
+
+	return []identityCandidate{{identity: identity}}, nil
+}
+
+// candidatesFromAllowedIdentities builds one identityCandidate per entry in
+// identities, so the caller can try each in turn and report which matched.
+// label is used only in error messages to identify the source of identities.
+func candidatesFromAllowedIdentities(identities []AllowedIdentity, label string) ([]identityCandidate, error) {
+	candidates := make([]identityCandidate, 0, len(identities))
+
+	for i := range identities {
+		allowed := identities[i]
+
+		identity, err := verify.NewShortCertificateIdentity(
+			allowed.Issuer, orMatchAny(allowed.Issuer, allowed.IssuerRegexp),
+			allowed.Subject, orMatchAny(allowed.Subject, allowed.SubjectRegexp),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create certificate identity for %q: %w", label, err)
+		}
+
+		candidates = append(candidates, identityCandidate{identity: identity, allowed: &allowed})
+	}
+
+	return candidates, nil
+}
+
+// orMatchAny returns regexp unless exact is set (in which case the regexp is
+// ignored, matching verify.NewShortCertificateIdentity's own precedence) or
+// both are empty, in which case any value matches.
+func orMatchAny(exact, regexp string) string {
+	if exact != "" {
+		return regexp
+	}
+
+	if regexp == "" {
+		return ".*"
+	}
+
+	return regexp
+}
+
 // VerifyBundleSignature verifies just the cryptographic signature in a bundle
 // without full Sigstore verification (no Rekor/Fulcio checks).
 // This is useful for offline verification when you have the certificate.
@@ -0,0 +1,109 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cosign
+
+import (
+	"testing"
+
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	rekorv1 "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+)
+
+func TestCertificateIdentityCandidates_AllowedIdentities(t *testing.T) {
+	opts := &VerifyOIDCOptions{
+		AllowedIdentities: []AllowedIdentity{
+			{Issuer: "https://token.actions.githubusercontent.com", SubjectRegexp: "^https://github.com/agntcy/.*$"},
+			{Issuer: "https://accounts.google.com", Subject: "someone@example.com"},
+		},
+	}
+
+	candidates, err := certificateIdentityCandidates(opts, nil)
+	if err != nil {
+		t.Fatalf("certificateIdentityCandidates failed: %v", err)
+	}
+
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 candidates, got %d", len(candidates))
+	}
+
+	if candidates[0].allowed.Issuer != opts.AllowedIdentities[0].Issuer {
+		t.Errorf("expected first candidate to carry back its AllowedIdentity, got %+v", candidates[0].allowed)
+	}
+}
+
+func TestCertificateIdentityCandidates_FallsBackToExpectedFields(t *testing.T) {
+	opts := &VerifyOIDCOptions{
+		ExpectedIssuer:   "https://token.actions.githubusercontent.com",
+		ExpectedIdentity: "https://github.com/agntcy/dir",
+	}
+
+	candidates, err := certificateIdentityCandidates(opts, nil)
+	if err != nil {
+		t.Fatalf("certificateIdentityCandidates failed: %v", err)
+	}
+
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 candidate, got %d", len(candidates))
+	}
+
+	if candidates[0].allowed != nil {
+		t.Errorf("expected no AllowedIdentity attached to the single Expected* candidate, got %+v", candidates[0].allowed)
+	}
+}
+
+func TestVerifierOptions_DefaultsToSignedTimestamp(t *testing.T) {
+	opts := verifierOptions(&VerifyOIDCOptions{})
+	if len(opts) != 1 {
+		t.Fatalf("expected 1 default option, got %d", len(opts))
+	}
+}
+
+func TestVerifierOptions_HonorsExplicitFields(t *testing.T) {
+	opts := verifierOptions(&VerifyOIDCOptions{
+		MinIntegratedTimestamps: 2,
+		RequireTransparencyLog:  true,
+	})
+
+	if len(opts) != 2 {
+		t.Fatalf("expected 2 options (integrated timestamps + transparency log), got %d", len(opts))
+	}
+}
+
+func TestRekorEntryUUID(t *testing.T) {
+	entry := &rekorv1.TransparencyLogEntry{
+		LogId:             &protocommon.LogId{KeyId: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}},
+		CanonicalizedBody: []byte("entry-body"),
+	}
+
+	uuid := rekorEntryUUID(entry)
+	if len(uuid) != 80 {
+		t.Fatalf("expected an 80 hex character UUID, got %d chars: %q", len(uuid), uuid)
+	}
+
+	if uuid != rekorEntryUUID(entry) {
+		t.Error("expected rekorEntryUUID to be deterministic")
+	}
+}
+
+func TestRekorEntryUUID_MissingFields(t *testing.T) {
+	if uuid := rekorEntryUUID(&rekorv1.TransparencyLogEntry{}); uuid != "" {
+		t.Errorf("expected empty UUID for an entry with no log ID or body, got %q", uuid)
+	}
+}
+
+func TestOrMatchAny(t *testing.T) {
+	tests := []struct {
+		name, exact, pattern, want string
+	}{
+		{"exact wins over pattern", "exact-value", "ignored.*", "ignored.*"},
+		{"empty both matches any", "", "", ".*"},
+		{"pattern used when no exact", "", "^foo.*$", "^foo.*$"},
+	}
+
+	for _, tt := range tests {
+		if got := orMatchAny(tt.exact, tt.pattern); got != tt.want {
+			t.Errorf("%s: orMatchAny(%q, %q) = %q, want %q", tt.name, tt.exact, tt.pattern, got, tt.want)
+		}
+	}
+}